@@ -111,6 +111,7 @@ func main() {
 
 	pipelineInformer := pipelineInformerFactory.Tekton().V1alpha1().Pipelines()
 	pipelineRunInformer := pipelineInformerFactory.Tekton().V1alpha1().PipelineRuns()
+	runInformer := pipelineInformerFactory.Tekton().V1alpha1().Runs()
 	timeoutHandler := reconciler.NewTimeoutHandler(kubeClient, pipelineClient, stopCh, logger)
 
 	trc := taskrun.NewController(opt,
@@ -129,6 +130,7 @@ func main() {
 		clusterTaskInformer,
 		taskRunInformer,
 		resourceInformer,
+		runInformer,
 		timeoutHandler,
 	)
 	// Build all of our controllers, with the clients constructed above.
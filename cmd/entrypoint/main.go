@@ -17,34 +17,56 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
 )
 
 var (
-	ep       = flag.String("entrypoint", "", "Original specified entrypoint to execute")
-	waitFile = flag.String("wait_file", "", "If specified, file to wait for")
-	postFile = flag.String("post_file", "", "If specified, file to write upon completion")
+	ep         = flag.String("entrypoint", "", "Original specified entrypoint to execute")
+	waitFile   = flag.String("wait_file", "", "If specified, file to wait for")
+	postFile   = flag.String("post_file", "", "If specified, file to write upon completion")
+	resultsDir = flag.String("results_dir", "", "If specified, directory containing the results to report upon completion")
+	timeout    = flag.Duration("timeout", 0, "If specified, duration after which the command is killed")
+	onError    = flag.String("on_error", "", "If continue, the exit code of the command is reported and the next steps are run when it fails")
+)
+
+const (
+	// terminationMessagePath is where the results are reported, so that they
+	// end up in the status of the container.
+	terminationMessagePath = "/dev/termination-log"
+	// maxTerminationMessageSize is the size in bytes above which Kubernetes
+	// truncates the termination message of a container.
+	maxTerminationMessageSize = 4096
 )
 
 func main() {
 	flag.Parse()
 
 	e := entrypoint.Entrypointer{
-		Entrypoint: *ep,
-		WaitFile:   *waitFile,
-		PostFile:   *postFile,
-		Args:       flag.Args(),
-		Waiter:     &RealWaiter{},
-		Runner:     &RealRunner{},
-		PostWriter: &RealPostWriter{},
+		Entrypoint:    *ep,
+		WaitFile:      *waitFile,
+		PostFile:      *postFile,
+		ResultsDir:    *resultsDir,
+		Timeout:       *timeout,
+		OnError:       v1alpha1.OnErrorType(*onError),
+		Args:          flag.Args(),
+		Waiter:        &RealWaiter{},
+		Runner:        &RealRunner{},
+		PostWriter:    &RealPostWriter{},
+		ResultsWriter: &RealResultsWriter{path: terminationMessagePath},
 	}
 	if err := e.Go(); err != nil {
 		switch err.(type) {
@@ -98,13 +120,13 @@ type RealRunner struct{}
 
 var _ entrypoint.Runner = (*RealRunner)(nil)
 
-func (*RealRunner) Run(args ...string) error {
+func (*RealRunner) Run(ctx context.Context, args ...string) error {
 	if len(args) == 0 {
 		return nil
 	}
 	name, args := args[0], args[1:]
 
-	cmd := exec.Command(name, args...)
+	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -128,6 +150,50 @@ func (*RealPostWriter) Write(file string) {
 	}
 }
 
+// RealResultsWriter actually reports the results, by writing them to the
+// termination message of the container, at path.
+type RealResultsWriter struct{ path string }
+
+var _ entrypoint.ResultsWriter = (*RealResultsWriter)(nil)
+
+func (*RealResultsWriter) ReadResults(dir string) ([]v1alpha1.TaskRunResult, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("Reading results from %q: %v", dir, err)
+	}
+	results := []v1alpha1.TaskRunResult{}
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("Reading result %q: %v", f.Name(), err)
+		}
+		results = append(results, v1alpha1.TaskRunResult{
+			Name:  f.Name(),
+			Value: strings.TrimRight(string(b), "\n"),
+		})
+	}
+	return results, nil
+}
+
+func (w *RealResultsWriter) WriteResults(results []v1alpha1.TaskRunResult) error {
+	b, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+	// A truncated termination message can't be parsed, so all of the results
+	// would be lost
+	if len(b) > maxTerminationMessageSize {
+		return fmt.Errorf("Results take %d bytes, which is more than the %d bytes of the termination message", len(b), maxTerminationMessageSize)
+	}
+	if err := ioutil.WriteFile(w.path, b, 0644); err != nil {
+		return fmt.Errorf("Writing results to %q: %v", w.path, err)
+	}
+	return nil
+}
+
 type skipError string
 
 func (e skipError) Error() string {
@@ -0,0 +1,73 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+func TestRealResultsWriter_WriteResults(t *testing.T) {
+	dir, err := ioutil.TempDir("", "entrypoint")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	w := &RealResultsWriter{path: filepath.Join(dir, "termination-log")}
+
+	results := []v1alpha1.TaskRunResult{{Name: "digest", Value: "sha256:1234"}}
+	if err := w.WriteResults(results); err != nil {
+		t.Fatalf("WriteResults: %v", err)
+	}
+	b, err := ioutil.ReadFile(w.path)
+	if err != nil {
+		t.Fatalf("Error reading termination message: %v", err)
+	}
+	var got []v1alpha1.TaskRunResult
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Error parsing termination message %q: %v", b, err)
+	}
+	if d := cmp.Diff(results, got); d != "" {
+		t.Errorf("Unexpected results -want, +got: %s", d)
+	}
+}
+
+func TestRealResultsWriter_WriteResultsTooLarge(t *testing.T) {
+	dir, err := ioutil.TempDir("", "entrypoint")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	w := &RealResultsWriter{path: filepath.Join(dir, "termination-log")}
+
+	// The results fail the step instead of being truncated by Kubernetes
+	results := []v1alpha1.TaskRunResult{{Name: "report", Value: strings.Repeat("a", maxTerminationMessageSize)}}
+	err = w.WriteResults(results)
+	if err == nil || !strings.Contains(err.Error(), "more than the 4096 bytes of the termination message") {
+		t.Errorf("Expected an error about the size of the termination message but got %v", err)
+	}
+	if _, err := os.Stat(w.path); !os.IsNotExist(err) {
+		t.Errorf("Expected no termination message to be written but got %v", err)
+	}
+}
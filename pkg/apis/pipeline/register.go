@@ -23,4 +23,13 @@ const (
 	TaskRunLabelKey     = "/taskRun"
 	PipelineLabelKey    = "/pipeline"
 	PipelineRunLabelKey = "/pipelineRun"
+	// PipelineTaskLabelKey is set on the TaskRuns created by a PipelineRun to
+	// the name of the PipelineTask they run
+	PipelineTaskLabelKey = "/pipelineTask"
+	// ArtifactStorageLabelKey is set on the PipelineRuns, and their TaskRuns,
+	// which use the artifact storage of a previous PipelineRun they rerun
+	ArtifactStorageLabelKey = "/artifactStorage"
+	// AncestorPipelinesAnnotationKey is set on the child PipelineRuns to the
+	// comma separated names of the Pipelines run by the PipelineRuns above them
+	AncestorPipelinesAnnotationKey = "/ancestorPipelines"
 )
@@ -72,7 +72,7 @@ func (b *ArtifactBucket) GetType() string {
 
 // StorageBasePath returns the path to be used to store artifacts in a pipelinerun temporary storage
 func (b *ArtifactBucket) StorageBasePath(pr *PipelineRun) string {
-	return fmt.Sprintf("%s-%s-bucket", pr.Name, pr.Namespace)
+	return fmt.Sprintf("%s-%s-bucket", pr.GetArtifactStorageName(), pr.Namespace)
 }
 
 // GetCopyFromContainerSpec returns a container used to download artifacts from temporary storage
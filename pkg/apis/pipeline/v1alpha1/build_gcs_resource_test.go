@@ -37,7 +37,7 @@ func Test_Invalid_BuildGCSResource(t *testing.T) {
 			},
 			Spec: PipelineResourceSpec{
 				Type: PipelineResourceTypeStorage,
-				Params: []Param{{
+				Params: []ResourceParam{{
 					Name:  "NotLocation",
 					Value: "doesntmatter",
 				}, {
@@ -54,7 +54,7 @@ func Test_Invalid_BuildGCSResource(t *testing.T) {
 			},
 			Spec: PipelineResourceSpec{
 				Type: PipelineResourceTypeStorage,
-				Params: []Param{{
+				Params: []ResourceParam{{
 					Name:  "Location",
 					Value: "",
 				}, {
@@ -71,7 +71,7 @@ func Test_Invalid_BuildGCSResource(t *testing.T) {
 			},
 			Spec: PipelineResourceSpec{
 				Type: PipelineResourceTypeStorage,
-				Params: []Param{{
+				Params: []ResourceParam{{
 					Name:  "Location",
 					Value: "gs://test",
 				}, {
@@ -88,7 +88,7 @@ func Test_Invalid_BuildGCSResource(t *testing.T) {
 			},
 			Spec: PipelineResourceSpec{
 				Type: PipelineResourceTypeStorage,
-				Params: []Param{{
+				Params: []ResourceParam{{
 					Name:  "Location",
 					Value: "gs://test",
 				}, {
@@ -108,7 +108,7 @@ func Test_Invalid_BuildGCSResource(t *testing.T) {
 			},
 			Spec: PipelineResourceSpec{
 				Type: PipelineResourceTypeStorage,
-				Params: []Param{{
+				Params: []ResourceParam{{
 					Name:  "Location",
 					Value: "gs://test",
 				}, {
@@ -128,7 +128,7 @@ func Test_Invalid_BuildGCSResource(t *testing.T) {
 			},
 			Spec: PipelineResourceSpec{
 				Type: PipelineResourceTypeStorage,
-				Params: []Param{{
+				Params: []ResourceParam{{
 					Name:  "Location",
 					Value: "gs://test",
 				}, {
@@ -163,7 +163,7 @@ func Test_Valid_NewBuildGCSResource(t *testing.T) {
 		},
 		Spec: PipelineResourceSpec{
 			Type: PipelineResourceTypeStorage,
-			Params: []Param{{
+			Params: []ResourceParam{{
 				Name:  "Location",
 				Value: "gs://fake-bucket",
 			}, {
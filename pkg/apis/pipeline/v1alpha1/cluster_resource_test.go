@@ -36,7 +36,7 @@ func TestNewClusterResource(t *testing.T) {
 			},
 			Spec: PipelineResourceSpec{
 				Type: PipelineResourceTypeCluster,
-				Params: []Param{{
+				Params: []ResourceParam{{
 					Name:  "name",
 					Value: "test_cluster_resource",
 				}, {
@@ -68,7 +68,7 @@ func TestNewClusterResource(t *testing.T) {
 			},
 			Spec: PipelineResourceSpec{
 				Type: PipelineResourceTypeCluster,
-				Params: []Param{{
+				Params: []ResourceParam{{
 					Name:  "name",
 					Value: "test_cluster_resource",
 				}, {
@@ -104,7 +104,7 @@ func TestNewClusterResource(t *testing.T) {
 			},
 			Spec: PipelineResourceSpec{
 				Type: PipelineResourceTypeCluster,
-				Params: []Param{{
+				Params: []ResourceParam{{
 					Name:  "Name",
 					Value: "test.cluster.resource",
 				}, {
@@ -133,7 +133,7 @@ func TestNewClusterResource(t *testing.T) {
 			},
 			Spec: PipelineResourceSpec{
 				Type: PipelineResourceTypeCluster,
-				Params: []Param{{
+				Params: []ResourceParam{{
 					Name:  "name",
 					Value: "test-cluster-resource",
 				}, {
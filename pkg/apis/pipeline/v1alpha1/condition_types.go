@@ -0,0 +1,77 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ConditionOperator is the operator used to compare the input of a
+// ConditionExpression with its values.
+type ConditionOperator string
+
+const (
+	// ConditionOperatorIn indicates that the input must be one of the values.
+	ConditionOperatorIn ConditionOperator = "in"
+	// ConditionOperatorNotIn indicates that the input must not be any of the values.
+	ConditionOperatorNotIn ConditionOperator = "notin"
+)
+
+// PipelineTaskCondition guards the execution of a PipelineTask. The PipelineTask
+// (and any PipelineTasks that depend on it) will only be run if the condition is met.
+// Exactly one of Check and Expression must be specified.
+type PipelineTaskCondition struct {
+	// Name identifies the condition within the PipelineTask.
+	Name string `json:"name"`
+	// Check is a container which is run before the PipelineTask; the condition
+	// is met if the container exits successfully.
+	// +optional
+	Check *corev1.Container `json:"check,omitempty"`
+	// Expression is evaluated by the controller before the PipelineTask is run.
+	// +optional
+	Expression *ConditionExpression `json:"expression,omitempty"`
+}
+
+// ConditionExpression compares an input, usually a templated parameter such as
+// ${params.branch}, against a list of values.
+type ConditionExpression struct {
+	Input    string            `json:"input"`
+	Operator ConditionOperator `json:"operator"`
+	Values   []string          `json:"values"`
+}
+
+// IsTrue returns true if the input of the expression satisfies its operator and values.
+func (e *ConditionExpression) IsTrue() bool {
+	found := false
+	for _, v := range e.Values {
+		if v == e.Input {
+			found = true
+			break
+		}
+	}
+	if e.Operator == ConditionOperatorNotIn {
+		return !found
+	}
+	return found
+}
+
+// String returns a human readable version of the expression.
+func (e *ConditionExpression) String() string {
+	return fmt.Sprintf("%q %s %v", e.Input, e.Operator, e.Values)
+}
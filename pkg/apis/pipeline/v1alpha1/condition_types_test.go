@@ -0,0 +1,50 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "testing"
+
+func TestConditionExpression_IsTrue(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression ConditionExpression
+		want       bool
+	}{{
+		name:       "in matching value",
+		expression: ConditionExpression{Input: "main", Operator: ConditionOperatorIn, Values: []string{"release", "main"}},
+		want:       true,
+	}, {
+		name:       "in no matching value",
+		expression: ConditionExpression{Input: "feature", Operator: ConditionOperatorIn, Values: []string{"release", "main"}},
+		want:       false,
+	}, {
+		name:       "notin matching value",
+		expression: ConditionExpression{Input: "main", Operator: ConditionOperatorNotIn, Values: []string{"main"}},
+		want:       false,
+	}, {
+		name:       "notin no matching value",
+		expression: ConditionExpression{Input: "feature", Operator: ConditionOperatorNotIn, Values: []string{"main"}},
+		want:       true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.expression.IsTrue(); got != tc.want {
+				t.Errorf("IsTrue() for %s = %t, want %t", tc.expression.String(), got, tc.want)
+			}
+		})
+	}
+}
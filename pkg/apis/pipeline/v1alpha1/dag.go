@@ -130,6 +130,17 @@ func BuildDAG(tasks []PipelineTask) (*DAG, error) {
 				}
 			}
 		}
+		// Consuming the result of another task implicitly requires running after it
+		linked := map[string]bool{}
+		for _, ref := range pt.ResultRefs() {
+			if linked[ref.PipelineTask] {
+				continue
+			}
+			linked[ref.PipelineTask] = true
+			if err := addLink(pt, ref.PipelineTask, d.Nodes); err != nil {
+				return nil, fmt.Errorf("couldn't add link between %s and %s: %v", pt.Name, ref.PipelineTask, err)
+			}
+		}
 	}
 	return d, nil
 }
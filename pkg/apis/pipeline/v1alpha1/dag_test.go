@@ -231,6 +231,39 @@ func TestBuild_FanInFanOut(t *testing.T) {
 	assertSameDAG(t, expectedDAG, g)
 }
 
+func TestBuild_ResultRefs(t *testing.T) {
+	a := PipelineTask{Name: "a"}
+	bUsesResultsOfA := PipelineTask{
+		Name: "b",
+		Params: []Param{{
+			Name: "digest", Value: *NewArrayOrString("${tasks.a.results.digest}"),
+		}, {
+			Name: "image", Value: *NewArrayOrString("gcr.io/foo@${tasks.a.results.digest}:${tasks.a.results.tag}"),
+		}},
+	}
+
+	//   a
+	//   |
+	//   b
+	nodeA := &Node{Task: a}
+	nodeB := &Node{Task: bUsesResultsOfA}
+
+	nodeA.Next = []*Node{nodeB}
+	nodeB.Prev = []*Node{nodeA}
+
+	expectedDAG := &DAG{
+		Nodes: map[string]*Node{
+			"a": nodeA,
+			"b": nodeB,
+		},
+	}
+	g, err := BuildDAG([]PipelineTask{a, bUsesResultsOfA})
+	if err != nil {
+		t.Fatalf("didn't expect error creating valid DAG but got %v", err)
+	}
+	assertSameDAG(t, expectedDAG, g)
+}
+
 func TestBuild_Invalid(t *testing.T) {
 	a := PipelineTask{Name: "a"}
 	xDependsOnA := PipelineTask{
@@ -283,6 +316,14 @@ func TestBuild_Invalid(t *testing.T) {
 		Name:     "a",
 		RunAfter: []string{"none"},
 	}
+	selfLinkResult := PipelineTask{
+		Name:   "a",
+		Params: []Param{{Name: "foo", Value: *NewArrayOrString("${tasks.a.results.foo}")}},
+	}
+	invalidTaskResult := PipelineTask{
+		Name:   "a",
+		Params: []Param{{Name: "foo", Value: *NewArrayOrString("${tasks.none.results.foo}")}},
+	}
 
 	tcs := []struct {
 		name string
@@ -311,6 +352,12 @@ func TestBuild_Invalid(t *testing.T) {
 	}, {
 		name: "invalid-task-name-after",
 		spec: PipelineSpec{Tasks: []PipelineTask{invalidTaskAfter}},
+	}, {
+		name: "self-link-result",
+		spec: PipelineSpec{Tasks: []PipelineTask{selfLinkResult}},
+	}, {
+		name: "invalid-task-name-result",
+		spec: PipelineSpec{Tasks: []PipelineTask{invalidTaskResult}},
 	},
 	}
 	for _, tc := range tcs {
@@ -47,7 +47,7 @@ func Test_Invalid_NewStorageResource(t *testing.T) {
 			},
 			Spec: PipelineResourceSpec{
 				Type: PipelineResourceTypeStorage,
-				Params: []Param{{
+				Params: []ResourceParam{{
 					Name:  "Location",
 					Value: "gs://fake-bucket",
 				}, {
@@ -64,7 +64,7 @@ func Test_Invalid_NewStorageResource(t *testing.T) {
 			},
 			Spec: PipelineResourceSpec{
 				Type: PipelineResourceTypeStorage,
-				Params: []Param{{
+				Params: []ResourceParam{{
 					Name:  "Location",
 					Value: "gs://fake-bucket",
 				}},
@@ -78,7 +78,7 @@ func Test_Invalid_NewStorageResource(t *testing.T) {
 			},
 			Spec: PipelineResourceSpec{
 				Type: PipelineResourceTypeStorage,
-				Params: []Param{{
+				Params: []ResourceParam{{
 					Name:  "NotLocation",
 					Value: "doesntmatter",
 				}, {
@@ -95,7 +95,7 @@ func Test_Invalid_NewStorageResource(t *testing.T) {
 			},
 			Spec: PipelineResourceSpec{
 				Type: PipelineResourceTypeStorage,
-				Params: []Param{{
+				Params: []ResourceParam{{
 					Name:  "Location",
 					Value: "",
 				}, {
@@ -122,7 +122,7 @@ func Test_Valid_NewGCSResource(t *testing.T) {
 		},
 		Spec: PipelineResourceSpec{
 			Type: PipelineResourceTypeStorage,
-			Params: []Param{{
+			Params: []ResourceParam{{
 				Name:  "Location",
 				Value: "gs://fake-bucket",
 			}, {
@@ -180,7 +180,7 @@ func Test_GetParams(t *testing.T) {
 	pr := &PipelineResource{
 		Spec: PipelineResourceSpec{
 			Type: PipelineResourceTypeStorage,
-			Params: []Param{{
+			Params: []ResourceParam{{
 				Name:  "type",
 				Value: "gcs",
 			}, {
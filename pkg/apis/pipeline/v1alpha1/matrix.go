@@ -0,0 +1,42 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// MatrixCombinations returns the combinations of the values of the parameters of the
+// matrix of pt, each as a list of string params, or nil if pt has no matrix. The
+// combinations are ordered deterministically, the values of the last parameter of
+// the matrix varying first.
+func (pt PipelineTask) MatrixCombinations() [][]Param {
+	if len(pt.Matrix) == 0 {
+		return nil
+	}
+	combinations := [][]Param{{}}
+	for _, mp := range pt.Matrix {
+		var next [][]Param
+		for _, c := range combinations {
+			for _, v := range mp.Value.ArrayVal {
+				combination := append(append([]Param{}, c...), Param{
+					Name:  mp.Name,
+					Value: *NewArrayOrString(v),
+				})
+				next = append(next, combination)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+func TestMatrixCombinations(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		matrix   []v1alpha1.Param
+		expected [][]v1alpha1.Param
+	}{{
+		name:     "no matrix",
+		expected: nil,
+	}, {
+		name: "single parameter",
+		matrix: []v1alpha1.Param{{
+			Name: "version", Value: *v1alpha1.NewArrayOrString("1.12", "1.13"),
+		}},
+		expected: [][]v1alpha1.Param{
+			{{Name: "version", Value: *v1alpha1.NewArrayOrString("1.12")}},
+			{{Name: "version", Value: *v1alpha1.NewArrayOrString("1.13")}},
+		},
+	}, {
+		name: "several parameters",
+		matrix: []v1alpha1.Param{{
+			Name: "version", Value: *v1alpha1.NewArrayOrString("1.12", "1.13"),
+		}, {
+			Name: "platform", Value: *v1alpha1.NewArrayOrString("linux", "darwin", "windows"),
+		}},
+		expected: [][]v1alpha1.Param{
+			{{Name: "version", Value: *v1alpha1.NewArrayOrString("1.12")}, {Name: "platform", Value: *v1alpha1.NewArrayOrString("linux")}},
+			{{Name: "version", Value: *v1alpha1.NewArrayOrString("1.12")}, {Name: "platform", Value: *v1alpha1.NewArrayOrString("darwin")}},
+			{{Name: "version", Value: *v1alpha1.NewArrayOrString("1.12")}, {Name: "platform", Value: *v1alpha1.NewArrayOrString("windows")}},
+			{{Name: "version", Value: *v1alpha1.NewArrayOrString("1.13")}, {Name: "platform", Value: *v1alpha1.NewArrayOrString("linux")}},
+			{{Name: "version", Value: *v1alpha1.NewArrayOrString("1.13")}, {Name: "platform", Value: *v1alpha1.NewArrayOrString("darwin")}},
+			{{Name: "version", Value: *v1alpha1.NewArrayOrString("1.13")}, {Name: "platform", Value: *v1alpha1.NewArrayOrString("windows")}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pt := v1alpha1.PipelineTask{Name: "test", Matrix: tc.matrix}
+			if d := cmp.Diff(tc.expected, pt.MatrixCombinations()); d != "" {
+				t.Errorf("Unexpected combinations -want, +got: %v", d)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	"github.com/tektoncd/pipeline/pkg/templating"
+)
+
+// ParamType indicates the type of an input parameter;
+// Used to distinguish between a single string and an array of strings.
+type ParamType string
+
+// Valid ParamTypes:
+const (
+	ParamTypeString ParamType = "string"
+	ParamTypeArray  ParamType = "array"
+)
+
+// AllParamTypes can be used for ParamType validation.
+var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray}
+
+// getParamType returns the type of a parameter declared with paramType and the
+// default value defaultValue: it defaults to the type of the default value, or
+// to a string if the parameter has no default.
+func getParamType(paramType ParamType, defaultValue *ArrayOrString) ParamType {
+	switch {
+	case paramType != "":
+		return paramType
+	case defaultValue != nil && defaultValue.Type != "":
+		return defaultValue.Type
+	}
+	return ParamTypeString
+}
+
+// ArrayOrString is a type that can hold a single string or string array.
+// Used in JSON unmarshalling so that a single JSON field can accept
+// either an individual string or an array of strings.
+type ArrayOrString struct {
+	Type      ParamType // Represents the stored type of ArrayOrString.
+	StringVal string
+	ArrayVal  []string
+}
+
+// NewArrayOrString returns an ArrayOrString holding value if no other values
+// are given, or an array of value followed by values otherwise.
+func NewArrayOrString(value string, values ...string) *ArrayOrString {
+	if len(values) > 0 {
+		return &ArrayOrString{
+			Type:     ParamTypeArray,
+			ArrayVal: append([]string{value}, values...),
+		}
+	}
+	return &ArrayOrString{
+		Type:      ParamTypeString,
+		StringVal: value,
+	}
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (arrayOrString *ArrayOrString) UnmarshalJSON(value []byte) error {
+	if len(value) > 0 && value[0] == '[' {
+		arrayOrString.Type = ParamTypeArray
+		return json.Unmarshal(value, &arrayOrString.ArrayVal)
+	}
+	arrayOrString.Type = ParamTypeString
+	return json.Unmarshal(value, &arrayOrString.StringVal)
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (arrayOrString ArrayOrString) MarshalJSON() ([]byte, error) {
+	if arrayOrString.Type == ParamTypeArray {
+		return json.Marshal(arrayOrString.ArrayVal)
+	}
+	return json.Marshal(arrayOrString.StringVal)
+}
+
+// ApplyReplacements replaces the variables in the value with stringReplacements.
+// The elements of an array which are exactly a variable of arrayReplacements are
+// expanded into the elements of the array that variable is replaced with.
+func (arrayOrString *ArrayOrString) ApplyReplacements(stringReplacements map[string]string, arrayReplacements map[string][]string) {
+	if arrayOrString.Type != ParamTypeArray {
+		arrayOrString.StringVal = templating.ApplyReplacements(arrayOrString.StringVal, stringReplacements)
+		return
+	}
+	var newArrayVal []string
+	for _, v := range arrayOrString.ArrayVal {
+		newArrayVal = append(newArrayVal, templating.ApplyArrayReplacements(v, stringReplacements, arrayReplacements)...)
+	}
+	arrayOrString.ArrayVal = newArrayVal
+}
+
+// Strings returns the strings the value holds: the string itself, or the
+// elements of the array.
+func (arrayOrString ArrayOrString) Strings() []string {
+	if arrayOrString.Type == ParamTypeArray {
+		return arrayOrString.ArrayVal
+	}
+	return []string{arrayOrString.StringVal}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestArrayOrString_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input  string
+		result ArrayOrString
+	}{
+		{`"hello"`, ArrayOrString{Type: ParamTypeString, StringVal: "hello"}},
+		{`""`, ArrayOrString{Type: ParamTypeString, StringVal: ""}},
+		{`["hello","world"]`, ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{"hello", "world"}}},
+		{`[]`, ArrayOrString{Type: ParamTypeArray, ArrayVal: []string{}}},
+	}
+	for _, tc := range tests {
+		var result ArrayOrString
+		if err := json.Unmarshal([]byte(tc.input), &result); err != nil {
+			t.Fatalf("Failed to unmarshal input %q: %v", tc.input, err)
+		}
+		if d := cmp.Diff(tc.result, result); d != "" {
+			t.Errorf("Unexpected result of unmarshalling %q -want, +got: %v", tc.input, d)
+		}
+	}
+}
+
+func TestArrayOrString_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		input  Param
+		result string
+	}{
+		{Param{Name: "s", Value: *NewArrayOrString("hello")}, `{"name":"s","value":"hello"}`},
+		{Param{Name: "a", Value: *NewArrayOrString("hello", "world")}, `{"name":"a","value":["hello","world"]}`},
+	}
+	for _, tc := range tests {
+		result, err := json.Marshal(&tc.input)
+		if err != nil {
+			t.Fatalf("Failed to marshal %v: %v", tc.input, err)
+		}
+		if string(result) != tc.result {
+			t.Errorf("Expected %v to be marshalled to %s but was %s", tc.input, tc.result, string(result))
+		}
+	}
+}
+
+func TestArrayOrString_ApplyReplacements(t *testing.T) {
+	stringReplacements := map[string]string{"params.name": "world"}
+	arrayReplacements := map[string][]string{"params.list": {"a", "b"}}
+	tests := []struct {
+		name     string
+		input    *ArrayOrString
+		expected *ArrayOrString
+	}{{
+		name:     "string",
+		input:    NewArrayOrString("hello ${params.name} ${params.list}"),
+		expected: NewArrayOrString("hello world ${params.list}"),
+	}, {
+		name:     "array",
+		input:    NewArrayOrString("${params.name}", "${params.list}", "--${params.list}"),
+		expected: NewArrayOrString("world", "a", "b", "--${params.list}"),
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.input.ApplyReplacements(stringReplacements, arrayReplacements)
+			if d := cmp.Diff(tc.expected, tc.input); d != "" {
+				t.Errorf("Unexpected value -want, +got: %v", d)
+			}
+		})
+	}
+}
+
+func TestTaskParam_GetType(t *testing.T) {
+	tests := []struct {
+		name     string
+		param    TaskParam
+		expected ParamType
+	}{
+		{"declared", TaskParam{Type: ParamTypeArray, Default: NewArrayOrString("a")}, ParamTypeArray},
+		{"from default", TaskParam{Default: NewArrayOrString("a", "b")}, ParamTypeArray},
+		{"no default", TaskParam{}, ParamTypeString},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.param.GetType(); got != tc.expected {
+				t.Errorf("Expected type %q but got %q", tc.expected, got)
+			}
+		})
+	}
+}
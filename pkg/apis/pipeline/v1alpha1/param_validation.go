@@ -0,0 +1,53 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/knative/pkg/apis"
+)
+
+// validateParamType validates that the parameter called name, declared under path, has a
+// valid type, and that its default value has that type.
+func validateParamType(name string, paramType ParamType, defaultValue *ArrayOrString, path string) *apis.FieldError {
+	if paramType != "" {
+		valid := false
+		for _, t := range AllParamTypes {
+			if paramType == t {
+				valid = true
+			}
+		}
+		if !valid {
+			return apis.ErrInvalidValue(string(paramType), fmt.Sprintf("%s.%s.type", path, name))
+		}
+	}
+	if defaultValue == nil {
+		return nil
+	}
+	defaultType := getParamType(defaultValue.Type, nil)
+	if defaultType != getParamType(paramType, defaultValue) {
+		return &apis.FieldError{
+			Message: fmt.Sprintf("%q type does not match default value's type: %q", paramType, defaultType),
+			Paths: []string{
+				fmt.Sprintf("%s.%s.type", path, name),
+				fmt.Sprintf("%s.%s.default.type", path, name),
+			},
+		}
+	}
+	return nil
+}
@@ -26,8 +26,38 @@ type PipelineSpec struct {
 	Resources []PipelineDeclaredResource `json:"resources"`
 	Tasks     []PipelineTask             `json:"tasks"`
 	Params    []PipelineParam            `json:"params"`
+	// Finally is a list of PipelineTasks which are run once all of the Tasks
+	// have finished executing, whether they succeeded, failed or were cancelled.
+	// +optional
+	Finally []PipelineTask `json:"finally,omitempty"`
+	// Results are the values the Pipeline reports once it has finished
+	// executing, computed from the results of its Tasks.
+	// +optional
+	Results []PipelineResult `json:"results,omitempty"`
+	// Workspaces are the volumes the Pipeline expects to be bound by its
+	// PipelineRun and which its Tasks can share.
+	// +optional
+	Workspaces []PipelineWorkspaceDeclaration `json:"workspaces,omitempty"`
+	// FailurePolicy is what happens once one of the Tasks has failed: by
+	// default no more Tasks are started, but with "runAllIndependent" the
+	// Tasks which don't run after a failed Task are still run.
+	// +optional
+	FailurePolicy FailurePolicy `json:"failurePolicy,omitempty"`
 }
 
+// FailurePolicy is what happens once one of the Tasks of a Pipeline has failed.
+type FailurePolicy string
+
+const (
+	// FailurePolicyFailFast fails the PipelineRun as soon as one of its Tasks
+	// has failed, without starting any more Tasks.
+	FailurePolicyFailFast FailurePolicy = "failFast"
+	// FailurePolicyRunAllIndependent keeps starting the Tasks which only run
+	// after Tasks which succeeded, and fails the PipelineRun once no more
+	// Tasks can be run.
+	FailurePolicyRunAllIndependent FailurePolicy = "runAllIndependent"
+)
+
 // PipelineStatus does not contain anything because Pipelines on their own
 // do not have a status, they just hold data which is later used by a
 // PipelineRun.
@@ -46,6 +76,9 @@ const (
 	NamespacedTaskKind TaskKind = "Task"
 	// ClusterTaskKind indicates that task type has a cluster scope.
 	ClusterTaskKind TaskKind = "ClusterTask"
+	// PipelineKind indicates that the PipelineTask runs another Pipeline, in a
+	// PipelineRun owned by the PipelineRun of this Pipeline.
+	PipelineKind TaskKind = "Pipeline"
 )
 
 // +genclient
@@ -72,6 +105,10 @@ type Pipeline struct {
 type PipelineTask struct {
 	Name    string  `json:"name"`
 	TaskRef TaskRef `json:"taskRef"`
+	// TaskSpec is an embedded Task which is run instead of the one
+	// referenced by TaskRef.
+	// +optional
+	TaskSpec *TaskSpec `json:"taskSpec,omitempty"`
 
 	// RunAfter is the list of PipelineTask names that should be executed before
 	// this Task executes. (Used to force a specific ordering in graph execution.)
@@ -82,6 +119,47 @@ type PipelineTask struct {
 	Resources *PipelineTaskResources `json:"resources,omitempty"`
 	// +optional
 	Params []Param `json:"params,omitempty"`
+
+	// Matrix is a list of array parameters: a TaskRun is created for each
+	// combination of their values, with the params above, and the Task is
+	// only considered done once all of these TaskRuns are.
+	// +optional
+	Matrix []Param `json:"matrix,omitempty"`
+
+	// Conditions is a list of guards which must all be met before this Task
+	// is run. If any of them isn't met, this Task and the Tasks depending on
+	// it are skipped.
+	// +optional
+	Conditions []PipelineTaskCondition `json:"conditions,omitempty"`
+
+	// Approval is a manual approval guarding this Task: it is only run once
+	// one of its approvers has approved it in the PipelineRun, and the
+	// PipelineRun fails if one of them rejects it.
+	// +optional
+	Approval *PipelineTaskApproval `json:"approval,omitempty"`
+
+	// Retries is the number of times the TaskRun created for this Task is
+	// retried after it fails, before the PipelineRun is marked as failed.
+	// +optional
+	Retries int `json:"retries,omitempty"`
+
+	// Timeout is the time after which the TaskRun created for this Task times
+	// out. It is clipped to the time remaining before the PipelineRun times out.
+	// Defaults to the timeout of the PipelineRun.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Workspaces maps the workspaces declared by the Task to the workspaces
+	// of the Pipeline.
+	// +optional
+	Workspaces []WorkspacePipelineTaskBinding `json:"workspaces,omitempty"`
+}
+
+// PipelineTaskApproval lists the users allowed to approve or reject a
+// PipelineTask before it is run.
+type PipelineTaskApproval struct {
+	// Approvers are the names of the users allowed to approve the PipelineTask
+	Approvers []string `json:"approvers"`
 }
 
 // PipelineTaskParam is used to provide arbitrary string parameters to a Task.
@@ -94,10 +172,30 @@ type PipelineTaskParam struct {
 // such as resources.
 type PipelineParam struct {
 	Name string `json:"name"`
+	// Type is the type of the parameter, "string" or "array". It defaults to
+	// the type of Default, or to "string" if there is no default.
+	// +optional
+	Type ParamType `json:"type,omitempty"`
 	// +optional
 	Description string `json:"description,omitempty"`
 	// +optional
-	Default string `json:"default,omitempty"`
+	Default *ArrayOrString `json:"default,omitempty"`
+}
+
+// GetType returns the type of the parameter, taking its default into account.
+func (pp PipelineParam) GetType() ParamType {
+	return getParamType(pp.Type, pp.Default)
+}
+
+// PipelineResult declares a value reported by the Pipeline, which is computed
+// from the results reported by its Tasks.
+type PipelineResult struct {
+	Name string `json:"name"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Value is the value of the result, which references the results of
+	// the Tasks using ${tasks.<name>.results.<result>} variables.
+	Value string `json:"value"`
 }
 
 // PipelineDeclaredResource is used by a Pipeline to declare the types of the
@@ -153,7 +251,8 @@ type PipelineTaskOutputResource struct {
 type TaskRef struct {
 	// Name of the referent; More info: http://kubernetes.io/docs/user-guide/identifiers#names
 	Name string `json:"name"`
-	// TaskKind inficates the kind of the task, namespaced or cluster scoped.
+	// TaskKind inficates the kind of the task, namespaced or cluster scoped,
+	// or that a Pipeline is referenced instead.
 	Kind TaskKind `json:"kind,omitempty"`
 	// API version of the referent
 	// +optional
@@ -37,7 +37,7 @@ func (p *Pipeline) Validate(ctx context.Context) *apis.FieldError {
 
 func validateDeclaredResources(ps *PipelineSpec) error {
 	required := []string{}
-	for _, t := range ps.Tasks {
+	for _, t := range append(ps.Tasks, ps.Finally...) {
 		if t.Resources != nil {
 			for _, input := range t.Resources.Inputs {
 				required = append(required, input.Resource)
@@ -70,9 +70,12 @@ func isOutput(outputs []PipelineTaskOutputResource, resource string) bool {
 
 // validateFrom ensures that the `from` values make sense: that they rely on values from Tasks
 // that ran previously, and that the PipelineResource is actually an output of the Task it should come from.
+// Since the outputs are passed between the TaskRuns of the tasks, the tasks running a Pipeline can't use them.
 func validateFrom(tasks []PipelineTask) error {
 	taskOutputs := map[string][]PipelineTaskOutputResource{}
+	runsPipeline := map[string]bool{}
 	for _, task := range tasks {
+		runsPipeline[task.Name] = task.TaskRef.Kind == PipelineKind
 		var to []PipelineTaskOutputResource
 		if task.Resources != nil {
 			to = make([]PipelineTaskOutputResource, len(task.Resources.Outputs))
@@ -93,6 +96,9 @@ func validateFrom(tasks []PipelineTask) error {
 					if !isOutput(outputs, rd.Resource) {
 						return fmt.Errorf("the resource %s from %s must be an output but is an input", rd.Resource, pb)
 					}
+					if runsPipeline[pb] || runsPipeline[t.Name] {
+						return fmt.Errorf("the resource %s from %s can't be passed from or to a task running a Pipeline", rd.Resource, pb)
+					}
 				}
 			}
 		}
@@ -125,6 +131,85 @@ func (ps *PipelineSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 		taskNames[t.Name] = struct{}{}
 	}
+	for _, t := range ps.Finally {
+		if _, ok := taskNames[t.Name]; ok {
+			return apis.ErrMultipleOneOf("spec.finally.name")
+		}
+		taskNames[t.Name] = struct{}{}
+	}
+
+	// Each task must either reference a Task or embed a TaskSpec
+	for _, t := range ps.Tasks {
+		if err := validatePipelineTaskSpec(ctx, t); err != nil {
+			return err.ViaField("spec.tasks")
+		}
+	}
+	for _, t := range ps.Finally {
+		if err := validatePipelineTaskSpec(ctx, t); err != nil {
+			return err.ViaField("spec.finally")
+		}
+	}
+
+	// Finally tasks are run after all of the other tasks so they can't be ordered
+	if err := validateFinallyTasks(ps.Finally); err != nil {
+		return err
+	}
+
+	// Conditions must be well formed
+	for i, t := range ps.Tasks {
+		if err := validatePipelineTaskConditions(t.Conditions); err != nil {
+			return err.ViaField("conditions").ViaFieldIndex("spec.tasks", i)
+		}
+	}
+
+	// Approvals must list who can approve the tasks
+	for _, t := range ps.Tasks {
+		if err := validateApproval(t.Approval); err != nil {
+			return err.ViaField("spec.tasks")
+		}
+	}
+
+	switch ps.FailurePolicy {
+	case "", FailurePolicyFailFast, FailurePolicyRunAllIndependent:
+	default:
+		return apis.ErrInvalidValue(string(ps.FailurePolicy), "spec.failurePolicy")
+	}
+
+	// Retries can't be negative
+	for _, t := range ps.Tasks {
+		if t.Retries < 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", t.Retries), "spec.tasks.retries")
+		}
+	}
+	for _, t := range ps.Finally {
+		if t.Retries < 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", t.Retries), "spec.finally.retries")
+		}
+	}
+
+	// Timeouts must be positive
+	for _, t := range ps.Tasks {
+		if t.Timeout != nil && t.Timeout.Duration <= 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", t.Timeout.Duration), "spec.tasks.timeout")
+		}
+	}
+	for _, t := range ps.Finally {
+		if t.Timeout != nil && t.Timeout.Duration <= 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", t.Timeout.Duration), "spec.finally.timeout")
+		}
+	}
+
+	// Matrices must provide arrays of values for the params of the tasks
+	for _, t := range ps.Tasks {
+		if err := validateMatrix(t); err != nil {
+			return err.ViaField("spec.tasks")
+		}
+	}
+	for _, t := range ps.Finally {
+		if err := validateMatrix(t); err != nil {
+			return err.ViaField("spec.finally")
+		}
+	}
 
 	// All declared resources should be used, and the Pipeline shouldn't try to use any resources
 	// that aren't declared
@@ -137,32 +222,341 @@ func (ps *PipelineSpec) Validate(ctx context.Context) *apis.FieldError {
 		return apis.ErrInvalidValue(err.Error(), "spec.tasks.resources.inputs.from")
 	}
 
+	// Results can only be consumed from the tasks of the graph
+	if err := validateResultRefs(ps); err != nil {
+		return err
+	}
+
+	// The results of the pipeline are computed from the results of its tasks
+	if err := validatePipelineResults(ps); err != nil {
+		return err
+	}
+
+	// Tasks can only use the workspaces declared by the pipeline
+	if err := validatePipelineWorkspaces(ps); err != nil {
+		return err
+	}
+
 	// Validate the pipeline task graph
 	if err := validateGraph(ps.Tasks); err != nil {
 		return apis.ErrInvalidValue(err.Error(), "spec.tasks")
 	}
 
 	// The parameter variables should be valid
-	if err := validatePipelineParameterVariables(ps.Tasks, ps.Params); err != nil {
+	if err := validatePipelineParameterVariables(append(ps.Tasks, ps.Finally...), ps.Params); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// validatePipelineTaskSpec ensures that exactly one of the TaskRef and the TaskSpec of the
+// PipelineTask t is specified, that the TaskSpec is valid, and that t isn't retried if it runs
+// a Pipeline or a custom task. Since custom tasks aren't run in pods, they can't be given
+// PipelineResources, workspaces nor a timeout either.
+func validatePipelineTaskSpec(ctx context.Context, t PipelineTask) *apis.FieldError {
+	if t.TaskRef.Name != "" && t.TaskSpec != nil {
+		return apis.ErrDisallowedFields("taskref", "taskspec")
+	}
+	if t.TaskRef.Name == "" && t.TaskSpec == nil {
+		return apis.ErrMissingField("taskref.name", "taskspec")
+	}
+	if (t.TaskRef.Kind == PipelineKind || t.TaskRef.IsCustomTask()) && t.Retries > 0 {
+		return apis.ErrDisallowedFields("retries")
+	}
+	if t.TaskRef.IsCustomTask() {
+		if t.Resources != nil {
+			return apis.ErrDisallowedFields("resources")
+		}
+		if len(t.Workspaces) > 0 {
+			return apis.ErrDisallowedFields("workspaces")
+		}
+		if t.Timeout != nil {
+			return apis.ErrDisallowedFields("timeout")
+		}
+	}
+	if t.TaskSpec != nil {
+		return t.TaskSpec.Validate(ctx).ViaField("taskspec")
+	}
+	return nil
+}
+
+// validateFinallyTasks ensures that the finally tasks don't try to depend on any other
+// task, since they are always run once all of the other tasks have finished.
+func validateFinallyTasks(tasks []PipelineTask) *apis.FieldError {
+	for _, t := range tasks {
+		if len(t.RunAfter) > 0 {
+			return apis.ErrDisallowedFields("spec.finally.runAfter")
+		}
+		if len(t.Conditions) > 0 {
+			return apis.ErrDisallowedFields("spec.finally.conditions")
+		}
+		if t.Approval != nil {
+			return apis.ErrDisallowedFields("spec.finally.approval")
+		}
+		if t.Resources != nil {
+			for _, rd := range t.Resources.Inputs {
+				if len(rd.From) > 0 {
+					return apis.ErrDisallowedFields("spec.finally.resources.inputs.from")
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validatePipelineTaskConditions ensures that the conditions guarding a PipelineTask are well formed.
+func validatePipelineTaskConditions(conditions []PipelineTaskCondition) *apis.FieldError {
+	names := map[string]struct{}{}
+	for i, c := range conditions {
+		if err := validatePipelineTaskCondition(c, names); err != nil {
+			return err.ViaIndex(i)
+		}
+	}
+	return nil
+}
+
+// validatePipelineTaskCondition ensures that c is well formed, and that its name isn't among
+// names, the names of the conditions before it, to which it's added.
+func validatePipelineTaskCondition(c PipelineTaskCondition, names map[string]struct{}) *apis.FieldError {
+	if c.Name == "" {
+		return apis.ErrMissingField("name")
+	}
+	if _, ok := names[c.Name]; ok {
+		return apis.ErrInvalidValue(fmt.Sprintf("condition %s is duplicated", c.Name), "name")
+	}
+	names[c.Name] = struct{}{}
+
+	if c.Check != nil && c.Expression != nil {
+		return apis.ErrMultipleOneOf("check", "expression")
+	}
+	if c.Check == nil && c.Expression == nil {
+		return apis.ErrMissingOneOf("check", "expression")
+	}
+	if c.Check != nil && c.Check.Image == "" {
+		return apis.ErrMissingField("check.image")
+	}
+	if c.Expression != nil {
+		if c.Expression.Operator != ConditionOperatorIn && c.Expression.Operator != ConditionOperatorNotIn {
+			return apis.ErrInvalidValue(string(c.Expression.Operator), "expression.operator")
+		}
+		if len(c.Expression.Values) == 0 {
+			return apis.ErrMissingField("expression.values")
+		}
+	}
+	return nil
+}
+
+// validateApproval ensures that the approval guarding a PipelineTask, if any, lists the names
+// of the users allowed to approve it.
+func validateApproval(approval *PipelineTaskApproval) *apis.FieldError {
+	if approval == nil {
+		return nil
+	}
+	if len(approval.Approvers) == 0 {
+		return apis.ErrMissingField("approval.approvers")
+	}
+	for _, a := range approval.Approvers {
+		if a == "" {
+			return apis.ErrInvalidValue("approvers can't be empty", "approval.approvers")
+		}
+	}
+	return nil
+}
+
+// validateMatrix ensures that the parameters of the matrix of t are non-empty arrays which
+// aren't already provided by its params. Since each combination of the matrix is run by a
+// different TaskRun, the matrix can't be combined with conditions, nor used to run a Pipeline
+// or a custom task.
+func validateMatrix(t PipelineTask) *apis.FieldError {
+	if len(t.Matrix) == 0 {
+		return nil
+	}
+	if len(t.Conditions) > 0 {
+		return apis.ErrMultipleOneOf("matrix", "conditions")
+	}
+	if t.TaskSpec == nil && (t.TaskRef.Kind == PipelineKind || t.TaskRef.IsCustomTask()) {
+		return apis.ErrInvalidValue(fmt.Sprintf("task %s with a matrix can't run a %s", t.Name, t.TaskRef.Kind), "matrix")
+	}
+	names := map[string]struct{}{}
+	for _, p := range t.Params {
+		names[p.Name] = struct{}{}
+	}
+	for _, p := range t.Matrix {
+		if _, ok := names[p.Name]; ok {
+			return apis.ErrMultipleOneOf("matrix.name")
+		}
+		names[p.Name] = struct{}{}
+		if p.Value.Type != ParamTypeArray || len(p.Value.ArrayVal) == 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("matrix parameter %s must be a non-empty array", p.Name), "matrix")
+		}
+	}
+	return nil
+}
+
+// validateResultRefs ensures that the results consumed by the tasks and the finally tasks
+// of ps are reported by other tasks of the graph. The results of the tasks with a matrix
+// can't be consumed, since each of their TaskRuns reports its own.
+func validateResultRefs(ps *PipelineSpec) *apis.FieldError {
+	taskNames := map[string]struct{}{}
+	for _, t := range ps.Tasks {
+		if len(t.Matrix) == 0 {
+			taskNames[t.Name] = struct{}{}
+		}
+	}
+	for _, t := range ps.Tasks {
+		if err := validateTaskResultRefs(t, taskNames); err != nil {
+			return err.ViaField("spec.tasks")
+		}
+	}
+	for _, t := range ps.Finally {
+		if err := validateTaskResultRefs(t, taskNames); err != nil {
+			return err.ViaField("spec.finally")
+		}
+	}
+	return nil
+}
+
+func validateTaskResultRefs(t PipelineTask, taskNames map[string]struct{}) *apis.FieldError {
+	for _, ref := range t.ResultRefs() {
+		if _, ok := taskNames[ref.PipelineTask]; !ok || ref.PipelineTask == t.Name {
+			return apis.ErrInvalidValue(fmt.Sprintf("task %s can't consume results of task %s", t.Name, ref.PipelineTask), "params")
+		}
+	}
+	return nil
+}
+
+// validatePipelineResults ensures that the results of ps have unique and valid names, and
+// that their values are computed from the results of the tasks or the finally tasks of ps
+// which don't have a matrix.
+func validatePipelineResults(ps *PipelineSpec) *apis.FieldError {
+	taskNames := map[string]struct{}{}
+	for _, t := range append(ps.Tasks, ps.Finally...) {
+		if len(t.Matrix) == 0 {
+			taskNames[t.Name] = struct{}{}
+		}
+	}
+	names := map[string]struct{}{}
+	for _, r := range ps.Results {
+		if !resultNameRegex.MatchString(r.Name) {
+			return apis.ErrInvalidValue(r.Name, "spec.results.name")
+		}
+		if _, ok := names[r.Name]; ok {
+			return apis.ErrMultipleOneOf("spec.results.name")
+		}
+		names[r.Name] = struct{}{}
+
+		refs := r.ResultRefs()
+		if len(refs) == 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("result %s doesn't use the results of any task", r.Name), "spec.results.value")
+		}
+		for _, ref := range refs {
+			if _, ok := taskNames[ref.PipelineTask]; !ok {
+				return apis.ErrInvalidValue(fmt.Sprintf("result %s uses the results of unknown task %s", r.Name, ref.PipelineTask), "spec.results.value")
+			}
+		}
+	}
+	return nil
+}
+
 func validatePipelineParameterVariables(tasks []PipelineTask, params []PipelineParam) *apis.FieldError {
 	parameterNames := map[string]struct{}{}
+	arrayParameterNames := map[string]struct{}{}
 	for _, p := range params {
+		if err := validateParamType(p.Name, p.Type, p.Default, "pipelinespec.params"); err != nil {
+			return err
+		}
 		parameterNames[p.Name] = struct{}{}
+		if p.GetType() == ParamTypeArray {
+			arrayParameterNames[p.Name] = struct{}{}
+		}
+	}
+	if err := validatePipelineVariables(tasks, "params", parameterNames); err != nil {
+		return err
 	}
-	return validatePipelineVariables(tasks, "params", parameterNames)
+	return validatePipelineArraysUsage(tasks, "params", arrayParameterNames)
 }
 
 func validatePipelineVariables(tasks []PipelineTask, prefix string, vars map[string]struct{}) *apis.FieldError {
 	for _, task := range tasks {
-		for _, param := range task.Params {
-			if err := validatePipelineVariable(fmt.Sprintf("param[%s]", param.Name), param.Value, prefix, vars); err != nil {
-				return err
+		for _, param := range append(task.Params, task.Matrix...) {
+			for _, value := range param.Value.Strings() {
+				if err := validatePipelineVariable(fmt.Sprintf("param[%s]", param.Name), value, prefix, vars); err != nil {
+					return err
+				}
+			}
+		}
+		for _, c := range task.Conditions {
+			if c.Expression != nil {
+				if err := validatePipelineVariable(fmt.Sprintf("condition[%s].input", c.Name), c.Expression.Input, prefix, vars); err != nil {
+					return err
+				}
+				for i, v := range c.Expression.Values {
+					if err := validatePipelineVariable(fmt.Sprintf("condition[%s].values[%d]", c.Name, i), v, prefix, vars); err != nil {
+						return err
+					}
+				}
+			}
+			if c.Check != nil {
+				for i, arg := range c.Check.Args {
+					if err := validatePipelineVariable(fmt.Sprintf("condition[%s].args[%d]", c.Name, i), arg, prefix, vars); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validatePipelineArraysUsage validates that the array parameters in vars are only used as
+// whole elements of the arrays of the tasks in which they are expanded.
+func validatePipelineArraysUsage(tasks []PipelineTask, prefix string, vars map[string]struct{}) *apis.FieldError {
+	for _, task := range tasks {
+		for _, param := range append(task.Params, task.Matrix...) {
+			name := fmt.Sprintf("param[%s]", param.Name)
+			if param.Value.Type != ParamTypeArray {
+				if err := validatePipelineNoArrayReferenced(name, param.Value.StringVal, prefix, vars); err != nil {
+					return err
+				}
+				continue
+			}
+			for _, v := range param.Value.ArrayVal {
+				if err := validatePipelineArraysIsolated(name, v, prefix, vars); err != nil {
+					return err
+				}
+			}
+		}
+		for _, c := range task.Conditions {
+			if c.Expression != nil {
+				if err := validatePipelineNoArrayReferenced(fmt.Sprintf("condition[%s].input", c.Name), c.Expression.Input, prefix, vars); err != nil {
+					return err
+				}
+				for i, v := range c.Expression.Values {
+					if err := validatePipelineNoArrayReferenced(fmt.Sprintf("condition[%s].values[%d]", c.Name, i), v, prefix, vars); err != nil {
+						return err
+					}
+				}
+			}
+			if c.Check != nil {
+				if err := validatePipelineNoArrayReferenced(fmt.Sprintf("condition[%s].image", c.Name), c.Check.Image, prefix, vars); err != nil {
+					return err
+				}
+				for i, cmd := range c.Check.Command {
+					if err := validatePipelineArraysIsolated(fmt.Sprintf("condition[%s].command[%d]", c.Name, i), cmd, prefix, vars); err != nil {
+						return err
+					}
+				}
+				for i, arg := range c.Check.Args {
+					if err := validatePipelineArraysIsolated(fmt.Sprintf("condition[%s].args[%d]", c.Name, i), arg, prefix, vars); err != nil {
+						return err
+					}
+				}
+				for _, env := range c.Check.Env {
+					if err := validatePipelineNoArrayReferenced(fmt.Sprintf("condition[%s].env[%s]", c.Name, env.Name), env.Value, prefix, vars); err != nil {
+						return err
+					}
+				}
 			}
 		}
 	}
@@ -172,3 +566,11 @@ func validatePipelineVariables(tasks []PipelineTask, prefix string, vars map[str
 func validatePipelineVariable(name, value, prefix string, vars map[string]struct{}) *apis.FieldError {
 	return templating.ValidateVariable(name, value, prefix, "", "task parameter", "pipelinespec.params", vars)
 }
+
+func validatePipelineNoArrayReferenced(name, value, prefix string, vars map[string]struct{}) *apis.FieldError {
+	return templating.ValidateVariableProhibited(name, value, prefix, "", "task parameter", "pipelinespec.params", vars)
+}
+
+func validatePipelineArraysIsolated(name, value, prefix string, vars map[string]struct{}) *apis.FieldError {
+	return templating.ValidateVariableIsolated(name, value, prefix, "", "task parameter", "pipelinespec.params", vars)
+}
@@ -19,7 +19,10 @@ package v1alpha1_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/knative/pkg/apis"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	tb "github.com/tektoncd/pipeline/test/builder"
 )
@@ -36,6 +39,324 @@ func TestPipelineSpec_Validate_Error(t *testing.T) {
 				tb.PipelineTask("foo", "foo-task"),
 			)),
 		},
+		{
+			name: "finally task with the same name as a task",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinallyTask("foo", "foo-task"),
+			)),
+		},
+		{
+			name: "finally task with runAfter",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinallyTask("cleanup", "cleanup-task", tb.RunAfter("foo")),
+			)),
+		},
+		{
+			name: "finally task with conditions",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinallyTask("cleanup", "cleanup-task", tb.PipelineTaskCondition("is-main",
+					tb.ConditionExpression("main", v1alpha1.ConditionOperatorIn, "main"))),
+			)),
+		},
+		{
+			name: "finally task with resource from a task",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineDeclaredResource("great-resource", v1alpha1.PipelineResourceTypeGit),
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskOutputResource("the-resource", "great-resource")),
+				tb.PipelineFinallyTask("cleanup", "cleanup-task",
+					tb.PipelineTaskInputResource("the-resource", "great-resource", tb.From("foo"))),
+			)),
+		},
+		{
+			name: "finally task with non-existent parameter variable",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinallyTask("cleanup", "cleanup-task",
+					tb.PipelineTaskParam("a-param", "${params.does-not-exist}")),
+			)),
+		},
+		{
+			name: "task without taskRef or taskSpec",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", ""),
+			)),
+		},
+		{
+			name: "task with both taskRef and taskSpec",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskSpec(tb.Step("step", "busybox"))),
+			)),
+		},
+		{
+			name: "task with invalid taskSpec",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "", tb.PipelineTaskSpec(tb.TaskInputs(tb.InputsParam("foo")))),
+			)),
+		},
+		{
+			name: "task consuming results of a non-existent task",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskParam("digest", "${tasks.bar.results.digest}")),
+			)),
+		},
+		{
+			name: "task consuming results of a finally task",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskParam("digest", "${tasks.cleanup.results.digest}")),
+				tb.PipelineFinallyTask("cleanup", "cleanup-task"),
+			)),
+		},
+		{
+			name: "finally task consuming its own results",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinallyTask("cleanup", "cleanup-task",
+					tb.PipelineTaskParam("digest", "${tasks.cleanup.results.digest}")),
+			)),
+		},
+		{
+			name: "result without task results",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineResult("digest", "sha256:abcd"),
+			)),
+		},
+		{
+			name: "result using the results of a non-existent task",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineResult("digest", "${tasks.bar.results.digest}"),
+			)),
+		},
+		{
+			name: "duplicate results",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineResult("digest", "${tasks.foo.results.digest}"),
+				tb.PipelineResult("digest", "${tasks.foo.results.other-digest}"),
+			)),
+		},
+		{
+			name: "finally task without taskRef or taskSpec",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinallyTask("cleanup", ""),
+			)),
+		},
+		{
+			name: "duplicate condition names",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskCondition("is-main", tb.ConditionCheck("busybox")),
+					tb.PipelineTaskCondition("is-main", tb.ConditionCheck("busybox"))),
+			)),
+		},
+		{
+			name: "condition with both check and expression",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskCondition("is-main",
+						tb.ConditionCheck("busybox"),
+						tb.ConditionExpression("main", v1alpha1.ConditionOperatorIn, "main"))),
+			)),
+		},
+		{
+			name: "condition with neither check nor expression",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskCondition("is-main")),
+			)),
+		},
+		{
+			name: "condition check without image",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskCondition("is-main", tb.ConditionCheck(""))),
+			)),
+		},
+		{
+			name: "condition expression with unknown operator",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskCondition("is-main",
+					tb.ConditionExpression("main", "equals", "main"))),
+			)),
+		},
+		{
+			name: "condition expression without values",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskCondition("is-main",
+					tb.ConditionExpression("main", v1alpha1.ConditionOperatorIn))),
+			)),
+		},
+		{
+			name: "condition expression with non-existent parameter variable",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("branch"),
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskCondition("is-main",
+					tb.ConditionExpression("${params.revision}", v1alpha1.ConditionOperatorIn, "main"))),
+			)),
+		},
+		{
+			name: "negative retries",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.Retries(-1)),
+			)),
+		},
+		{
+			name: "invalid failure policy",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFailurePolicy("ignore"),
+			)),
+		},
+		{
+			name: "negative timeout",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task", tb.PipelineTaskTimeout(-1*time.Minute)),
+			)),
+		},
+		{
+			name: "zero timeout of a finally task",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinallyTask("cleanup", "cleanup-task", tb.PipelineTaskTimeout(0)),
+			)),
+		},
+		{
+			name: "matrix parameter also provided by the params",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskParam("version", "1.12"),
+					tb.PipelineTaskMatrix("version", "1.12", "1.13")),
+			)),
+		},
+		{
+			name: "duplicate matrix parameters",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskMatrix("version", "1.12"),
+					tb.PipelineTaskMatrix("version", "1.13")),
+			)),
+		},
+		{
+			name: "empty matrix parameter",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineFinallyTask("foo", "foo-task",
+					tb.PipelineTaskMatrix("version")),
+			)),
+		},
+		{
+			name: "matrix with conditions",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("branch"),
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskMatrix("version", "1.12", "1.13"),
+					tb.PipelineTaskCondition("is-main",
+						tb.ConditionExpression("${params.branch}", v1alpha1.ConditionOperatorIn, "main"))),
+			)),
+		},
+		{
+			name: "matrix running a pipeline",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-pipeline",
+					tb.PipelineTaskRefKind(v1alpha1.PipelineKind),
+					tb.PipelineTaskMatrix("version", "1.12", "1.13")),
+			)),
+		},
+		{
+			name: "matrix running a custom task",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-wait",
+					tb.PipelineTaskRefCustomTask("example.dev/v1", "Wait"),
+					tb.PipelineTaskMatrix("version", "1.12", "1.13")),
+			)),
+		},
+		{
+			name: "matrix with non-existent parameter variable",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskMatrix("version", "${params.versions}")),
+			)),
+		},
+		{
+			name: "task consuming results of a task with a matrix",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("build", "build-task",
+					tb.PipelineTaskMatrix("version", "1.12", "1.13")),
+				tb.PipelineTask("deploy", "deploy-task",
+					tb.PipelineTaskParam("image", "${tasks.build.results.digest}")),
+			)),
+		},
+		{
+			name: "retried task running a pipeline",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-pipeline",
+					tb.PipelineTaskRefKind(v1alpha1.PipelineKind), tb.Retries(1)),
+			)),
+		},
+		{
+			name: "from task running a pipeline",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineDeclaredResource("great-resource", v1alpha1.PipelineResourceTypeGit),
+				tb.PipelineTask("foo", "foo-pipeline", tb.PipelineTaskRefKind(v1alpha1.PipelineKind),
+					tb.PipelineTaskOutputResource("the-resource", "great-resource")),
+				tb.PipelineTask("bar", "bar-task",
+					tb.PipelineTaskInputResource("the-resource", "great-resource", tb.From("foo"))),
+			)),
+		},
+		{
+			name: "retried custom task",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-wait",
+					tb.PipelineTaskRefCustomTask("example.dev/v1", "Wait"), tb.Retries(1)),
+			)),
+		},
+		{
+			name: "custom task with resources",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineDeclaredResource("great-resource", v1alpha1.PipelineResourceTypeGit),
+				tb.PipelineTask("foo", "foo-wait", tb.PipelineTaskRefCustomTask("example.dev/v1", "Wait"),
+					tb.PipelineTaskInputResource("the-resource", "great-resource")),
+			)),
+		},
+		{
+			name: "custom task with workspaces",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineWorkspace("source", ""),
+				tb.PipelineTask("foo", "foo-wait", tb.PipelineTaskRefCustomTask("example.dev/v1", "Wait"),
+					tb.PipelineTaskWorkspace("source", "source")),
+			)),
+		},
+		{
+			name: "custom task with timeout",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-wait", tb.PipelineTaskRefCustomTask("example.dev/v1", "Wait"),
+					tb.PipelineTaskTimeout(time.Minute)),
+			)),
+		},
+		{
+			name: "approval without approvers",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("deploy", "deploy-task", tb.PipelineTaskApproval()),
+			)),
+		},
+		{
+			name: "approval with an empty approver",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("deploy", "deploy-task", tb.PipelineTaskApproval("alice", "")),
+			)),
+		},
+		{
+			name: "finally task with approval",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinallyTask("cleanup", "cleanup-task", tb.PipelineTaskApproval("alice")),
+			)),
+		},
 		{
 			name: "from is on first task",
 			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
@@ -104,6 +425,66 @@ func TestPipelineSpec_Validate_Error(t *testing.T) {
 				tb.PipelineTask("foo", "foo-task",
 					tb.PipelineTaskParam("a-param", "${params.foo} and ${params.does-not-exist}")))),
 		},
+		{
+			name: "invalid parameter type",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("foo", tb.PipelineParamType("invalidtype")),
+				tb.PipelineTask("foo", "foo-task"))),
+		},
+		{
+			name: "parameter mismatching default type",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("foo", tb.PipelineParamType(v1alpha1.ParamTypeString), tb.PipelineParamDefault("a", "b")),
+				tb.PipelineTask("foo", "foo-task"))),
+		},
+		{
+			name: "array parameter used in a string",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("foo", tb.PipelineParamType(v1alpha1.ParamTypeArray)),
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskParam("a-param", "${params.foo}")))),
+		},
+		{
+			name: "array parameter not isolated in an array",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("foo", tb.PipelineParamType(v1alpha1.ParamTypeArray)),
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskParam("a-param", "first", "--flag=${params.foo}")))),
+		},
+		{
+			name: "task uses undeclared workspace",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineWorkspace("source", ""),
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskWorkspace("src", "does-not-exist")),
+			)),
+		},
+		{
+			name: "finally task uses undeclared workspace",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinallyTask("cleanup", "cleanup-task",
+					tb.PipelineTaskWorkspace("src", "does-not-exist")),
+			)),
+		},
+		{
+			name: "duplicate workspaces",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineWorkspace("source", ""),
+				tb.PipelineWorkspace("source", ""),
+				tb.PipelineTask("foo", "foo-task"),
+			)),
+		},
+		{
+			name: "task workspace mapped twice",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineWorkspace("source", ""),
+				tb.PipelineWorkspace("cache", ""),
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskWorkspace("src", "source"),
+					tb.PipelineTaskWorkspace("src", "cache")),
+			)),
+		},
 		{
 			name: "invalid dependency graph between the tasks",
 			p: tb.Pipeline("foo", "namespace", tb.PipelineSpec(
@@ -121,6 +502,24 @@ func TestPipelineSpec_Validate_Error(t *testing.T) {
 	}
 }
 
+func TestPipelineSpec_Validate_DuplicateConditions(t *testing.T) {
+	p := tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+		tb.PipelineTask("foo", "foo-task"),
+		tb.PipelineTask("bar", "bar-task",
+			tb.PipelineTaskCondition("is-main", tb.ConditionCheck("busybox")),
+			tb.PipelineTaskCondition("tests-pass", tb.ConditionCheck("busybox")),
+			tb.PipelineTaskCondition("is-main", tb.ConditionCheck("busybox"))),
+	))
+	want := apis.ErrInvalidValue("condition is-main is duplicated", "spec.tasks[1].conditions[2].name")
+	err := p.Spec.Validate(context.Background())
+	if err == nil {
+		t.Fatal("PipelineSpec.Validate() did not return error, wanted error")
+	}
+	if d := cmp.Diff(want.Error(), err.Error()); d != "" {
+		t.Errorf("PipelineSpec.Validate() error -want, +got: %s", d)
+	}
+}
+
 func TestPipelineSpec_Validate_Valid(t *testing.T) {
 	tests := []struct {
 		name string
@@ -155,6 +554,18 @@ func TestPipelineSpec_Validate_Valid(t *testing.T) {
 					tb.PipelineTaskInputResource("wow-image", "wonderful-resource", tb.From("bar"))),
 			)),
 		},
+		{
+			name: "valid workspaces",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineWorkspace("source", "the source to build"),
+				tb.PipelineTask("build", "build-task",
+					tb.PipelineTaskWorkspace("src", "source")),
+				tb.PipelineTask("test", "test-task",
+					tb.PipelineTaskWorkspace("src", "source")),
+				tb.PipelineFinallyTask("cleanup", "cleanup-task",
+					tb.PipelineTaskWorkspace("dir", "source")),
+			)),
+		},
 		{
 			name: "valid parameter variables",
 			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
@@ -164,6 +575,120 @@ func TestPipelineSpec_Validate_Valid(t *testing.T) {
 					tb.PipelineTaskParam("a-param", "${baz} and ${foo-is-baz}")),
 			)),
 		},
+		{
+			name: "valid array parameter variables",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("baz", tb.PipelineParamType(v1alpha1.ParamTypeArray)),
+				tb.PipelineParam("foo-is-baz", tb.PipelineParamDefault("a", "b")),
+				tb.PipelineTask("bar", "bar-task",
+					tb.PipelineTaskParam("a-param", "${params.baz}", "and", "${params.foo-is-baz}"),
+					tb.PipelineTaskCondition("tests-pass",
+						tb.ConditionCheck("busybox", tb.Args("${params.baz}")))),
+			)),
+		},
+		{
+			name: "valid matrix",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("versions", tb.PipelineParamType(v1alpha1.ParamTypeArray)),
+				tb.PipelineParam("platform"),
+				tb.PipelineTask("build", "build-task",
+					tb.PipelineTaskParam("flags", "-v"),
+					tb.PipelineTaskMatrix("version", "${params.versions}"),
+					tb.PipelineTaskMatrix("platform", "${params.platform}", "windows")),
+				tb.PipelineFinallyTask("cleanup", "cleanup-task",
+					tb.PipelineTaskMatrix("platform", "linux", "darwin")),
+			)),
+		},
+		{
+			name: "valid task running a pipeline",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("version"),
+				tb.PipelineTask("build", "build-pipeline",
+					tb.PipelineTaskRefKind(v1alpha1.PipelineKind),
+					tb.PipelineTaskParam("version", "${params.version}")),
+				tb.PipelineTask("publish", "publish-task",
+					tb.PipelineTaskParam("image", "${tasks.build.results.image}")),
+			)),
+		},
+		{
+			name: "valid custom task",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("approve", "release-approval",
+					tb.PipelineTaskRefCustomTask("example.dev/v1", "Approval"),
+					tb.PipelineTaskParam("approvers", "alice", "bob")),
+				tb.PipelineTask("publish", "publish-task",
+					tb.PipelineTaskParam("approver", "${tasks.approve.results.approver}")),
+			)),
+		},
+		{
+			name: "valid approval",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("build", "build-task"),
+				tb.PipelineTask("deploy", "deploy-task",
+					tb.RunAfter("build"), tb.PipelineTaskApproval("alice", "bob")),
+			)),
+		},
+		{
+			name: "valid conditions",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("branch"),
+				tb.PipelineTask("foo", "foo-task",
+					tb.PipelineTaskCondition("is-main",
+						tb.ConditionExpression("${params.branch}", v1alpha1.ConditionOperatorIn, "main", "master")),
+					tb.PipelineTaskCondition("tests-pass",
+						tb.ConditionCheck("busybox", tb.Args("${params.branch}")))),
+			)),
+		},
+		{
+			name: "valid finally tasks",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("namespace"),
+				tb.PipelineDeclaredResource("great-resource", v1alpha1.PipelineResourceTypeGit),
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineFinallyTask("cleanup", "cleanup-task",
+					tb.PipelineTaskInputResource("the-resource", "great-resource"),
+					tb.PipelineTaskParam("a-param", "${params.namespace}"),
+					tb.Retries(1), tb.PipelineTaskTimeout(5*time.Minute)),
+			)),
+		},
+		{
+			name: "run all independent tasks after a failure",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("foo", "foo-task"),
+				tb.PipelineTask("bar", "bar-task"),
+				tb.PipelineFailurePolicy(v1alpha1.FailurePolicyRunAllIndependent),
+			)),
+		},
+		{
+			name: "valid result references",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("build", "build-task"),
+				tb.PipelineTask("deploy", "deploy-task",
+					tb.PipelineTaskParam("image", "gcr.io/foo@${tasks.build.results.digest}")),
+				tb.PipelineFinallyTask("report", "report-task",
+					tb.PipelineTaskParam("digest", "${tasks.build.results.digest}")),
+			)),
+		},
+		{
+			name: "valid results",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineTask("build", "build-task"),
+				tb.PipelineFinallyTask("report", "report-task"),
+				tb.PipelineResult("image", "gcr.io/foo@${tasks.build.results.digest}",
+					tb.PipelineResultDescription("the image which was built")),
+				tb.PipelineResult("report-url", "${tasks.report.results.url}"),
+			)),
+		},
+		{
+			name: "task with embedded taskSpec",
+			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+				tb.PipelineParam("greeting"),
+				tb.PipelineTask("foo", "", tb.PipelineTaskSpec(
+					tb.TaskInputs(tb.InputsParam("message")),
+					tb.Step("echo", "busybox", tb.Command("echo"), tb.Args("${inputs.params.message}")),
+				), tb.PipelineTaskParam("message", "${params.greeting}")),
+			)),
+		},
 		{
 			name: "pipeline parameter nested in task parameter",
 			p: tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
@@ -40,7 +40,7 @@ func TestResourceValidation_Invalid(t *testing.T) {
 				},
 				Spec: PipelineResourceSpec{
 					Type: PipelineResourceTypeCluster,
-					Params: []Param{{
+					Params: []ResourceParam{{
 						Name:  "name",
 						Value: "test-cluster-resource",
 					}, {
@@ -70,7 +70,7 @@ func TestResourceValidation_Invalid(t *testing.T) {
 				},
 				Spec: PipelineResourceSpec{
 					Type: PipelineResourceTypeCluster,
-					Params: []Param{{
+					Params: []ResourceParam{{
 						Name:  "name",
 						Value: "test-cluster-resource",
 					}, {
@@ -97,7 +97,7 @@ func TestResourceValidation_Invalid(t *testing.T) {
 				},
 				Spec: PipelineResourceSpec{
 					Type: PipelineResourceTypeCluster,
-					Params: []Param{{
+					Params: []ResourceParam{{
 						Name:  "url",
 						Value: "http://10.10.10.10",
 					}, {
@@ -121,7 +121,7 @@ func TestResourceValidation_Invalid(t *testing.T) {
 				},
 				Spec: PipelineResourceSpec{
 					Type: PipelineResourceTypeCluster,
-					Params: []Param{{
+					Params: []ResourceParam{{
 						Name:  "Name",
 						Value: "test-cluster-resource",
 					}, {
@@ -146,7 +146,7 @@ func TestResourceValidation_Invalid(t *testing.T) {
 				},
 				Spec: PipelineResourceSpec{
 					Type: PipelineResourceTypeStorage,
-					Params: []Param{{
+					Params: []ResourceParam{{
 						Name:  "no-type-param",
 						Value: "sometype",
 					}},
@@ -161,7 +161,7 @@ func TestResourceValidation_Invalid(t *testing.T) {
 				},
 				Spec: PipelineResourceSpec{
 					Type: PipelineResourceTypeStorage,
-					Params: []Param{{
+					Params: []ResourceParam{{
 						Name:  "type",
 						Value: "not-implemented-yet",
 					}},
@@ -176,7 +176,7 @@ func TestResourceValidation_Invalid(t *testing.T) {
 				},
 				Spec: PipelineResourceSpec{
 					Type: PipelineResourceTypeStorage,
-					Params: []Param{{
+					Params: []ResourceParam{{
 						Name:  "type",
 						Value: "gcs",
 					}},
@@ -191,7 +191,7 @@ func TestResourceValidation_Invalid(t *testing.T) {
 				},
 				Spec: PipelineResourceSpec{
 					Type: PipelineResourceTypeStorage,
-					Params: []Param{{
+					Params: []ResourceParam{{
 						Name:  "type",
 						Value: "gcs",
 					}, {
@@ -232,7 +232,7 @@ func TestClusterResourceValidation_Valid(t *testing.T) {
 		},
 		Spec: PipelineResourceSpec{
 			Type: PipelineResourceTypeCluster,
-			Params: []Param{{
+			Params: []ResourceParam{{
 				Name:  "name",
 				Value: "test-cluster-resource",
 			}, {
@@ -23,6 +23,7 @@ import (
 
 	"github.com/knative/pkg/apis"
 	duckv1beta1 "github.com/knative/pkg/apis/duck/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -35,12 +36,21 @@ var (
 		Version: SchemeGroupVersion.Version,
 		Kind:    pipelineRunControllerName,
 	}
+	taskRunGroupVersionKind = schema.GroupVersionKind{
+		Group:   SchemeGroupVersion.Group,
+		Version: SchemeGroupVersion.Version,
+		Kind:    "TaskRun",
+	}
 )
 
 // PipelineRunSpec defines the desired state of PipelineRun
 type PipelineRunSpec struct {
-	PipelineRef PipelineRef     `json:"pipelineRef"`
-	Trigger     PipelineTrigger `json:"trigger"`
+	PipelineRef PipelineRef `json:"pipelineRef"`
+	// PipelineSpec is an embedded Pipeline which is run instead of the one
+	// referenced by PipelineRef.
+	// +optional
+	PipelineSpec *PipelineSpec   `json:"pipelineSpec,omitempty"`
+	Trigger      PipelineTrigger `json:"trigger"`
 	// Resources is a list of bindings specifying which actual instances of
 	// PipelineResources to use for the resources the Pipeline has declared
 	// it needs.
@@ -49,6 +59,10 @@ type PipelineRunSpec struct {
 	Params []Param `json:"params"`
 	// +optional
 	ServiceAccount string `json:"serviceAccount"`
+	// ServiceAccounts overrides ServiceAccount for the TaskRuns of the
+	// PipelineTasks they name.
+	// +optional
+	ServiceAccounts []PipelineRunSpecServiceAccount `json:"serviceAccounts,omitempty"`
 	// +optional
 	Results *Results `json:"results,omitempty"`
 	// Used for cancelling a pipelinerun (and maybe more later on)
@@ -69,6 +83,62 @@ type PipelineRunSpec struct {
 	// If specified, the pod's scheduling constraints
 	// +optional
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// PodTemplate configures the pods the TaskRuns of the PipelineRun are run in.
+	// +optional
+	PodTemplate *PodTemplate `json:"podTemplate,omitempty"`
+	// Workspaces binds the workspaces declared by the Pipeline to volumes.
+	// +optional
+	Workspaces []WorkspaceBinding `json:"workspaces,omitempty"`
+	// Approvals are the decisions of the approvers of the PipelineTasks which
+	// are guarded by an approval.
+	// +optional
+	Approvals []PipelineRunApproval `json:"approvals,omitempty"`
+	// RerunOf references a previous PipelineRun of the same Pipeline which
+	// this PipelineRun reruns: the TaskRuns of the tasks which succeeded are
+	// reused, along with their results and the artifacts they stored, and
+	// only the other tasks and the tasks depending on them are run again.
+	// +optional
+	RerunOf *PipelineRunRef `json:"rerunOf,omitempty"`
+}
+
+// PipelineRunRef can be used to refer to another PipelineRun in the same
+// namespace.
+type PipelineRunRef struct {
+	// Name of the referent; More info: http://kubernetes.io/docs/user-guide/identifiers#names
+	Name string `json:"name"`
+}
+
+// PipelineRunSpecServiceAccount is the ServiceAccount the TaskRuns of a
+// PipelineTask are run with.
+type PipelineRunSpecServiceAccount struct {
+	// TaskName is the name of the PipelineTask
+	TaskName string `json:"taskName"`
+	// ServiceAccount is the name of the ServiceAccount
+	ServiceAccount string `json:"serviceAccount"`
+}
+
+// ApprovalDecision is the decision of an approver about a PipelineTask
+type ApprovalDecision string
+
+const (
+	// ApprovalDecisionApproved indicates that the PipelineTask can be run
+	ApprovalDecisionApproved ApprovalDecision = "Approved"
+	// ApprovalDecisionRejected indicates that the PipelineTask must not be run,
+	// which fails the PipelineRun
+	ApprovalDecisionRejected ApprovalDecision = "Rejected"
+)
+
+// PipelineRunApproval is the decision given by an approver about a
+// PipelineTask waiting for approval.
+type PipelineRunApproval struct {
+	// PipelineTask is the name of the PipelineTask
+	PipelineTask string `json:"pipelineTask"`
+	// Decision is either Approved or Rejected
+	Decision ApprovalDecision `json:"decision"`
+	// Approver is the name of the user who gave the decision, which is set by
+	// the webhook.
+	// +optional
+	Approver string `json:"approver,omitempty"`
 }
 
 // PipelineRunSpecStatus defines the pipelinerun spec status the user can provide
@@ -78,6 +148,10 @@ const (
 	// PipelineRunSpecStatusCancelled indicates that the user wants to cancel the task,
 	// if not already cancelled or terminated
 	PipelineRunSpecStatusCancelled = "PipelineRunCancelled"
+
+	// PipelineRunSpecStatusPaused indicates that the user wants to pause the pipelinerun:
+	// its running tasks are left to finish but no new ones are started until it is resumed
+	PipelineRunSpecStatusPaused = "PipelineRunPaused"
 )
 
 // PipelineResourceRef can be used to refer to a specific instance of a Resource
@@ -131,9 +205,67 @@ type PipelineRunStatus struct {
 	// +optional
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 
+	// PausedTime is the time the PipelineRun was paused, while it is paused.
+	// +optional
+	PausedTime *metav1.Time `json:"pausedTime,omitempty"`
+
+	// PausedDuration is the time the PipelineRun spent paused before it was
+	// last resumed, which doesn't count towards its timeout.
+	// +optional
+	PausedDuration *metav1.Duration `json:"pausedDuration,omitempty"`
+
 	// map of PipelineRunTaskRunStatus with the taskRun name as the key
 	// +optional
 	TaskRuns map[string]*PipelineRunTaskRunStatus `json:"taskRuns,omitempty"`
+
+	// map of PipelineRunChildStatus with the name of the child PipelineRun as
+	// the key, for the PipelineTasks which run another Pipeline
+	// +optional
+	ChildPipelineRuns map[string]*PipelineRunChildStatus `json:"childPipelineRuns,omitempty"`
+
+	// map of PipelineRunRunStatus with the name of the Run as the key, for the
+	// PipelineTasks which run a custom task
+	// +optional
+	Runs map[string]*PipelineRunRunStatus `json:"runs,omitempty"`
+
+	// SkippedTasks lists the PipelineTasks which were not run because one of
+	// their conditions, or one of the conditions of a Task they depend on,
+	// wasn't met.
+	// +optional
+	SkippedTasks []SkippedTask `json:"skippedTasks,omitempty"`
+
+	// PendingApprovals lists the PipelineTasks which are ready to run but are
+	// waiting for one of their approvers to approve them.
+	// +optional
+	PendingApprovals []PipelineRunPendingApproval `json:"pendingApprovals,omitempty"`
+
+	// PipelineResults are the values of the results declared by the Pipeline,
+	// which are set once the PipelineRun has finished executing.
+	// +optional
+	PipelineResults []PipelineRunResult `json:"pipelineResults,omitempty"`
+}
+
+// PipelineRunResult is the value of one of the results declared by the Pipeline.
+type PipelineRunResult struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// SkippedTask is used to describe a PipelineTask that was skipped.
+type SkippedTask struct {
+	// Name is the name of the PipelineTask
+	Name string `json:"name"`
+	// Reason explains why the PipelineTask was skipped
+	Reason string `json:"reason"`
+}
+
+// PipelineRunPendingApproval is used to describe a PipelineTask waiting for
+// approval.
+type PipelineRunPendingApproval struct {
+	// PipelineTask is the name of the PipelineTask
+	PipelineTask string `json:"pipelineTask"`
+	// Approvers are the names of the users allowed to approve the PipelineTask
+	Approvers []string `json:"approvers"`
 }
 
 // PipelineRunTaskRunStatus contains the name of the PipelineTask for this TaskRun and the TaskRun's Status
@@ -143,6 +275,48 @@ type PipelineRunTaskRunStatus struct {
 	// Status is the TaskRunStatus for the corresponding TaskRun
 	// +optional
 	Status *TaskRunStatus `json:"status,omitempty"`
+	// ConditionChecks maps the name of the TaskRun running a condition check
+	// to the status of that check
+	// +optional
+	ConditionChecks map[string]*PipelineRunConditionCheckStatus `json:"conditionChecks,omitempty"`
+}
+
+// PipelineRunChildStatus contains the name of the PipelineTask for this child PipelineRun
+// and the child PipelineRun's Status
+type PipelineRunChildStatus struct {
+	// PipelineTaskName is the name of the PipelineTask
+	PipelineTaskName string `json:"pipelineTaskName"`
+	// Status is the PipelineRunStatus for the corresponding child PipelineRun
+	// +optional
+	Status *PipelineRunStatus `json:"status,omitempty"`
+	// ConditionChecks maps the name of the TaskRun running a condition check
+	// to the status of that check
+	// +optional
+	ConditionChecks map[string]*PipelineRunConditionCheckStatus `json:"conditionChecks,omitempty"`
+}
+
+// PipelineRunRunStatus contains the name of the PipelineTask for this Run and the
+// Run's Status
+type PipelineRunRunStatus struct {
+	// PipelineTaskName is the name of the PipelineTask
+	PipelineTaskName string `json:"pipelineTaskName"`
+	// Status is the RunStatus for the corresponding Run
+	// +optional
+	Status *RunStatus `json:"status,omitempty"`
+	// ConditionChecks maps the name of the TaskRun running a condition check
+	// to the status of that check
+	// +optional
+	ConditionChecks map[string]*PipelineRunConditionCheckStatus `json:"conditionChecks,omitempty"`
+}
+
+// PipelineRunConditionCheckStatus contains the name of the condition and the
+// status of the TaskRun which checked it
+type PipelineRunConditionCheckStatus struct {
+	// ConditionName is the name of the PipelineTaskCondition
+	ConditionName string `json:"conditionName"`
+	// Status is the TaskRunStatus for the corresponding condition check TaskRun
+	// +optional
+	Status *TaskRunStatus `json:"status,omitempty"`
 }
 
 var pipelineRunCondSet = apis.NewBatchConditionSet()
@@ -214,8 +388,19 @@ func (pr *PipelineRun) GetTaskRunRef() corev1.ObjectReference {
 	}
 }
 
-// SetDefaults for pipelinerun
-func (pr *PipelineRun) SetDefaults(ctx context.Context) {}
+// SetDefaults for pipelinerun: the decisions added to the approvals by the user making the
+// request are given their name.
+func (pr *PipelineRun) SetDefaults(ctx context.Context) {
+	ui := apis.GetUserInfo(ctx)
+	if ui == nil {
+		return
+	}
+	for i := range pr.Spec.Approvals {
+		if pr.Spec.Approvals[i].Approver == "" {
+			pr.Spec.Approvals[i].Approver = ui.Username
+		}
+	}
+}
 
 // GetOwnerReference gets the pipeline run as owner reference for any related objects
 func (pr *PipelineRun) GetOwnerReference() []metav1.OwnerReference {
@@ -239,6 +424,50 @@ func (pr *PipelineRun) IsCancelled() bool {
 	return pr.Spec.Status == PipelineRunSpecStatusCancelled
 }
 
+// IsPaused returns true if the PipelineRun's spec status is set to Paused state
+func (pr *PipelineRun) IsPaused() bool {
+	return pr.Spec.Status == PipelineRunSpecStatusPaused
+}
+
+// GetServiceAccount returns the ServiceAccount the TaskRuns of the PipelineTask named
+// pipelineTaskName are run with: the one mapped to it, if any, or the one of the PipelineRun.
+func (pr *PipelineRun) GetServiceAccount(pipelineTaskName string) string {
+	for _, sa := range pr.Spec.ServiceAccounts {
+		if sa.TaskName == pipelineTaskName {
+			return sa.ServiceAccount
+		}
+	}
+	return pr.Spec.ServiceAccount
+}
+
+// TimeoutStartTime returns the time from which the timeout of the PipelineRun is counted,
+// which is its start time postponed by the time it has spent paused.
+func (pr *PipelineRun) TimeoutStartTime() *metav1.Time {
+	if pr.Status.StartTime == nil {
+		return nil
+	}
+	paused := time.Duration(0)
+	if pr.Status.PausedDuration != nil {
+		paused += pr.Status.PausedDuration.Duration
+	}
+	if pr.Status.PausedTime != nil {
+		paused += time.Since(pr.Status.PausedTime.Time)
+	}
+	if paused == 0 {
+		return pr.Status.StartTime
+	}
+	return &metav1.Time{Time: pr.Status.StartTime.Add(paused)}
+}
+
+// GetArtifactStorageName returns the name of the PipelineRun whose artifact storage is used
+// by the PipelineRun: its own, unless it reuses the storage of a previous PipelineRun it reruns.
+func (pr *PipelineRun) GetArtifactStorageName() string {
+	if name := pr.Labels[pipeline.GroupName+pipeline.ArtifactStorageLabelKey]; name != "" {
+		return name
+	}
+	return pr.Name
+}
+
 // GetRunKey return the pipelinerun key for timeout handler map
 func (pr *PipelineRun) GetRunKey() string {
 	return fmt.Sprintf("%s/%s/%s", pipelineRunControllerName, pr.Namespace, pr.Name)
@@ -1,11 +1,13 @@
 package v1alpha1
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/knative/pkg/apis"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -111,6 +113,70 @@ func TestPipelineRunIsCancelled(t *testing.T) {
 	}
 }
 
+func TestPipelineRunIsPaused(t *testing.T) {
+	pr := &PipelineRun{
+		Spec: PipelineRunSpec{
+			Status: PipelineRunSpecStatusPaused,
+		},
+	}
+	if !pr.IsPaused() {
+		t.Fatal("Expected pipelinerun status to be paused")
+	}
+}
+
+func TestPipelineRunGetServiceAccount(t *testing.T) {
+	pr := &PipelineRun{
+		Spec: PipelineRunSpec{
+			ServiceAccount: "default-sa",
+			ServiceAccounts: []PipelineRunSpecServiceAccount{
+				{TaskName: "deploy", ServiceAccount: "deployer"},
+			},
+		},
+	}
+	if sa := pr.GetServiceAccount("deploy"); sa != "deployer" {
+		t.Errorf("Expected the service account of deploy to be deployer but got %s", sa)
+	}
+	if sa := pr.GetServiceAccount("test"); sa != "default-sa" {
+		t.Errorf("Expected the service account of test to be default-sa but got %s", sa)
+	}
+}
+
+func TestPipelineRunTimeoutStartTime(t *testing.T) {
+	startTime := time.Now().Add(-3 * time.Hour)
+	for _, tc := range []struct {
+		name   string
+		status PipelineRunStatus
+		want   time.Time
+	}{{
+		name:   "never paused",
+		status: PipelineRunStatus{StartTime: &metav1.Time{Time: startTime}},
+		want:   startTime,
+	}, {
+		name: "resumed",
+		status: PipelineRunStatus{
+			StartTime:      &metav1.Time{Time: startTime},
+			PausedDuration: &metav1.Duration{Duration: time.Hour},
+		},
+		want: startTime.Add(time.Hour),
+	}, {
+		name: "paused",
+		status: PipelineRunStatus{
+			StartTime:      &metav1.Time{Time: startTime},
+			PausedDuration: &metav1.Duration{Duration: time.Hour},
+			PausedTime:     &metav1.Time{Time: time.Now().Add(-time.Hour)},
+		},
+		want: startTime.Add(2 * time.Hour),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &PipelineRun{Status: tc.status}
+			got := pr.TimeoutStartTime()
+			if d := got.Time.Sub(tc.want); d < 0 || d > time.Minute {
+				t.Errorf("Expected the timeout to be counted from %v but got %v", tc.want, got)
+			}
+		})
+	}
+}
+
 func TestPipelineRunKey(t *testing.T) {
 	pr := &PipelineRun{
 		ObjectMeta: metav1.ObjectMeta{
@@ -161,3 +227,24 @@ func TestPipelineRunHasStarted(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineRunSetDefaults_Approvals(t *testing.T) {
+	pr := &PipelineRun{
+		Spec: PipelineRunSpec{
+			Approvals: []PipelineRunApproval{
+				{PipelineTask: "build", Decision: ApprovalDecisionApproved, Approver: "alice"},
+				{PipelineTask: "deploy", Decision: ApprovalDecisionApproved},
+			},
+		},
+	}
+	ctx := apis.WithUserInfo(context.Background(), &authenticationv1.UserInfo{Username: "bob"})
+	pr.SetDefaults(ctx)
+
+	want := []PipelineRunApproval{
+		{PipelineTask: "build", Decision: ApprovalDecisionApproved, Approver: "alice"},
+		{PipelineTask: "deploy", Decision: ApprovalDecisionApproved, Approver: "bob"},
+	}
+	if d := cmp.Diff(want, pr.Spec.Approvals); d != "" {
+		t.Errorf("PipelineRun.SetDefaults() approvals (-want, +got) = %v", d)
+	}
+}
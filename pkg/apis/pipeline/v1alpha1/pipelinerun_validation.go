@@ -29,7 +29,13 @@ func (pr *PipelineRun) Validate(ctx context.Context) *apis.FieldError {
 	if err := validateObjectMetadata(pr.GetObjectMeta()).ViaField("metadata"); err != nil {
 		return err
 	}
-	return pr.Spec.Validate(ctx)
+	if err := pr.Spec.Validate(ctx); err != nil {
+		return err
+	}
+	if pr.Spec.RerunOf != nil && pr.Spec.RerunOf.Name == pr.Name {
+		return apis.ErrInvalidValue("a PipelineRun can't rerun itself", "spec.rerunOf.name")
+	}
+	return pr.validateApprovers(ctx)
 }
 
 // Validate pipelinerun spec
@@ -37,9 +43,18 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) *apis.FieldError {
 	if equality.Semantic.DeepEqual(ps, &PipelineRunSpec{}) {
 		return apis.ErrMissingField("spec")
 	}
-	// pipeline reference should be present for pipelinerun
-	if ps.PipelineRef.Name == "" {
-		return apis.ErrMissingField("pipelinerun.spec.Pipelineref.Name")
+	// can't have both pipelineRef and pipelineSpec at the same time
+	if ps.PipelineRef.Name != "" && ps.PipelineSpec != nil {
+		return apis.ErrDisallowedFields("pipelinerun.spec.pipelineref", "pipelinerun.spec.pipelinespec")
+	}
+	// pipeline reference or embedded pipeline spec should be present for pipelinerun
+	if ps.PipelineRef.Name == "" && ps.PipelineSpec == nil {
+		return apis.ErrMissingField("pipelinerun.spec.Pipelineref.Name", "pipelinerun.spec.pipelinespec")
+	}
+	if ps.PipelineSpec != nil {
+		if err := ps.PipelineSpec.Validate(ctx); err != nil {
+			return err
+		}
 	}
 	if ps.Trigger.Type != PipelineTriggerTypeManual {
 		return apis.ErrInvalidValue(string(ps.Trigger.Type), "pipelinerun.spec.trigger.type")
@@ -51,6 +66,14 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if err := validatePipelineResourceBindings(ctx, ps.Resources, "spec.resources"); err != nil {
+		return err
+	}
+
+	if err := validateWorkspaceBindings(ps.Workspaces).ViaField("spec.workspaces"); err != nil {
+		return err
+	}
+
 	if ps.Timeout != nil {
 		// timeout should be a valid duration of at least 0.
 		if ps.Timeout.Duration <= 0 {
@@ -58,5 +81,149 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if ps.Status != "" && ps.Status != PipelineRunSpecStatusCancelled && ps.Status != PipelineRunSpecStatusPaused {
+		return apis.ErrInvalidValue(string(ps.Status), "spec.status")
+	}
+
+	if err := ps.validateServiceAccounts(); err != nil {
+		return err
+	}
+
+	if err := validateApprovals(ps.Approvals); err != nil {
+		return err
+	}
+
+	if ps.RerunOf != nil && ps.RerunOf.Name == "" {
+		return apis.ErrMissingField("spec.rerunOf.name")
+	}
+
+	return nil
+}
+
+// validatePipelineResourceBindings ensures that each PipelineResource is bound at most once,
+// either to a reference or to a valid spec.
+func validatePipelineResourceBindings(ctx context.Context, bindings []PipelineResourceBinding, path string) *apis.FieldError {
+	encountered := map[string]struct{}{}
+	for _, b := range bindings {
+		if _, ok := encountered[b.Name]; ok {
+			return apis.ErrMultipleOneOf(path)
+		}
+		encountered[b.Name] = struct{}{}
+		if b.ResourceRef.Name != "" && b.ResourceSpec != nil {
+			return apis.ErrDisallowedFields(fmt.Sprintf("%s.resourceRef", path), fmt.Sprintf("%s.resourceSpec", path))
+		}
+		if b.ResourceRef.Name == "" && b.ResourceSpec == nil {
+			return apis.ErrMissingField(fmt.Sprintf("%s.resourceRef", path), fmt.Sprintf("%s.resourceSpec", path))
+		}
+		if b.ResourceSpec != nil {
+			if err := b.ResourceSpec.Validate(ctx); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
+
+// validateServiceAccounts ensures that each PipelineTask is mapped to at most one
+// ServiceAccount and, when the Pipeline is embedded, that it is one of its PipelineTasks.
+func (ps *PipelineRunSpec) validateServiceAccounts() *apis.FieldError {
+	var tasks map[string]struct{}
+	if ps.PipelineSpec != nil {
+		tasks = map[string]struct{}{}
+		for _, pt := range append(ps.PipelineSpec.Tasks, ps.PipelineSpec.Finally...) {
+			tasks[pt.Name] = struct{}{}
+		}
+	}
+	seen := map[string]struct{}{}
+	for _, sa := range ps.ServiceAccounts {
+		if sa.TaskName == "" {
+			return apis.ErrMissingField("spec.serviceAccounts.taskName")
+		}
+		if sa.ServiceAccount == "" {
+			return apis.ErrMissingField("spec.serviceAccounts.serviceAccount")
+		}
+		if _, ok := seen[sa.TaskName]; ok {
+			return apis.ErrMultipleOneOf("spec.serviceAccounts.taskName")
+		}
+		seen[sa.TaskName] = struct{}{}
+		if _, ok := tasks[sa.TaskName]; tasks != nil && !ok {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s isn't a task of the pipeline", sa.TaskName), "spec.serviceAccounts.taskName")
+		}
+	}
+	return nil
+}
+
+// validateApprovals ensures that each PipelineTask is given at most one decision, which is
+// either Approved or Rejected.
+func validateApprovals(approvals []PipelineRunApproval) *apis.FieldError {
+	seen := map[string]struct{}{}
+	for _, a := range approvals {
+		if a.PipelineTask == "" {
+			return apis.ErrMissingField("spec.approvals.pipelineTask")
+		}
+		if _, ok := seen[a.PipelineTask]; ok {
+			return apis.ErrMultipleOneOf("spec.approvals.pipelineTask")
+		}
+		seen[a.PipelineTask] = struct{}{}
+		if a.Decision != ApprovalDecisionApproved && a.Decision != ApprovalDecisionRejected {
+			return apis.ErrInvalidValue(string(a.Decision), "spec.approvals.decision")
+		}
+	}
+	return nil
+}
+
+// validateApprovers ensures, when the user making the request is known, that the decisions
+// they add to the PipelineRun are their own and are about PipelineTasks they are allowed to
+// approve, which are listed in the pending approvals of the PipelineRun being updated. The
+// decisions which were already given can't be changed.
+func (pr *PipelineRun) validateApprovers(ctx context.Context) *apis.FieldError {
+	ui := apis.GetUserInfo(ctx)
+	if ui == nil {
+		return nil
+	}
+	given := map[string]PipelineRunApproval{}
+	var pending []PipelineRunPendingApproval
+	if base, ok := apis.GetBaseline(ctx).(*PipelineRun); ok && base != nil {
+		for _, a := range base.Spec.Approvals {
+			given[a.PipelineTask] = a
+		}
+		pending = base.Status.PendingApprovals
+	}
+
+	kept := 0
+	for _, a := range pr.Spec.Approvals {
+		if old, ok := given[a.PipelineTask]; ok {
+			if old != a {
+				return apis.ErrInvalidValue(fmt.Sprintf("the decision about %s can't be changed", a.PipelineTask), "spec.approvals")
+			}
+			kept++
+			continue
+		}
+		if a.Approver != ui.Username {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s can't give a decision on behalf of %s", ui.Username, a.Approver), "spec.approvals.approver")
+		}
+		if !isPendingApprover(pending, a.PipelineTask, ui.Username) {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s isn't allowed to approve %s", ui.Username, a.PipelineTask), "spec.approvals")
+		}
+	}
+	if kept != len(given) {
+		return apis.ErrInvalidValue("decisions can't be removed once given", "spec.approvals")
+	}
+	return nil
+}
+
+// isPendingApprover returns true if the PipelineTask named pipelineTask is waiting for approval
+// and user is one of its approvers.
+func isPendingApprover(pending []PipelineRunPendingApproval, pipelineTask, user string) bool {
+	for _, p := range pending {
+		if p.PipelineTask != pipelineTask {
+			continue
+		}
+		for _, a := range p.Approvers {
+			if a == user {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -22,6 +22,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/knative/pkg/apis"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -63,7 +65,45 @@ func TestPipelineRun_Invalidate(t *testing.T) {
 					},
 				},
 			},
-			want: apis.ErrMissingField("pipelinerun.spec.Pipelineref.Name"),
+			want: apis.ErrMissingField("pipelinerun.spec.Pipelineref.Name", "pipelinerun.spec.pipelinespec"),
+		}, {
+			name: "pipeline reference and embedded pipeline spec together",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{
+						Name: "prname",
+					},
+					PipelineSpec: &PipelineSpec{
+						Tasks: []PipelineTask{{Name: "mytask", TaskRef: TaskRef{Name: "mytask"}}},
+					},
+					Trigger: PipelineTrigger{
+						Type: PipelineTriggerTypeManual,
+					},
+				},
+			},
+			want: apis.ErrDisallowedFields("pipelinerun.spec.pipelineref", "pipelinerun.spec.pipelinespec"),
+		}, {
+			name: "invalid embedded pipeline spec",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineSpec: &PipelineSpec{
+						Tasks: []PipelineTask{
+							{Name: "mytask", TaskRef: TaskRef{Name: "mytask"}},
+							{Name: "mytask", TaskRef: TaskRef{Name: "mytask"}},
+						},
+					},
+					Trigger: PipelineTrigger{
+						Type: PipelineTriggerTypeManual,
+					},
+				},
+			},
+			want: apis.ErrMultipleOneOf("spec.tasks.name"),
 		}, {
 			name: "invalid trigger reference",
 			pr: PipelineRun{
@@ -97,6 +137,187 @@ func TestPipelineRun_Invalidate(t *testing.T) {
 				},
 			},
 			want: apis.ErrInvalidValue("-48h0m0s should be > 0", "spec.timeout"),
+		}, {
+			name: "workspace bound to several volumes",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "pipelinelineName",
+				},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{
+						Name: "prname",
+					},
+					Trigger: PipelineTrigger{
+						Type: PipelineTriggerTypeManual,
+					},
+					Workspaces: []WorkspaceBinding{{
+						Name:     "source",
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+							ObjectMeta: metav1.ObjectMeta{Name: "pvc"},
+						},
+					}},
+				},
+			},
+			want: apis.ErrMultipleOneOf("spec.workspaces.volumeClaimTemplate", "spec.workspaces.emptyDir"),
+		}, {
+			name: "invalid spec status",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinelineName"},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{Name: "prname"},
+					Trigger:     PipelineTrigger{Type: PipelineTriggerTypeManual},
+					Status:      "PipelineRunStopped",
+				},
+			},
+			want: apis.ErrInvalidValue("PipelineRunStopped", "spec.status"),
+		}, {
+			name: "rerun without name",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinelineName"},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{Name: "prname"},
+					Trigger:     PipelineTrigger{Type: PipelineTriggerTypeManual},
+					RerunOf:     &PipelineRunRef{},
+				},
+			},
+			want: apis.ErrMissingField("spec.rerunOf.name"),
+		}, {
+			name: "rerun of itself",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinelineName"},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{Name: "prname"},
+					Trigger:     PipelineTrigger{Type: PipelineTriggerTypeManual},
+					RerunOf:     &PipelineRunRef{Name: "pipelinelineName"},
+				},
+			},
+			want: apis.ErrInvalidValue("a PipelineRun can't rerun itself", "spec.rerunOf.name"),
+		}, {
+			name: "approval without pipelineTask",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinelineName"},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{Name: "prname"},
+					Trigger:     PipelineTrigger{Type: PipelineTriggerTypeManual},
+					Approvals:   []PipelineRunApproval{{Decision: ApprovalDecisionApproved}},
+				},
+			},
+			want: apis.ErrMissingField("spec.approvals.pipelineTask"),
+		}, {
+			name: "invalid approval decision",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinelineName"},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{Name: "prname"},
+					Trigger:     PipelineTrigger{Type: PipelineTriggerTypeManual},
+					Approvals:   []PipelineRunApproval{{PipelineTask: "deploy", Decision: "Maybe"}},
+				},
+			},
+			want: apis.ErrInvalidValue("Maybe", "spec.approvals.decision"),
+		}, {
+			name: "several decisions about the same task",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinelineName"},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{Name: "prname"},
+					Trigger:     PipelineTrigger{Type: PipelineTriggerTypeManual},
+					Approvals: []PipelineRunApproval{
+						{PipelineTask: "deploy", Decision: ApprovalDecisionApproved},
+						{PipelineTask: "deploy", Decision: ApprovalDecisionRejected},
+					},
+				},
+			},
+			want: apis.ErrMultipleOneOf("spec.approvals.pipelineTask"),
+		}, {
+			name: "resource bound to both a reference and a spec",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinelineName"},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{Name: "prname"},
+					Trigger:     PipelineTrigger{Type: PipelineTriggerTypeManual},
+					Resources: []PipelineResourceBinding{{
+						Name:         "git-resource",
+						ResourceRef:  PipelineResourceRef{Name: "sweet-resource"},
+						ResourceSpec: &PipelineResourceSpec{Type: PipelineResourceTypeGit},
+					}},
+				},
+			},
+			want: apis.ErrDisallowedFields("spec.resources.resourceRef", "spec.resources.resourceSpec"),
+		}, {
+			name: "resource bound to neither a reference nor a spec",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinelineName"},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{Name: "prname"},
+					Trigger:     PipelineTrigger{Type: PipelineTriggerTypeManual},
+					Resources:   []PipelineResourceBinding{{Name: "git-resource"}},
+				},
+			},
+			want: apis.ErrMissingField("spec.resources.resourceRef", "spec.resources.resourceSpec"),
+		}, {
+			name: "resource bound twice",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinelineName"},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{Name: "prname"},
+					Trigger:     PipelineTrigger{Type: PipelineTriggerTypeManual},
+					Resources: []PipelineResourceBinding{
+						{Name: "git-resource", ResourceRef: PipelineResourceRef{Name: "sweet-resource"}},
+						{Name: "git-resource", ResourceRef: PipelineResourceRef{Name: "other-resource"}},
+					},
+				},
+			},
+			want: apis.ErrMultipleOneOf("spec.resources"),
+		}, {
+			name: "service account without task name",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinelineName"},
+				Spec: PipelineRunSpec{
+					PipelineRef:     PipelineRef{Name: "prname"},
+					Trigger:         PipelineTrigger{Type: PipelineTriggerTypeManual},
+					ServiceAccounts: []PipelineRunSpecServiceAccount{{ServiceAccount: "deployer"}},
+				},
+			},
+			want: apis.ErrMissingField("spec.serviceAccounts.taskName"),
+		}, {
+			name: "task name without service account",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinelineName"},
+				Spec: PipelineRunSpec{
+					PipelineRef:     PipelineRef{Name: "prname"},
+					Trigger:         PipelineTrigger{Type: PipelineTriggerTypeManual},
+					ServiceAccounts: []PipelineRunSpecServiceAccount{{TaskName: "deploy"}},
+				},
+			},
+			want: apis.ErrMissingField("spec.serviceAccounts.serviceAccount"),
+		}, {
+			name: "several service accounts for the same task",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinelineName"},
+				Spec: PipelineRunSpec{
+					PipelineRef: PipelineRef{Name: "prname"},
+					Trigger:     PipelineTrigger{Type: PipelineTriggerTypeManual},
+					ServiceAccounts: []PipelineRunSpecServiceAccount{
+						{TaskName: "deploy", ServiceAccount: "deployer"},
+						{TaskName: "deploy", ServiceAccount: "tester"},
+					},
+				},
+			},
+			want: apis.ErrMultipleOneOf("spec.serviceAccounts.taskName"),
+		}, {
+			name: "service account for a task missing from the embedded pipeline",
+			pr: PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinelineName"},
+				Spec: PipelineRunSpec{
+					PipelineSpec: &PipelineSpec{
+						Tasks: []PipelineTask{{Name: "test", TaskRef: TaskRef{Name: "test-task"}}},
+					},
+					Trigger:         PipelineTrigger{Type: PipelineTriggerTypeManual},
+					ServiceAccounts: []PipelineRunSpecServiceAccount{{TaskName: "deploy", ServiceAccount: "deployer"}},
+				},
+			},
+			want: apis.ErrInvalidValue("deploy isn't a task of the pipeline", "spec.serviceAccounts.taskName"),
 		},
 	}
 
@@ -132,3 +353,115 @@ func TestPipelineRun_Validate(t *testing.T) {
 		t.Errorf("Unexpected PipelineRun.Validate() error = %v", err)
 	}
 }
+
+func TestPipelineRun_Validate_EmbeddedPipelineSpec(t *testing.T) {
+	pr := PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipelinelineName",
+		},
+		Spec: PipelineRunSpec{
+			PipelineSpec: &PipelineSpec{
+				Tasks: []PipelineTask{{Name: "mytask", TaskRef: TaskRef{Name: "mytask"}}},
+			},
+			Trigger: PipelineTrigger{
+				Type: PipelineTriggerTypeManual,
+			},
+		},
+	}
+	if err := pr.Validate(context.Background()); err != nil {
+		t.Errorf("Unexpected PipelineRun.Validate() error = %v", err)
+	}
+}
+
+func TestPipelineRun_ValidateApprovals(t *testing.T) {
+	pendingDeploy := PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+		Spec: PipelineRunSpec{
+			PipelineRef: PipelineRef{Name: "p"},
+			Trigger:     PipelineTrigger{Type: PipelineTriggerTypeManual},
+		},
+		Status: PipelineRunStatus{
+			PendingApprovals: []PipelineRunPendingApproval{{PipelineTask: "deploy", Approvers: []string{"alice", "bob"}}},
+		},
+	}
+	approvedDeploy := pendingDeploy.DeepCopy()
+	approvedDeploy.Spec.Approvals = []PipelineRunApproval{{PipelineTask: "deploy", Decision: ApprovalDecisionApproved, Approver: "alice"}}
+
+	withApprovals := func(base *PipelineRun, approvals ...PipelineRunApproval) *PipelineRun {
+		pr := base.DeepCopy()
+		pr.Spec.Approvals = approvals
+		return pr
+	}
+
+	tests := []struct {
+		name string
+		user string
+		base *PipelineRun
+		pr   *PipelineRun
+		want *apis.FieldError
+	}{{
+		name: "approval by an approver",
+		user: "bob",
+		base: &pendingDeploy,
+		pr:   withApprovals(&pendingDeploy, PipelineRunApproval{PipelineTask: "deploy", Decision: ApprovalDecisionApproved}),
+	}, {
+		name: "rejection by an approver",
+		user: "alice",
+		base: &pendingDeploy,
+		pr:   withApprovals(&pendingDeploy, PipelineRunApproval{PipelineTask: "deploy", Decision: ApprovalDecisionRejected}),
+	}, {
+		name: "other changes by anyone once approved",
+		user: "carol",
+		base: approvedDeploy,
+		pr:   approvedDeploy.DeepCopy(),
+	}, {
+		name: "approval by someone else",
+		user: "carol",
+		base: &pendingDeploy,
+		pr:   withApprovals(&pendingDeploy, PipelineRunApproval{PipelineTask: "deploy", Decision: ApprovalDecisionApproved}),
+		want: apis.ErrInvalidValue("carol isn't allowed to approve deploy", "spec.approvals"),
+	}, {
+		name: "approval on behalf of an approver",
+		user: "carol",
+		base: &pendingDeploy,
+		pr:   withApprovals(&pendingDeploy, PipelineRunApproval{PipelineTask: "deploy", Decision: ApprovalDecisionApproved, Approver: "alice"}),
+		want: apis.ErrInvalidValue("carol can't give a decision on behalf of alice", "spec.approvals.approver"),
+	}, {
+		name: "approval of a task which isn't pending",
+		user: "alice",
+		base: &pendingDeploy,
+		pr:   withApprovals(&pendingDeploy, PipelineRunApproval{PipelineTask: "release", Decision: ApprovalDecisionApproved}),
+		want: apis.ErrInvalidValue("alice isn't allowed to approve release", "spec.approvals"),
+	}, {
+		name: "approval on creation",
+		user: "alice",
+		pr:   withApprovals(&pendingDeploy, PipelineRunApproval{PipelineTask: "deploy", Decision: ApprovalDecisionApproved}),
+		want: apis.ErrInvalidValue("alice isn't allowed to approve deploy", "spec.approvals"),
+	}, {
+		name: "changed decision",
+		user: "alice",
+		base: approvedDeploy,
+		pr:   withApprovals(approvedDeploy, PipelineRunApproval{PipelineTask: "deploy", Decision: ApprovalDecisionRejected, Approver: "alice"}),
+		want: apis.ErrInvalidValue("the decision about deploy can't be changed", "spec.approvals"),
+	}, {
+		name: "removed decision",
+		user: "alice",
+		base: approvedDeploy,
+		pr:   withApprovals(approvedDeploy),
+		want: apis.ErrInvalidValue("decisions can't be removed once given", "spec.approvals"),
+	}}
+
+	for _, ts := range tests {
+		t.Run(ts.name, func(t *testing.T) {
+			ctx := apis.WithUserInfo(context.Background(), &authenticationv1.UserInfo{Username: ts.user})
+			if ts.base != nil {
+				ctx = apis.WithinUpdate(ctx, ts.base)
+			}
+			ts.pr.SetDefaults(ctx)
+			err := ts.pr.Validate(ctx)
+			if d := cmp.Diff(ts.want.Error(), err.Error()); d != "" {
+				t.Errorf("PipelineRun.Validate/%s (-want, +got) = %v", ts.name, d)
+			}
+		})
+	}
+}
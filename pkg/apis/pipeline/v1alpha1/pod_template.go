@@ -0,0 +1,53 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodTemplate holds the configuration of the pod a TaskRun is run in, which
+// isn't specified by its Task.
+type PodTemplate struct {
+	// SecurityContext holds the pod-level security attributes and common
+	// container settings.
+	// +optional
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+	// RuntimeClassName is the name of the RuntimeClass used to run the pod.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+	// PriorityClassName is the name of the PriorityClass which gives the
+	// priority of the pod.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// SchedulerName is the name of the scheduler which schedules the pod.
+	// +optional
+	SchedulerName string `json:"schedulerName,omitempty"`
+	// HostAliases are the hosts and IPs added to the hosts file of the pod.
+	// +optional
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+	// DNSConfig holds the DNS parameters of the pod.
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+	// ImagePullSecrets are the secrets used to pull the images of the pod.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Volumes are added to the volumes of the pod, so that the steps of
+	// the Task can mount them.
+	// +optional
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+}
@@ -64,10 +64,16 @@ type SecretParam struct {
 	SecretName string `json:"secretName"`
 }
 
+// ResourceParam declares a string value to use for the parameter called Name.
+type ResourceParam struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
 // PipelineResourceSpec defines  an individual resources used in the pipeline.
 type PipelineResourceSpec struct {
 	Type   PipelineResourceType `json:"type"`
-	Params []Param              `json:"params"`
+	Params []ResourceParam      `json:"params"`
 	// Secrets to fetch to populate some of resource fields
 	// +optional
 	SecretParams []SecretParam `json:"secrets,omitempty"`
@@ -122,7 +128,13 @@ type PipelineResourceBinding struct {
 	Name string `json:"name"`
 	// ResourceRef is a reference to the instance of the actual PipelineResource
 	// that should be used
+	// +optional
 	ResourceRef PipelineResourceRef `json:"resourceRef"`
+	// ResourceSpec is the spec of the PipelineResource to use instead of a
+	// reference to an instance of one. No more than one of ResourceRef and
+	// ResourceSpec may be specified.
+	// +optional
+	ResourceSpec *PipelineResourceSpec `json:"resourceSpec,omitempty"`
 }
 
 // TaskResourceBinding points to the PipelineResource that
@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// resultRefRegex matches the variables used to reference the result of another
+// PipelineTask, e.g. ${tasks.build.results.digest}
+var resultRefRegex = regexp.MustCompile(`\$\{tasks\.([^.{}]+)\.results\.([^.{}]+)\}`)
+
+// ResultRef is a reference to a result reported by the TaskRun of another PipelineTask.
+type ResultRef struct {
+	// PipelineTask is the name of the PipelineTask which reports the result.
+	PipelineTask string
+	// Result is the name of the result.
+	Result string
+}
+
+// Variable returns the name of the variable to replace with the value of the result.
+func (r ResultRef) Variable() string {
+	return fmt.Sprintf("tasks.%s.results.%s", r.PipelineTask, r.Result)
+}
+
+// ResultRefs returns the references to the results of other PipelineTasks made in the
+// params of pt, without duplicates.
+func (pt PipelineTask) ResultRefs() []ResultRef {
+	values := []string{}
+	for _, p := range pt.Params {
+		values = append(values, p.Value.Strings()...)
+	}
+	return getResultRefs(values...)
+}
+
+// ResultRefs returns the references to the results of PipelineTasks made in the value
+// of p, without duplicates.
+func (p Param) ResultRefs() []ResultRef {
+	return getResultRefs(p.Value.Strings()...)
+}
+
+// ResultRefs returns the references to the results of PipelineTasks made in the value
+// of r, without duplicates.
+func (r PipelineResult) ResultRefs() []ResultRef {
+	return getResultRefs(r.Value)
+}
+
+func getResultRefs(values ...string) []ResultRef {
+	refs := []ResultRef{}
+	seen := map[ResultRef]bool{}
+	for _, v := range values {
+		for _, match := range resultRefRegex.FindAllStringSubmatch(v, -1) {
+			ref := ResultRef{PipelineTask: match[1], Result: match[2]}
+			if !seen[ref] {
+				seen[ref] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+	return refs
+}
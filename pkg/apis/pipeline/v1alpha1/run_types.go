@@ -0,0 +1,144 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/knative/pkg/apis"
+	duckv1beta1 "github.com/knative/pkg/apis/duck/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Check that Run may be validated and defaulted.
+var _ apis.Validatable = (*Run)(nil)
+var _ apis.Defaultable = (*Run)(nil)
+
+// RunSpec defines the desired state of Run
+type RunSpec struct {
+	// Ref references the custom task to run, by the apiVersion and the kind
+	// implemented by its controller, and its name.
+	Ref *TaskRef `json:"ref"`
+	// +optional
+	Params []Param `json:"params,omitempty"`
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// Used for cancelling a run, which the controller of the custom task
+	// should stop
+	// +optional
+	Status RunSpecStatus `json:"status,omitempty"`
+}
+
+// RunSpecStatus defines the run spec status the user can provide
+type RunSpecStatus string
+
+const (
+	// RunSpecStatusCancelled indicates that the user wants to cancel the run,
+	// if not already cancelled or terminated
+	RunSpecStatusCancelled RunSpecStatus = "RunCancelled"
+)
+
+// RunResult is the value reported by the controller of a custom task for one
+// of its results.
+type RunResult struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+var runCondSet = apis.NewBatchConditionSet()
+
+// RunStatus defines the observed state of Run, which is reported by the
+// controller of the custom task.
+type RunStatus struct {
+	duckv1beta1.Status `json:",inline"`
+
+	// StartTime is the time the run is actually started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the run completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Results are the values reported by the custom task.
+	// +optional
+	Results []RunResult `json:"results,omitempty"`
+}
+
+// GetCondition returns the Condition matching the given type.
+func (r *RunStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return runCondSet.Manage(r).GetCondition(t)
+}
+
+// InitializeConditions will set all conditions in runCondSet to unknown
+func (r *RunStatus) InitializeConditions() {
+	runCondSet.Manage(r).InitializeConditions()
+}
+
+// SetCondition sets the condition, unsetting previous conditions with the same
+// type as necessary.
+func (r *RunStatus) SetCondition(newCond *apis.Condition) {
+	if newCond != nil {
+		runCondSet.Manage(r).SetCondition(*newCond)
+	}
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Run is the Schema for the runs API. A Run runs a custom task, which isn't
+// executed in a pod by Tekton but by the controller implementing its kind.
+// +k8s:openapi-gen=true
+type Run struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec RunSpec `json:"spec,omitempty"`
+	// +optional
+	Status RunStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RunList contains a list of Run
+type RunList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Run `json:"items"`
+}
+
+// SetDefaults for run
+func (r *Run) SetDefaults(ctx context.Context) {}
+
+// IsDone returns true if the Run's status indicates that it is done.
+func (r *Run) IsDone() bool {
+	return !r.Status.GetCondition(apis.ConditionSucceeded).IsUnknown()
+}
+
+// IsCancelled returns true if the Run's spec status is set to Cancelled state
+func (r *Run) IsCancelled() bool {
+	return r.Spec.Status == RunSpecStatusCancelled
+}
+
+// IsCustomTask returns true if ref references a custom task, of a kind implemented by
+// another controller, which is run by a Run instead of a TaskRun.
+func (ref TaskRef) IsCustomTask() bool {
+	return ref.APIVersion != "" && ref.APIVersion != SchemeGroupVersion.String() && ref.Kind != ""
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/knative/pkg/apis"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRun_Invalidate(t *testing.T) {
+	tests := []struct {
+		name string
+		run  Run
+		want *apis.FieldError
+	}{
+		{
+			name: "missing spec",
+			run: Run{
+				ObjectMeta: metav1.ObjectMeta{Name: "run"},
+			},
+			want: apis.ErrMissingField("spec"),
+		},
+		{
+			name: "invalid run metadata",
+			run: Run{
+				ObjectMeta: metav1.ObjectMeta{Name: "run.name"},
+			},
+			want: &apis.FieldError{
+				Message: "Invalid resource name: special character . must not be present",
+				Paths:   []string{"metadata.name"},
+			},
+		},
+		{
+			name: "missing ref",
+			run: Run{
+				ObjectMeta: metav1.ObjectMeta{Name: "run"},
+				Spec:       RunSpec{ServiceAccount: "sa"},
+			},
+			want: apis.ErrMissingField("spec.ref"),
+		},
+		{
+			name: "missing apiVersion",
+			run: Run{
+				ObjectMeta: metav1.ObjectMeta{Name: "run"},
+				Spec:       RunSpec{Ref: &TaskRef{Kind: "Wait"}},
+			},
+			want: apis.ErrMissingField("spec.ref.apiVersion"),
+		},
+		{
+			name: "missing kind",
+			run: Run{
+				ObjectMeta: metav1.ObjectMeta{Name: "run"},
+				Spec:       RunSpec{Ref: &TaskRef{APIVersion: "example.dev/v1"}},
+			},
+			want: apis.ErrMissingField("spec.ref.kind"),
+		},
+		{
+			name: "tekton task",
+			run: Run{
+				ObjectMeta: metav1.ObjectMeta{Name: "run"},
+				Spec:       RunSpec{Ref: &TaskRef{APIVersion: "tekton.dev/v1alpha1", Kind: NamespacedTaskKind}},
+			},
+			want: apis.ErrInvalidValue("tekton.dev/v1alpha1", "spec.ref.apiVersion"),
+		},
+		{
+			name: "duplicate params",
+			run: Run{
+				ObjectMeta: metav1.ObjectMeta{Name: "run"},
+				Spec: RunSpec{
+					Ref: &TaskRef{APIVersion: "example.dev/v1", Kind: "Wait"},
+					Params: []Param{{
+						Name: "duration", Value: *NewArrayOrString("1h"),
+					}, {
+						Name: "duration", Value: *NewArrayOrString("2h"),
+					}},
+				},
+			},
+			want: apis.ErrMultipleOneOf("spec.inputs.params"),
+		},
+		{
+			name: "invalid status",
+			run: Run{
+				ObjectMeta: metav1.ObjectMeta{Name: "run"},
+				Spec: RunSpec{
+					Ref:    &TaskRef{APIVersion: "example.dev/v1", Kind: "Wait"},
+					Status: "RunPaused",
+				},
+			},
+			want: apis.ErrInvalidValue("RunPaused", "spec.status"),
+		},
+	}
+
+	for _, ts := range tests {
+		t.Run(ts.name, func(t *testing.T) {
+			err := ts.run.Validate(context.Background())
+			if d := cmp.Diff(ts.want.Error(), err.Error()); d != "" {
+				t.Errorf("Run.Validate/%s (-want, +got) = %v", ts.name, d)
+			}
+		})
+	}
+}
+
+func TestRun_Validate(t *testing.T) {
+	r := Run{
+		ObjectMeta: metav1.ObjectMeta{Name: "run"},
+		Spec: RunSpec{
+			Ref: &TaskRef{APIVersion: "example.dev/v1", Kind: "Wait", Name: "deploy-window"},
+			Params: []Param{{
+				Name: "duration", Value: *NewArrayOrString("1h"),
+			}},
+			Status: RunSpecStatusCancelled,
+		},
+	}
+	if err := r.Validate(context.Background()); err != nil {
+		t.Errorf("Run.Validate() error = %v", err)
+	}
+}
@@ -44,13 +44,66 @@ type TaskSpec struct {
 
 	// Steps are the steps of the build; each step is run sequentially with the
 	// source mounted into /workspace.
-	Steps []corev1.Container `json:"steps,omitempty"`
+	Steps []Step `json:"steps,omitempty"`
 
 	// Volumes is a collection of volumes that are available to mount into the
 	// steps of the build.
 	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// Sidecars are containers which run alongside the steps of the build, for
+	// example to provide a database or a docker daemon to the steps. They are
+	// stopped once all of the steps have finished.
+	// +optional
+	Sidecars []corev1.Container `json:"sidecars,omitempty"`
+
+	// Results are the values the steps of the Task can report back, by writing
+	// them to files named after the results.
+	// +optional
+	Results []TaskResult `json:"results,omitempty"`
+
+	// Workspaces are the volumes the Task expects to be bound by its TaskRun
+	// and mounted into each of its steps.
+	// +optional
+	Workspaces []WorkspaceDeclaration `json:"workspaces,omitempty"`
 }
 
+// Step embeds the Container type, which allows it to include fields not
+// provided by Container.
+type Step struct {
+	corev1.Container `json:",inline"`
+
+	// Script is the contents of an executable file to execute. The file is
+	// run with the interpreter of its shebang line, or with /bin/sh if it
+	// has none.
+	//
+	// If Script is not empty, the Step cannot have a Command.
+	// +optional
+	Script string `json:"script,omitempty"`
+
+	// Timeout is the time after which the Step is killed. The steps after it
+	// are then skipped and the TaskRun fails.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// OnError is what happens when the Step fails: by default the steps
+	// after it are skipped and the TaskRun fails, but with "continue" its
+	// exit code is only reported and the next steps are run.
+	// +optional
+	OnError OnErrorType `json:"onError,omitempty"`
+}
+
+// OnErrorType is what happens when a Step fails.
+type OnErrorType string
+
+const (
+	// StepOnErrorStopAndFail skips the steps after the failing Step and fails
+	// the TaskRun.
+	StepOnErrorStopAndFail OnErrorType = "stopAndFail"
+	// StepOnErrorContinue reports the exit code of the failing Step and runs
+	// the steps after it.
+	StepOnErrorContinue OnErrorType = "continue"
+)
+
 // Check that Task may be validated and defaulted.
 var _ apis.Validatable = (*Task)(nil)
 var _ apis.Defaultable = (*Task)(nil)
@@ -83,16 +136,33 @@ type Inputs struct {
 // such as resources.
 type TaskParam struct {
 	Name string `json:"name"`
+	// Type is the type of the parameter, "string" or "array". It defaults to
+	// the type of Default, or to "string" if there is no default.
+	// +optional
+	Type ParamType `json:"type,omitempty"`
 	// +optional
 	Description string `json:"description,omitempty"`
 	// +optional
-	Default string `json:"default,omitempty"`
+	Default *ArrayOrString `json:"default,omitempty"`
+}
+
+// GetType returns the type of the parameter, taking its default into account.
+func (tp TaskParam) GetType() ParamType {
+	return getParamType(tp.Type, tp.Default)
 }
 
 // Param declares a value to use for the Param called Name.
 type Param struct {
-	Name  string `json:"name"`
-	Value string `json:"value"`
+	Name  string        `json:"name"`
+	Value ArrayOrString `json:"value"`
+}
+
+// TaskResult declares a value the Task can report back, which can be consumed
+// by the Tasks running after it in a Pipeline.
+type TaskResult struct {
+	Name string `json:"name"`
+	// +optional
+	Description string `json:"description,omitempty"`
 }
 
 // Outputs allow a task to declare what data the Build/Task will be producing,
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/knative/pkg/apis"
@@ -28,6 +29,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 )
 
+// resultNameRegex matches the names which can be used both as a file name and in the
+// variables referencing a result.
+var resultNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
 func (t *Task) Validate(ctx context.Context) *apis.FieldError {
 	if err := validateObjectMetadata(t.GetObjectMeta()); err != nil {
 		return err.ViaField("metadata")
@@ -49,6 +54,9 @@ func (ts *TaskSpec) Validate(ctx context.Context) *apis.FieldError {
 	if err := validateSteps(ts.Steps).ViaField("steps"); err != nil {
 		return err
 	}
+	if err := validateContainers(ts.Sidecars).ViaField("sidecars"); err != nil {
+		return err
+	}
 
 	// A task doesn't have to have inputs or outputs, but if it does they must be valid.
 	// A task can't duplicate input or output names.
@@ -84,11 +92,33 @@ func (ts *TaskSpec) Validate(ctx context.Context) *apis.FieldError {
 			}
 		}
 	}
+	for _, sidecar := range ts.Sidecars {
+		if errs := validation.IsDNS1123Label(sidecar.Name); len(errs) > 0 {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("invalid value %q", sidecar.Name),
+				Paths:   []string{"taskspec.sidecars.name"},
+				Details: "Task sidecar name must be a valid DNS Label, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+			}
+		}
+	}
 
-	if err := validateInputParameterVariables(ts.Steps, ts.Inputs); err != nil {
+	if err := validateResults(ts.Results).ViaField("taskspec.results"); err != nil {
 		return err
 	}
-	if err := validateResourceVariables(ts.Steps, ts.Inputs, ts.Outputs); err != nil {
+
+	if err := validateDeclaredWorkspaces(ts.Workspaces).ViaField("taskspec.workspaces"); err != nil {
+		return err
+	}
+
+	// The sidecars can use the same variables as the steps.
+	steps := append([]Step{}, ts.Steps...)
+	for _, sidecar := range ts.Sidecars {
+		steps = append(steps, Step{Container: sidecar})
+	}
+	if err := validateInputParameterVariables(steps, ts.Inputs); err != nil {
+		return err
+	}
+	if err := validateResourceVariables(steps, ts.Inputs, ts.Outputs); err != nil {
 		return err
 	}
 	return nil
@@ -106,10 +136,30 @@ func ValidateVolumes(volumes []corev1.Volume) *apis.FieldError {
 	return nil
 }
 
-func validateSteps(steps []corev1.Container) *apis.FieldError {
+func validateSteps(steps []Step) *apis.FieldError {
+	containers := make([]corev1.Container, 0, len(steps))
+	for _, s := range steps {
+		// The script is run instead of the command.
+		if s.Script != "" && len(s.Command) > 0 {
+			return apis.ErrMultipleOneOf("script", "command")
+		}
+		if s.Timeout != nil && s.Timeout.Duration <= 0 {
+			return apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", s.Timeout.Duration), "timeout")
+		}
+		switch s.OnError {
+		case "", StepOnErrorStopAndFail, StepOnErrorContinue:
+		default:
+			return apis.ErrInvalidValue(string(s.OnError), "onError")
+		}
+		containers = append(containers, s.Container)
+	}
+	return validateContainers(containers)
+}
+
+func validateContainers(containers []corev1.Container) *apis.FieldError {
 	// Task must not have duplicate step names.
 	names := map[string]struct{}{}
-	for _, s := range steps {
+	for _, s := range containers {
 		if s.Image == "" {
 			return apis.ErrMissingField("Image")
 		}
@@ -125,17 +175,42 @@ func validateSteps(steps []corev1.Container) *apis.FieldError {
 	return nil
 }
 
-func validateInputParameterVariables(steps []corev1.Container, inputs *Inputs) *apis.FieldError {
+func validateResults(results []TaskResult) *apis.FieldError {
+	// Results are written to files named after them and can't be duplicated.
+	names := map[string]struct{}{}
+	for _, r := range results {
+		if !resultNameRegex.MatchString(r.Name) {
+			return apis.ErrInvalidValue(r.Name, "name")
+		}
+		if _, ok := names[r.Name]; ok {
+			return apis.ErrMultipleOneOf("name")
+		}
+		names[r.Name] = struct{}{}
+	}
+	return nil
+}
+
+func validateInputParameterVariables(steps []Step, inputs *Inputs) *apis.FieldError {
 	parameterNames := map[string]struct{}{}
+	arrayParameterNames := map[string]struct{}{}
 	if inputs != nil {
 		for _, p := range inputs.Params {
+			if err := validateParamType(p.Name, p.Type, p.Default, "taskspec.inputs.params"); err != nil {
+				return err
+			}
 			parameterNames[p.Name] = struct{}{}
+			if p.GetType() == ParamTypeArray {
+				arrayParameterNames[p.Name] = struct{}{}
+			}
 		}
 	}
-	return validateVariables(steps, "params", parameterNames)
+	if err := validateVariables(steps, "params", parameterNames); err != nil {
+		return err
+	}
+	return validateArraysUsage(steps, "params", arrayParameterNames)
 }
 
-func validateResourceVariables(steps []corev1.Container, inputs *Inputs, outputs *Outputs) *apis.FieldError {
+func validateResourceVariables(steps []Step, inputs *Inputs, outputs *Outputs) *apis.FieldError {
 	resourceNames := map[string]struct{}{}
 	if inputs != nil {
 		for _, r := range inputs.Resources {
@@ -150,7 +225,7 @@ func validateResourceVariables(steps []corev1.Container, inputs *Inputs, outputs
 	return validateVariables(steps, "resources", resourceNames)
 }
 
-func validateVariables(steps []corev1.Container, prefix string, vars map[string]struct{}) *apis.FieldError {
+func validateVariables(steps []Step, prefix string, vars map[string]struct{}) *apis.FieldError {
 	for _, step := range steps {
 		if err := validateTaskVariable("name", step.Name, prefix, vars); err != nil {
 			return err
@@ -161,6 +236,9 @@ func validateVariables(steps []corev1.Container, prefix string, vars map[string]
 		if err := validateTaskVariable("workingDir", step.WorkingDir, prefix, vars); err != nil {
 			return err
 		}
+		if err := validateTaskVariable("script", step.Script, prefix, vars); err != nil {
+			return err
+		}
 		for i, cmd := range step.Command {
 			if err := validateTaskVariable(fmt.Sprintf("command[%d]", i), cmd, prefix, vars); err != nil {
 				return err
@@ -191,10 +269,64 @@ func validateVariables(steps []corev1.Container, prefix string, vars map[string]
 	return nil
 }
 
+// validateArraysUsage validates that the array parameters in vars are only used as whole
+// elements of the command or of the args of the steps, in which they are expanded.
+func validateArraysUsage(steps []Step, prefix string, vars map[string]struct{}) *apis.FieldError {
+	for _, step := range steps {
+		if err := validateTaskNoArrayReferenced("name", step.Name, prefix, vars); err != nil {
+			return err
+		}
+		if err := validateTaskNoArrayReferenced("image", step.Image, prefix, vars); err != nil {
+			return err
+		}
+		if err := validateTaskNoArrayReferenced("workingDir", step.WorkingDir, prefix, vars); err != nil {
+			return err
+		}
+		if err := validateTaskNoArrayReferenced("script", step.Script, prefix, vars); err != nil {
+			return err
+		}
+		for i, cmd := range step.Command {
+			if err := validateTaskArraysIsolated(fmt.Sprintf("command[%d]", i), cmd, prefix, vars); err != nil {
+				return err
+			}
+		}
+		for i, arg := range step.Args {
+			if err := validateTaskArraysIsolated(fmt.Sprintf("arg[%d]", i), arg, prefix, vars); err != nil {
+				return err
+			}
+		}
+		for _, env := range step.Env {
+			if err := validateTaskNoArrayReferenced(fmt.Sprintf("env[%s]", env.Name), env.Value, prefix, vars); err != nil {
+				return err
+			}
+		}
+		for i, v := range step.VolumeMounts {
+			if err := validateTaskNoArrayReferenced(fmt.Sprintf("volumeMount[%d].Name", i), v.Name, prefix, vars); err != nil {
+				return err
+			}
+			if err := validateTaskNoArrayReferenced(fmt.Sprintf("volumeMount[%d].MountPath", i), v.MountPath, prefix, vars); err != nil {
+				return err
+			}
+			if err := validateTaskNoArrayReferenced(fmt.Sprintf("volumeMount[%d].SubPath", i), v.SubPath, prefix, vars); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func validateTaskVariable(name, value, prefix string, vars map[string]struct{}) *apis.FieldError {
 	return templating.ValidateVariable(name, value, prefix, "(?:inputs|outputs).", "step", "taskspec.steps", vars)
 }
 
+func validateTaskNoArrayReferenced(name, value, prefix string, vars map[string]struct{}) *apis.FieldError {
+	return templating.ValidateVariableProhibited(name, value, prefix, "(?:inputs|outputs).", "step", "taskspec.steps", vars)
+}
+
+func validateTaskArraysIsolated(name, value, prefix string, vars map[string]struct{}) *apis.FieldError {
+	return templating.ValidateVariableIsolated(name, value, prefix, "(?:inputs|outputs).", "step", "taskspec.steps", vars)
+}
+
 func checkForDuplicates(resources []TaskResource, path string) *apis.FieldError {
 	encountered := map[string]struct{}{}
 	for _, r := range resources {
@@ -19,11 +19,13 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/knative/pkg/apis"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var validResource = TaskResource{
@@ -31,26 +33,38 @@ var validResource = TaskResource{
 	Type: "git",
 }
 
-var validBuildSteps = []corev1.Container{{
+var validBuildSteps = []Step{{Container: corev1.Container{
 	Name:  "mystep",
 	Image: "myimage",
-}}
+}}}
 
-var invalidBuildSteps = []corev1.Container{{
+var invalidBuildSteps = []Step{{Container: corev1.Container{
 	Name:  "replaceImage",
 	Image: "myimage",
-}}
+}}}
 
 func TestTaskSpecValidate(t *testing.T) {
 	type fields struct {
 		Inputs     *Inputs
 		Outputs    *Outputs
-		BuildSteps []corev1.Container
+		BuildSteps []Step
+		Sidecars   []corev1.Container
+		Results    []TaskResult
+		Workspaces []WorkspaceDeclaration
 	}
 	tests := []struct {
 		name   string
 		fields fields
 	}{{
+		name: "valid context variables",
+		fields: fields{
+			BuildSteps: []Step{{Container: corev1.Container{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"--tag=${context.pipelineRun.name}-${context.taskRun.name}"},
+			}}},
+		},
+	}, {
 		name: "valid inputs",
 		fields: fields{
 			Inputs: &Inputs{
@@ -59,7 +73,7 @@ func TestTaskSpecValidate(t *testing.T) {
 					{
 						Name:        "task",
 						Description: "param",
-						Default:     "default",
+						Default:     NewArrayOrString("default"),
 					},
 				},
 			},
@@ -101,20 +115,112 @@ func TestTaskSpecValidate(t *testing.T) {
 			Outputs: &Outputs{
 				Resources: []TaskResource{validResource},
 			},
-			BuildSteps: []corev1.Container{{
+			BuildSteps: []Step{{Container: corev1.Container{
 				Name:       "mystep",
 				Image:      "${inputs.resources.foo.url}",
 				Args:       []string{"--flag=${inputs.params.baz} && ${input.params.foo-is-baz}"},
 				WorkingDir: "/foo/bar/${outputs.resources.source}",
+			}}},
+		},
+	}, {
+		name: "valid array template variable",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{
+					Name: "baz",
+					Type: ParamTypeArray,
+				}, {
+					Name:    "foo-is-baz",
+					Default: NewArrayOrString("a", "b"),
+				}, {
+					Name:    "bar",
+					Type:    ParamTypeString,
+					Default: NewArrayOrString("c"),
+				}},
+			},
+			BuildSteps: []Step{{Container: corev1.Container{
+				Name:    "mystep",
+				Image:   "myimage:${inputs.params.bar}",
+				Command: []string{"${inputs.params.foo-is-baz}"},
+				Args:    []string{"--flag=${inputs.params.bar}", "${inputs.params.baz}"},
+			}}},
+		},
+	}, {
+		name: "valid results",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Results: []TaskResult{{
+				Name:        "commit-sha",
+				Description: "the sha of the commit which was built",
+			}, {
+				Name: "image_digest",
+			}},
+		},
+	}, {
+		name: "valid workspaces",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Workspaces: []WorkspaceDeclaration{{
+				Name:        "source",
+				Description: "the source to build",
+			}, {
+				Name:      "cache",
+				MountPath: "/cache",
+				ReadOnly:  true,
+			}},
+		},
+	}, {
+		name: "valid sidecars",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{Name: "version"}},
+			},
+			BuildSteps: validBuildSteps,
+			Sidecars: []corev1.Container{{
+				Name:  "database",
+				Image: "postgres:${inputs.params.version}",
+			}},
+		},
+	}, {
+		name: "valid script",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{Name: "message"}},
+			},
+			BuildSteps: []Step{{
+				Container: corev1.Container{Name: "mystep", Image: "ubuntu"},
+				Script:    "#!/usr/bin/env bash\necho ${inputs.params.message}",
+			}},
+		},
+	}, {
+		name: "step with timeout",
+		fields: fields{
+			BuildSteps: []Step{{
+				Container: corev1.Container{Name: "mystep", Image: "ubuntu"},
+				Timeout:   &metav1.Duration{Duration: 5 * time.Minute},
+			}},
+		},
+	}, {
+		name: "step continuing after an error",
+		fields: fields{
+			BuildSteps: []Step{{
+				Container: corev1.Container{Name: "lint", Image: "golangci-lint"},
+				OnError:   StepOnErrorContinue,
+			}, {
+				Container: corev1.Container{Name: "test", Image: "golang"},
+				OnError:   StepOnErrorStopAndFail,
 			}},
 		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &TaskSpec{
-				Inputs:  tt.fields.Inputs,
-				Outputs: tt.fields.Outputs,
-				Steps:   tt.fields.BuildSteps,
+				Inputs:     tt.fields.Inputs,
+				Outputs:    tt.fields.Outputs,
+				Steps:      tt.fields.BuildSteps,
+				Sidecars:   tt.fields.Sidecars,
+				Results:    tt.fields.Results,
+				Workspaces: tt.fields.Workspaces,
 			}
 			if err := ts.Validate(context.Background()); err != nil {
 				t.Errorf("TaskSpec.Validate() = %v", err)
@@ -127,7 +233,10 @@ func TestTaskSpecValidateError(t *testing.T) {
 	type fields struct {
 		Inputs     *Inputs
 		Outputs    *Outputs
-		BuildSteps []corev1.Container
+		BuildSteps []Step
+		Sidecars   []corev1.Container
+		Results    []TaskResult
+		Workspaces []WorkspaceDeclaration
 	}
 	tests := []struct {
 		name          string
@@ -242,7 +351,7 @@ func TestTaskSpecValidateError(t *testing.T) {
 			Inputs: &Inputs{
 				Resources: []TaskResource{validResource},
 			},
-			BuildSteps: []corev1.Container{},
+			BuildSteps: []Step{},
 		},
 		expectedError: apis.FieldError{
 			Message: "missing field(s)",
@@ -264,11 +373,11 @@ func TestTaskSpecValidateError(t *testing.T) {
 	}, {
 		name: "inexistent input param variable",
 		fields: fields{
-			BuildSteps: []corev1.Container{{
+			BuildSteps: []Step{{Container: corev1.Container{
 				Name:  "mystep",
 				Image: "myimage",
 				Args:  []string{"--flag=${inputs.params.inexistent}"},
-			}},
+			}}},
 		},
 		expectedError: apis.FieldError{
 			Message: `non-existent variable in "--flag=${inputs.params.inexistent}" for step arg[0]`,
@@ -277,10 +386,10 @@ func TestTaskSpecValidateError(t *testing.T) {
 	}, {
 		name: "inexistent input resource variable",
 		fields: fields{
-			BuildSteps: []corev1.Container{{
+			BuildSteps: []Step{{Container: corev1.Container{
 				Name:  "mystep",
 				Image: "myimage:${inputs.resources.inputs}",
-			}},
+			}}},
 		},
 		expectedError: apis.FieldError{
 			Message: `non-existent variable in "myimage:${inputs.resources.inputs}" for step image`,
@@ -289,16 +398,29 @@ func TestTaskSpecValidateError(t *testing.T) {
 	}, {
 		name: "inexistent output param variable",
 		fields: fields{
-			BuildSteps: []corev1.Container{{
+			BuildSteps: []Step{{Container: corev1.Container{
 				Name:       "mystep",
 				Image:      "myimage",
 				WorkingDir: "/foo/bar/${outputs.resources.inexistent}",
-			}},
+			}}},
 		},
 		expectedError: apis.FieldError{
 			Message: `non-existent variable in "/foo/bar/${outputs.resources.inexistent}" for step workingDir`,
 			Paths:   []string{"taskspec.steps.workingDir"},
 		},
+	}, {
+		name: "inexistent context variable",
+		fields: fields{
+			BuildSteps: []Step{{Container: corev1.Container{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"--tag=${context.taskRun.labels}"},
+			}}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent context variable in "--tag=${context.taskRun.labels}" for step arg[0]`,
+			Paths:   []string{"taskspec.steps.arg[0]"},
+		},
 	}, {
 		name: "Inexistent param variable with existing",
 		fields: fields{
@@ -307,27 +429,227 @@ func TestTaskSpecValidateError(t *testing.T) {
 					{
 						Name:        "foo",
 						Description: "param",
-						Default:     "default",
+						Default:     NewArrayOrString("default"),
 					},
 				},
 			},
-			BuildSteps: []corev1.Container{{
+			BuildSteps: []Step{{Container: corev1.Container{
 				Name:  "mystep",
 				Image: "myimage",
 				Args:  []string{"${inputs.params.foo} && ${inputs.params.inexistent}"},
-			}},
+			}}},
 		},
 		expectedError: apis.FieldError{
 			Message: `non-existent variable in "${inputs.params.foo} && ${inputs.params.inexistent}" for step arg[0]`,
 			Paths:   []string{"taskspec.steps.arg[0]"},
 		},
+	}, {
+		name: "invalid result name",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Results:    []TaskResult{{Name: "commit.sha"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: commit.sha`,
+			Paths:   []string{"taskspec.results.name"},
+		},
+	}, {
+		name: "duplicated results",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Results:    []TaskResult{{Name: "sha"}, {Name: "sha"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"taskspec.results.name"},
+		},
+	}, {
+		name: "invalid workspace name",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Workspaces: []WorkspaceDeclaration{{Name: "my_source"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: my_source`,
+			Paths:   []string{"taskspec.workspaces.name"},
+		},
+	}, {
+		name: "duplicated workspaces",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Workspaces: []WorkspaceDeclaration{{Name: "source"}, {Name: "source"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"taskspec.workspaces.name"},
+		},
+	}, {
+		name: "duplicated workspace mount paths",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Workspaces: []WorkspaceDeclaration{{Name: "source"}, {Name: "other", MountPath: "/workspace/source/"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"taskspec.workspaces.mountPath"},
+		},
+	}, {
+		name: "relative workspace mount path",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Workspaces: []WorkspaceDeclaration{{Name: "source", MountPath: "source"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: source`,
+			Paths:   []string{"taskspec.workspaces.mountPath"},
+		},
+	}, {
+		name: "sidecar without image",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Sidecars:   []corev1.Container{{Name: "database"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `missing field(s)`,
+			Paths:   []string{"sidecars.Image"},
+		},
+	}, {
+		name: "invalid sidecar name",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Sidecars:   []corev1.Container{{Name: "Database", Image: "postgres"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value "Database"`,
+			Paths:   []string{"taskspec.sidecars.name"},
+			Details: "Task sidecar name must be a valid DNS Label, For more info refer to https://kubernetes.io/docs/concepts/overview/working-with-objects/names/#names",
+		},
+	}, {
+		name: "inexistent input param variable in sidecar",
+		fields: fields{
+			BuildSteps: validBuildSteps,
+			Sidecars: []corev1.Container{{
+				Name:  "database",
+				Image: "postgres:${inputs.params.version}",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "postgres:${inputs.params.version}" for step image`,
+			Paths:   []string{"taskspec.steps.image"},
+		},
+	}, {
+		name: "invalid param type",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{Name: "validparam", Type: "invalidtype"}},
+			},
+			BuildSteps: validBuildSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: invalidtype",
+			Paths:   []string{"taskspec.inputs.params.validparam.type"},
+		},
+	}, {
+		name: "param mismatching default type",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{Name: "validparam", Type: ParamTypeArray, Default: NewArrayOrString("string")}},
+			},
+			BuildSteps: validBuildSteps,
+		},
+		expectedError: apis.FieldError{
+			Message: `"array" type does not match default value's type: "string"`,
+			Paths:   []string{"taskspec.inputs.params.validparam.type", "taskspec.inputs.params.validparam.default.type"},
+		},
+	}, {
+		name: "array param used in a string field",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{Name: "baz", Type: ParamTypeArray}},
+			},
+			BuildSteps: []Step{{Container: corev1.Container{
+				Name:  "mystep",
+				Image: "myimage:${inputs.params.baz}",
+			}}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable type invalid in "myimage:${inputs.params.baz}" for step image`,
+			Paths:   []string{"taskspec.steps.image"},
+		},
+	}, {
+		name: "array param not isolated in args",
+		fields: fields{
+			Inputs: &Inputs{
+				Params: []TaskParam{{Name: "baz", Type: ParamTypeArray}},
+			},
+			BuildSteps: []Step{{Container: corev1.Container{
+				Name:  "mystep",
+				Image: "myimage",
+				Args:  []string{"--flag=${inputs.params.baz}"},
+			}}},
+		},
+		expectedError: apis.FieldError{
+			Message: `variable is not properly isolated in "--flag=${inputs.params.baz}" for step arg[0]`,
+			Paths:   []string{"taskspec.steps.arg[0]"},
+		},
+	}, {
+		name: "step with script and command",
+		fields: fields{
+			BuildSteps: []Step{{
+				Container: corev1.Container{Name: "mystep", Image: "ubuntu", Command: []string{"echo"}},
+				Script:    "echo hello",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "expected exactly one, got both",
+			Paths:   []string{"steps.script", "steps.command"},
+		},
+	}, {
+		name: "step with negative timeout",
+		fields: fields{
+			BuildSteps: []Step{{
+				Container: corev1.Container{Name: "mystep", Image: "ubuntu"},
+				Timeout:   &metav1.Duration{Duration: -1 * time.Minute},
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: -1m0s should be > 0",
+			Paths:   []string{"steps.timeout"},
+		},
+	}, {
+		name: "step with invalid onError",
+		fields: fields{
+			BuildSteps: []Step{{
+				Container: corev1.Container{Name: "mystep", Image: "ubuntu"},
+				OnError:   "ignore",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: "invalid value: ignore",
+			Paths:   []string{"steps.onError"},
+		},
+	}, {
+		name: "inexistent input param variable in script",
+		fields: fields{
+			BuildSteps: []Step{{
+				Container: corev1.Container{Name: "mystep", Image: "ubuntu"},
+				Script:    "echo ${inputs.params.message}",
+			}},
+		},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "echo ${inputs.params.message}" for step script`,
+			Paths:   []string{"taskspec.steps.script"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ts := &TaskSpec{
-				Inputs:  tt.fields.Inputs,
-				Outputs: tt.fields.Outputs,
-				Steps:   tt.fields.BuildSteps,
+				Inputs:     tt.fields.Inputs,
+				Outputs:    tt.fields.Outputs,
+				Steps:      tt.fields.BuildSteps,
+				Sidecars:   tt.fields.Sidecars,
+				Results:    tt.fields.Results,
+				Workspaces: tt.fields.Workspaces,
 			}
 			err := ts.Validate(context.Background())
 			if err == nil {
@@ -22,6 +22,7 @@ import (
 
 	"github.com/knative/pkg/apis"
 	duckv1beta1 "github.com/knative/pkg/apis/duck/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -54,6 +55,10 @@ type TaskRunSpec struct {
 	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// Retries is the number of times a new pod is created for the TaskRun
+	// after its pod fails, before the TaskRun is marked as failed.
+	// +optional
+	Retries int `json:"retries,omitempty"`
 	// NodeSelector is a selector which must be true for the pod to fit on a node.
 	// Selector which must match a node's labels for the pod to be scheduled on that node.
 	// More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/
@@ -65,6 +70,12 @@ type TaskRunSpec struct {
 	// If specified, the pod's scheduling constraints
 	// +optional
 	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// PodTemplate configures the pod the TaskRun is run in.
+	// +optional
+	PodTemplate *PodTemplate `json:"podTemplate,omitempty"`
+	// Workspaces binds the workspaces declared by the Task to volumes.
+	// +optional
+	Workspaces []WorkspaceBinding `json:"workspaces,omitempty"`
 }
 
 // TaskRunSpecStatus defines the taskrun spec status the user can provide
@@ -114,6 +125,27 @@ type TaskTrigger struct {
 	Name string `json:"name,omitempty"`
 }
 
+// TaskRunResult is the value reported by a step for one of the results
+// declared by the Task.
+type TaskRunResult struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+const (
+	// StepReasonResultName is the name of the entry of the termination message
+	// of a step which reports why the step terminated. It can't be the name of
+	// a result declared by the Task.
+	StepReasonResultName = "$reason"
+	// StepExitCodeResultName is the name of the entry of the termination
+	// message of a step which reports the exit code of its command, when the
+	// step continues after failing.
+	StepExitCodeResultName = "$exitCode"
+	// StepReasonTimedOut is the reason of the terminated state of a step which
+	// was killed because it ran for longer than its timeout.
+	StepReasonTimedOut = "StepTimedOut"
+)
+
 var taskRunCondSet = apis.NewBatchConditionSet()
 
 // TaskRunStatus defines the observed state of TaskRun
@@ -138,6 +170,14 @@ type TaskRunStatus struct {
 	// Steps describes the state of each build step container.
 	// +optional
 	Steps []StepState `json:"steps,omitempty"`
+	// RetriesStatus contains the history of the TaskRunStatus of each failed
+	// attempt, in case the TaskRun was retried.
+	// +optional
+	RetriesStatus []TaskRunStatus `json:"retriesStatus,omitempty"`
+	// TaskResults are the values of the results declared by the Task which
+	// were reported by its steps.
+	// +optional
+	TaskResults []TaskRunResult `json:"taskResults,omitempty"`
 }
 
 // GetCondition returns the Condition matching the given type.
@@ -200,11 +240,22 @@ func (tr *TaskRun) GetBuildPodRef() corev1.ObjectReference {
 	}
 }
 
+// GetOwnerReference gets the task run as owner reference for any related objects
+func (tr *TaskRun) GetOwnerReference() []metav1.OwnerReference {
+	return []metav1.OwnerReference{
+		*metav1.NewControllerRef(tr, taskRunGroupVersionKind),
+	}
+}
+
 // GetPipelineRunPVCName for taskrun gets pipelinerun
 func (tr *TaskRun) GetPipelineRunPVCName() string {
 	if tr == nil {
 		return ""
 	}
+	// The TaskRuns of a rerun use the artifact storage of the PipelineRun it reruns
+	if name := tr.Labels[pipeline.GroupName+pipeline.ArtifactStorageLabelKey]; name != "" {
+		return fmt.Sprintf("%s-pvc", name)
+	}
 	for _, ref := range tr.GetOwnerReferences() {
 		if ref.Kind == pipelineRunControllerName {
 			return fmt.Sprintf("%s-pvc", ref.Name)
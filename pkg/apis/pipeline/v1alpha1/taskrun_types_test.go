@@ -73,6 +73,20 @@ func TestTaskRun_GetPipelineRunPVCName(t *testing.T) {
 			},
 		},
 		expectedPVCName: "testpr-pvc",
+	}, {
+		name: "rerun of a pipelinerun",
+		tr: &TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "taskrunname",
+				Namespace: "testns",
+				Labels:    map[string]string{"tekton.dev/artifactStorage": "previouspr"},
+				OwnerReferences: []metav1.OwnerReference{{
+					Kind: "PipelineRun",
+					Name: "testpr",
+				}},
+			},
+		},
+		expectedPVCName: "previouspr-pvc",
 	}, {
 		name:            "nil taskrun",
 		expectedPVCName: "",
@@ -71,6 +71,14 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 
+	if err := validateWorkspaceBindings(ts.Workspaces).ViaField("spec.workspaces"); err != nil {
+		return err
+	}
+
+	if ts.Retries < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", ts.Retries), "spec.retries")
+	}
+
 	return nil
 }
 
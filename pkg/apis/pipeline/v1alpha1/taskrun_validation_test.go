@@ -137,10 +137,10 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 					Name: "taskrefname",
 				},
 				TaskSpec: &TaskSpec{
-					Steps: []corev1.Container{{
+					Steps: []Step{{Container: corev1.Container{
 						Name:  "mystep",
 						Image: "myimage",
-					}},
+					}}},
 				},
 				Trigger: TaskTrigger{
 					Type: "manual",
@@ -148,6 +148,61 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			},
 			wantErr: apis.ErrDisallowedFields("spec.taskspec", "spec.taskref"),
 		},
+		{
+			name: "negative retries",
+			spec: TaskRunSpec{
+				TaskRef: &TaskRef{
+					Name: "taskrefname",
+				},
+				Retries: -1,
+			},
+			wantErr: apis.ErrInvalidValue("-1 should be >= 0", "spec.retries"),
+		},
+		{
+			name: "duplicated workspace bindings",
+			spec: TaskRunSpec{
+				TaskRef: &TaskRef{
+					Name: "taskrefname",
+				},
+				Workspaces: []WorkspaceBinding{{
+					Name:     "source",
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				}, {
+					Name:     "source",
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				}},
+			},
+			wantErr: apis.ErrMultipleOneOf("spec.workspaces.name"),
+		},
+		{
+			name: "workspace bound to several volumes",
+			spec: TaskRunSpec{
+				TaskRef: &TaskRef{
+					Name: "taskrefname",
+				},
+				Workspaces: []WorkspaceBinding{{
+					Name:     "source",
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "pvc",
+					},
+				}},
+			},
+			wantErr: apis.ErrMultipleOneOf("spec.workspaces.persistentVolumeClaim", "spec.workspaces.emptyDir"),
+		},
+		{
+			name: "workspace not bound to any volume",
+			spec: TaskRunSpec{
+				TaskRef: &TaskRef{
+					Name: "taskrefname",
+				},
+				Workspaces: []WorkspaceBinding{{
+					Name: "source",
+				}},
+			},
+			wantErr: apis.ErrMissingField("spec.workspaces.persistentVolumeClaim", "spec.workspaces.volumeClaimTemplate",
+				"spec.workspaces.emptyDir", "spec.workspaces.configMap", "spec.workspaces.secret"),
+		},
 	}
 
 	for _, ts := range tests {
@@ -193,14 +248,34 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "workspace bindings",
+			spec: TaskRunSpec{
+				TaskRef: &TaskRef{
+					Name: "taskrefname",
+				},
+				Workspaces: []WorkspaceBinding{{
+					Name:    "source",
+					SubPath: "src",
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "pvc",
+					},
+				}, {
+					Name: "config",
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "config"},
+					},
+				}},
+			},
+		},
 		{
 			name: "taskspec without a taskRef",
 			spec: TaskRunSpec{
 				TaskSpec: &TaskSpec{
-					Steps: []corev1.Container{{
+					Steps: []Step{{Container: corev1.Container{
 						Name:  "mystep",
 						Image: "myimage",
-					}},
+					}}},
 				},
 				Trigger: TaskTrigger{
 					Type: "PiPeLiNeRuN",
@@ -223,7 +298,7 @@ func TestInput_Validate(t *testing.T) {
 	i := TaskRunInputs{
 		Params: []Param{{
 			Name:  "name",
-			Value: "value",
+			Value: *NewArrayOrString("value"),
 		}},
 		Resources: []TaskResourceBinding{{
 			ResourceRef: PipelineResourceRef{
@@ -271,10 +346,10 @@ func TestInput_Invalidate(t *testing.T) {
 				}},
 				Params: []Param{{
 					Name:  "name",
-					Value: "value",
+					Value: *NewArrayOrString("value"),
 				}, {
 					Name:  "name",
-					Value: "value",
+					Value: *NewArrayOrString("value"),
 				}},
 			},
 			wantErr: apis.ErrMultipleOneOf("spec.inputs.params"),
@@ -0,0 +1,95 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// WorkspaceDeclaration is a volume the Task expects to be bound by its TaskRun
+// and mounted into each of its steps.
+type WorkspaceDeclaration struct {
+	// Name is the name by which the workspace is bound and referenced.
+	Name string `json:"name"`
+	// Description is a human readable description of the workspace.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// MountPath overrides the directory the workspace is mounted at, which
+	// defaults to /workspace/<name>.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+	// ReadOnly mounts the workspace as read-only into the steps.
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
+}
+
+// GetMountPath returns the directory the workspace is mounted at in the steps.
+func (w WorkspaceDeclaration) GetMountPath() string {
+	if w.MountPath != "" {
+		return w.MountPath
+	}
+	return filepath.Join(workspaceDir, w.Name)
+}
+
+// WorkspaceBinding binds the workspace called Name to a volume. Exactly one
+// source of volume must be provided.
+type WorkspaceBinding struct {
+	// Name is the name of the workspace being bound.
+	Name string `json:"name"`
+	// SubPath is a directory of the volume to use as the workspace instead of
+	// its root.
+	// +optional
+	SubPath string `json:"subPath,omitempty"`
+	// PersistentVolumeClaim binds the workspace to an existing claim.
+	// +optional
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+	// VolumeClaimTemplate is the template of a claim created for the run and
+	// deleted with it.
+	// +optional
+	VolumeClaimTemplate *corev1.PersistentVolumeClaim `json:"volumeClaimTemplate,omitempty"`
+	// EmptyDir binds the workspace to a directory which only lives as long as
+	// the pod of the TaskRun.
+	// +optional
+	EmptyDir *corev1.EmptyDirVolumeSource `json:"emptyDir,omitempty"`
+	// ConfigMap binds the workspace to the content of a ConfigMap.
+	// +optional
+	ConfigMap *corev1.ConfigMapVolumeSource `json:"configMap,omitempty"`
+	// Secret binds the workspace to the content of a Secret.
+	// +optional
+	Secret *corev1.SecretVolumeSource `json:"secret,omitempty"`
+}
+
+// PipelineWorkspaceDeclaration is a workspace the Pipeline expects to be bound by
+// its PipelineRun, which can be shared by several PipelineTasks.
+type PipelineWorkspaceDeclaration struct {
+	// Name is the name by which the workspace is bound and referenced.
+	Name string `json:"name"`
+	// Description is a human readable description of the workspace.
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// WorkspacePipelineTaskBinding maps the workspace called Name of the Task of a
+// PipelineTask to the workspace called Workspace of the Pipeline.
+type WorkspacePipelineTaskBinding struct {
+	// Name is the name of the workspace declared by the Task.
+	Name string `json:"name"`
+	// Workspace is the name of the workspace declared by the Pipeline.
+	Workspace string `json:"workspace"`
+}
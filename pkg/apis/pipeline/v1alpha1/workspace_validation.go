@@ -0,0 +1,140 @@
+/*
+Copyright 2019 The Knative Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/knative/pkg/apis"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// validateDeclaredWorkspaces ensures that the workspaces declared by a Task have unique
+// names which can be used in the name of a volume, and that they aren't mounted at the
+// same path.
+func validateDeclaredWorkspaces(workspaces []WorkspaceDeclaration) *apis.FieldError {
+	names := map[string]struct{}{}
+	mountPaths := map[string]struct{}{}
+	for _, w := range workspaces {
+		if errs := validation.IsDNS1123Label(w.Name); len(errs) > 0 {
+			return apis.ErrInvalidValue(w.Name, "name")
+		}
+		if _, ok := names[w.Name]; ok {
+			return apis.ErrMultipleOneOf("name")
+		}
+		names[w.Name] = struct{}{}
+
+		mountPath := filepath.Clean(w.GetMountPath())
+		if !filepath.IsAbs(mountPath) {
+			return apis.ErrInvalidValue(w.MountPath, "mountPath")
+		}
+		if _, ok := mountPaths[mountPath]; ok {
+			return apis.ErrMultipleOneOf("mountPath")
+		}
+		mountPaths[mountPath] = struct{}{}
+	}
+	return nil
+}
+
+// validateWorkspaceBindings ensures that each workspace is bound only once, and to
+// exactly one source of volume.
+func validateWorkspaceBindings(bindings []WorkspaceBinding) *apis.FieldError {
+	names := map[string]struct{}{}
+	for _, b := range bindings {
+		if b.Name == "" {
+			return apis.ErrMissingField("name")
+		}
+		if _, ok := names[b.Name]; ok {
+			return apis.ErrMultipleOneOf("name")
+		}
+		names[b.Name] = struct{}{}
+		if err := b.validateSource(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b WorkspaceBinding) validateSource() *apis.FieldError {
+	sources := []string{}
+	if b.PersistentVolumeClaim != nil {
+		sources = append(sources, "persistentVolumeClaim")
+	}
+	if b.VolumeClaimTemplate != nil {
+		sources = append(sources, "volumeClaimTemplate")
+	}
+	if b.EmptyDir != nil {
+		sources = append(sources, "emptyDir")
+	}
+	if b.ConfigMap != nil {
+		sources = append(sources, "configMap")
+	}
+	if b.Secret != nil {
+		sources = append(sources, "secret")
+	}
+	switch {
+	case len(sources) == 0:
+		return apis.ErrMissingField("persistentVolumeClaim", "volumeClaimTemplate", "emptyDir", "configMap", "secret")
+	case len(sources) > 1:
+		return apis.ErrMultipleOneOf(sources...)
+	}
+	if b.PersistentVolumeClaim != nil && b.PersistentVolumeClaim.ClaimName == "" {
+		return apis.ErrMissingField("persistentVolumeClaim.claimName")
+	}
+	return nil
+}
+
+// validatePipelineWorkspaces ensures that the workspaces declared by ps have unique names,
+// and that its tasks and finally tasks only map the workspaces of their Task to them.
+func validatePipelineWorkspaces(ps *PipelineSpec) *apis.FieldError {
+	names := map[string]struct{}{}
+	for _, w := range ps.Workspaces {
+		if w.Name == "" {
+			return apis.ErrMissingField("spec.workspaces.name")
+		}
+		if _, ok := names[w.Name]; ok {
+			return apis.ErrMultipleOneOf("spec.workspaces.name")
+		}
+		names[w.Name] = struct{}{}
+	}
+	for _, t := range ps.Tasks {
+		if err := validatePipelineTaskWorkspaces(t, names); err != nil {
+			return err.ViaField("spec.tasks")
+		}
+	}
+	for _, t := range ps.Finally {
+		if err := validatePipelineTaskWorkspaces(t, names); err != nil {
+			return err.ViaField("spec.finally")
+		}
+	}
+	return nil
+}
+
+func validatePipelineTaskWorkspaces(t PipelineTask, pipelineWorkspaces map[string]struct{}) *apis.FieldError {
+	names := map[string]struct{}{}
+	for _, w := range t.Workspaces {
+		if _, ok := names[w.Name]; ok {
+			return apis.ErrMultipleOneOf("workspaces.name")
+		}
+		names[w.Name] = struct{}{}
+		if _, ok := pipelineWorkspaces[w.Workspace]; !ok {
+			return apis.ErrInvalidValue(fmt.Sprintf("task %s uses undeclared workspace %s", t.Name, w.Workspace), "workspaces.workspace")
+		}
+	}
+	return nil
+}
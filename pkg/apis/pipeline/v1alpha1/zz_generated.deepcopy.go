@@ -26,6 +26,27 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArrayOrString) DeepCopyInto(out *ArrayOrString) {
+	*out = *in
+	if in.ArrayVal != nil {
+		in, out := &in.ArrayVal, &out.ArrayVal
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArrayOrString.
+func (in *ArrayOrString) DeepCopy() *ArrayOrString {
+	if in == nil {
+		return nil
+	}
+	out := new(ArrayOrString)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactBucket) DeepCopyInto(out *ArtifactBucket) {
 	*out = *in
@@ -165,6 +186,27 @@ func (in *ClusterTaskList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConditionExpression) DeepCopyInto(out *ConditionExpression) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConditionExpression.
+func (in *ConditionExpression) DeepCopy() *ConditionExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(ConditionExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DAG) DeepCopyInto(out *DAG) {
 	*out = *in
@@ -257,7 +299,9 @@ func (in *Inputs) DeepCopyInto(out *Inputs) {
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
 		*out = make([]TaskParam, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -342,6 +386,7 @@ func (in *Outputs) DeepCopy() *Outputs {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Param) DeepCopyInto(out *Param) {
 	*out = *in
+	in.Value.DeepCopyInto(&out.Value)
 	return
 }
 
@@ -435,6 +480,15 @@ func (in *PipelineList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineParam) DeepCopyInto(out *PipelineParam) {
 	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ArrayOrString)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -496,6 +550,15 @@ func (in *PipelineResource) DeepCopyObject() runtime.Object {
 func (in *PipelineResourceBinding) DeepCopyInto(out *PipelineResourceBinding) {
 	*out = *in
 	out.ResourceRef = in.ResourceRef
+	if in.ResourceSpec != nil {
+		in, out := &in.ResourceSpec, &out.ResourceSpec
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PipelineResourceSpec)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -563,7 +626,7 @@ func (in *PipelineResourceSpec) DeepCopyInto(out *PipelineResourceSpec) {
 	*out = *in
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
-		*out = make([]Param, len(*in))
+		*out = make([]ResourceParam, len(*in))
 		copy(*out, *in)
 	}
 	if in.SecretParams != nil {
@@ -600,6 +663,22 @@ func (in *PipelineResourceStatus) DeepCopy() *PipelineResourceStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineResult) DeepCopyInto(out *PipelineResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineResult.
+func (in *PipelineResult) DeepCopy() *PipelineResult {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineRun) DeepCopyInto(out *PipelineRun) {
 	*out = *in
@@ -628,6 +707,84 @@ func (in *PipelineRun) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunApproval) DeepCopyInto(out *PipelineRunApproval) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunApproval.
+func (in *PipelineRunApproval) DeepCopy() *PipelineRunApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunChildStatus) DeepCopyInto(out *PipelineRunChildStatus) {
+	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PipelineRunStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.ConditionChecks != nil {
+		in, out := &in.ConditionChecks, &out.ConditionChecks
+		*out = make(map[string]*PipelineRunConditionCheckStatus, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = new(PipelineRunConditionCheckStatus)
+				val.DeepCopyInto((*out)[key])
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunChildStatus.
+func (in *PipelineRunChildStatus) DeepCopy() *PipelineRunChildStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunChildStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunConditionCheckStatus) DeepCopyInto(out *PipelineRunConditionCheckStatus) {
+	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(TaskRunStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunConditionCheckStatus.
+func (in *PipelineRunConditionCheckStatus) DeepCopy() *PipelineRunConditionCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunConditionCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineRunList) DeepCopyInto(out *PipelineRunList) {
 	*out = *in
@@ -661,19 +818,127 @@ func (in *PipelineRunList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunPendingApproval) DeepCopyInto(out *PipelineRunPendingApproval) {
+	*out = *in
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunPendingApproval.
+func (in *PipelineRunPendingApproval) DeepCopy() *PipelineRunPendingApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunPendingApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunRef) DeepCopyInto(out *PipelineRunRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunRef.
+func (in *PipelineRunRef) DeepCopy() *PipelineRunRef {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunResult) DeepCopyInto(out *PipelineRunResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunResult.
+func (in *PipelineRunResult) DeepCopy() *PipelineRunResult {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunRunStatus) DeepCopyInto(out *PipelineRunRunStatus) {
+	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(RunStatus)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.ConditionChecks != nil {
+		in, out := &in.ConditionChecks, &out.ConditionChecks
+		*out = make(map[string]*PipelineRunConditionCheckStatus, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = new(PipelineRunConditionCheckStatus)
+				val.DeepCopyInto((*out)[key])
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunRunStatus.
+func (in *PipelineRunRunStatus) DeepCopy() *PipelineRunRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
 	*out = *in
 	out.PipelineRef = in.PipelineRef
+	if in.PipelineSpec != nil {
+		in, out := &in.PipelineSpec, &out.PipelineSpec
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PipelineSpec)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	out.Trigger = in.Trigger
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = make([]PipelineResourceBinding, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
 		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]PipelineRunSpecServiceAccount, len(*in))
 		copy(*out, *in)
 	}
 	if in.Results != nil {
@@ -717,6 +982,36 @@ func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PodTemplate)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]WorkspaceBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Approvals != nil {
+		in, out := &in.Approvals, &out.Approvals
+		*out = make([]PipelineRunApproval, len(*in))
+		copy(*out, *in)
+	}
+	if in.RerunOf != nil {
+		in, out := &in.RerunOf, &out.RerunOf
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PipelineRunRef)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -730,6 +1025,22 @@ func (in *PipelineRunSpec) DeepCopy() *PipelineRunSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineRunSpecServiceAccount) DeepCopyInto(out *PipelineRunSpecServiceAccount) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunSpecServiceAccount.
+func (in *PipelineRunSpecServiceAccount) DeepCopy() *PipelineRunSpecServiceAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineRunSpecServiceAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineRunStatus) DeepCopyInto(out *PipelineRunStatus) {
 	*out = *in
@@ -761,6 +1072,24 @@ func (in *PipelineRunStatus) DeepCopyInto(out *PipelineRunStatus) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.PausedTime != nil {
+		in, out := &in.PausedTime, &out.PausedTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.PausedDuration != nil {
+		in, out := &in.PausedDuration, &out.PausedDuration
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Duration)
+			**out = **in
+		}
+	}
 	if in.TaskRuns != nil {
 		in, out := &in.TaskRuns, &out.TaskRuns
 		*out = make(map[string]*PipelineRunTaskRunStatus, len(*in))
@@ -773,14 +1102,55 @@ func (in *PipelineRunStatus) DeepCopyInto(out *PipelineRunStatus) {
 			}
 		}
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunStatus.
-func (in *PipelineRunStatus) DeepCopy() *PipelineRunStatus {
-	if in == nil {
-		return nil
-	}
+	if in.ChildPipelineRuns != nil {
+		in, out := &in.ChildPipelineRuns, &out.ChildPipelineRuns
+		*out = make(map[string]*PipelineRunChildStatus, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = new(PipelineRunChildStatus)
+				val.DeepCopyInto((*out)[key])
+			}
+		}
+	}
+	if in.Runs != nil {
+		in, out := &in.Runs, &out.Runs
+		*out = make(map[string]*PipelineRunRunStatus, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = new(PipelineRunRunStatus)
+				val.DeepCopyInto((*out)[key])
+			}
+		}
+	}
+	if in.SkippedTasks != nil {
+		in, out := &in.SkippedTasks, &out.SkippedTasks
+		*out = make([]SkippedTask, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingApprovals != nil {
+		in, out := &in.PendingApprovals, &out.PendingApprovals
+		*out = make([]PipelineRunPendingApproval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PipelineResults != nil {
+		in, out := &in.PipelineResults, &out.PipelineResults
+		*out = make([]PipelineRunResult, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineRunStatus.
+func (in *PipelineRunStatus) DeepCopy() *PipelineRunStatus {
+	if in == nil {
+		return nil
+	}
 	out := new(PipelineRunStatus)
 	in.DeepCopyInto(out)
 	return out
@@ -798,6 +1168,18 @@ func (in *PipelineRunTaskRunStatus) DeepCopyInto(out *PipelineRunTaskRunStatus)
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.ConditionChecks != nil {
+		in, out := &in.ConditionChecks, &out.ConditionChecks
+		*out = make(map[string]*PipelineRunConditionCheckStatus, len(*in))
+		for key, val := range *in {
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				(*out)[key] = new(PipelineRunConditionCheckStatus)
+				val.DeepCopyInto((*out)[key])
+			}
+		}
+	}
 	return
 }
 
@@ -829,6 +1211,25 @@ func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
 		*out = make([]PipelineParam, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Finally != nil {
+		in, out := &in.Finally, &out.Finally
+		*out = make([]PipelineTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]PipelineResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]PipelineWorkspaceDeclaration, len(*in))
 		copy(*out, *in)
 	}
 	return
@@ -864,6 +1265,15 @@ func (in *PipelineStatus) DeepCopy() *PipelineStatus {
 func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
 	*out = *in
 	out.TaskRef = in.TaskRef
+	if in.TaskSpec != nil {
+		in, out := &in.TaskSpec, &out.TaskSpec
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(TaskSpec)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	if in.RunAfter != nil {
 		in, out := &in.RunAfter, &out.RunAfter
 		*out = make([]string, len(*in))
@@ -881,6 +1291,45 @@ func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
 		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]PipelineTaskCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PipelineTaskApproval)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Duration)
+			**out = **in
+		}
+	}
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]WorkspacePipelineTaskBinding, len(*in))
 		copy(*out, *in)
 	}
 	return
@@ -896,6 +1345,61 @@ func (in *PipelineTask) DeepCopy() *PipelineTask {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskApproval) DeepCopyInto(out *PipelineTaskApproval) {
+	*out = *in
+	if in.Approvers != nil {
+		in, out := &in.Approvers, &out.Approvers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskApproval.
+func (in *PipelineTaskApproval) DeepCopy() *PipelineTaskApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskCondition) DeepCopyInto(out *PipelineTaskCondition) {
+	*out = *in
+	if in.Check != nil {
+		in, out := &in.Check, &out.Check
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(core_v1.Container)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Expression != nil {
+		in, out := &in.Expression, &out.Expression
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ConditionExpression)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskCondition.
+func (in *PipelineTaskCondition) DeepCopy() *PipelineTaskCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineTaskInputResource) DeepCopyInto(out *PipelineTaskInputResource) {
 	*out = *in
@@ -1009,6 +1513,116 @@ func (in *PipelineTrigger) DeepCopy() *PipelineTrigger {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineWorkspaceDeclaration) DeepCopyInto(out *PipelineWorkspaceDeclaration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineWorkspaceDeclaration.
+func (in *PipelineWorkspaceDeclaration) DeepCopy() *PipelineWorkspaceDeclaration {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineWorkspaceDeclaration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodTemplate) DeepCopyInto(out *PodTemplate) {
+	*out = *in
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(core_v1.PodSecurityContext)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]core_v1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(core_v1.PodDNSConfig)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]core_v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]core_v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodTemplate.
+func (in *PodTemplate) DeepCopy() *PodTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PodTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceParam) DeepCopyInto(out *ResourceParam) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceParam.
+func (in *ResourceParam) DeepCopy() *ResourceParam {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceParam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultRef) DeepCopyInto(out *ResultRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultRef.
+func (in *ResultRef) DeepCopy() *ResultRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ResultRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Results) DeepCopyInto(out *Results) {
 	*out = *in
@@ -1025,6 +1639,155 @@ func (in *Results) DeepCopy() *Results {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Run) DeepCopyInto(out *Run) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Run.
+func (in *Run) DeepCopy() *Run {
+	if in == nil {
+		return nil
+	}
+	out := new(Run)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Run) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunList) DeepCopyInto(out *RunList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Run, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunList.
+func (in *RunList) DeepCopy() *RunList {
+	if in == nil {
+		return nil
+	}
+	out := new(RunList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RunList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunResult) DeepCopyInto(out *RunResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunResult.
+func (in *RunResult) DeepCopy() *RunResult {
+	if in == nil {
+		return nil
+	}
+	out := new(RunResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunSpec) DeepCopyInto(out *RunSpec) {
+	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(TaskRef)
+			**out = **in
+		}
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]Param, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunSpec.
+func (in *RunSpec) DeepCopy() *RunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunStatus) DeepCopyInto(out *RunStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Time)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]RunResult, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunStatus.
+func (in *RunStatus) DeepCopy() *RunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretParam) DeepCopyInto(out *SecretParam) {
 	*out = *in
@@ -1041,6 +1804,48 @@ func (in *SecretParam) DeepCopy() *SecretParam {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkippedTask) DeepCopyInto(out *SkippedTask) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkippedTask.
+func (in *SkippedTask) DeepCopy() *SkippedTask {
+	if in == nil {
+		return nil
+	}
+	out := new(SkippedTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Step) DeepCopyInto(out *Step) {
+	*out = *in
+	in.Container.DeepCopyInto(&out.Container)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(v1.Duration)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Step.
+func (in *Step) DeepCopy() *Step {
+	if in == nil {
+		return nil
+	}
+	out := new(Step)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StepState) DeepCopyInto(out *StepState) {
 	*out = *in
@@ -1121,6 +1926,15 @@ func (in *TaskList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskParam) DeepCopyInto(out *TaskParam) {
 	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ArrayOrString)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -1197,6 +2011,22 @@ func (in *TaskResourceBinding) DeepCopy() *TaskResourceBinding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskResult) DeepCopyInto(out *TaskResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskResult.
+func (in *TaskResult) DeepCopy() *TaskResult {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskRun) DeepCopyInto(out *TaskRun) {
 	*out = *in
@@ -1238,7 +2068,9 @@ func (in *TaskRunInputs) DeepCopyInto(out *TaskRunInputs) {
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
 		*out = make([]Param, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -1299,7 +2131,9 @@ func (in *TaskRunOutputs) DeepCopyInto(out *TaskRunOutputs) {
 	if in.Params != nil {
 		in, out := &in.Params, &out.Params
 		*out = make([]Param, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -1314,6 +2148,22 @@ func (in *TaskRunOutputs) DeepCopy() *TaskRunOutputs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRunResult) DeepCopyInto(out *TaskRunResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRunResult.
+func (in *TaskRunResult) DeepCopy() *TaskRunResult {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskRunResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 	*out = *in
@@ -1379,6 +2229,22 @@ func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PodTemplate)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]WorkspaceBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1430,6 +2296,18 @@ func (in *TaskRunStatus) DeepCopyInto(out *TaskRunStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RetriesStatus != nil {
+		in, out := &in.RetriesStatus, &out.RetriesStatus
+		*out = make([]TaskRunStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TaskResults != nil {
+		in, out := &in.TaskResults, &out.TaskResults
+		*out = make([]TaskRunResult, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1466,7 +2344,7 @@ func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 	}
 	if in.Steps != nil {
 		in, out := &in.Steps, &out.Steps
-		*out = make([]core_v1.Container, len(*in))
+		*out = make([]Step, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -1478,6 +2356,23 @@ func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]core_v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]TaskResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]WorkspaceDeclaration, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1522,3 +2417,96 @@ func (in *TestResult) DeepCopy() *TestResult {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceBinding) DeepCopyInto(out *WorkspaceBinding) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(core_v1.PersistentVolumeClaimVolumeSource)
+			**out = **in
+		}
+	}
+	if in.VolumeClaimTemplate != nil {
+		in, out := &in.VolumeClaimTemplate, &out.VolumeClaimTemplate
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(core_v1.PersistentVolumeClaim)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.EmptyDir != nil {
+		in, out := &in.EmptyDir, &out.EmptyDir
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(core_v1.EmptyDirVolumeSource)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(core_v1.ConfigMapVolumeSource)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(core_v1.SecretVolumeSource)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceBinding.
+func (in *WorkspaceBinding) DeepCopy() *WorkspaceBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceDeclaration) DeepCopyInto(out *WorkspaceDeclaration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceDeclaration.
+func (in *WorkspaceDeclaration) DeepCopy() *WorkspaceDeclaration {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceDeclaration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspacePipelineTaskBinding) DeepCopyInto(out *WorkspacePipelineTaskBinding) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspacePipelineTaskBinding.
+func (in *WorkspacePipelineTaskBinding) DeepCopy() *WorkspacePipelineTaskBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspacePipelineTaskBinding)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -189,6 +189,34 @@ func TestInitializeArtifactStorageWithoutConfigMap(t *testing.T) {
 	}
 }
 
+func TestInitializeArtifactStorageOfRerun(t *testing.T) {
+	logger := logtesting.TestLogger(t)
+	fakekubeclient := fakek8s.NewSimpleClientset()
+	pipelinerun := &v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "foo",
+			Name:      "pipelineruntest-rerun",
+			Labels:    map[string]string{"tekton.dev/artifactStorage": "pipelineruntest"},
+		},
+	}
+
+	pvc, err := InitializeArtifactStorage(pipelinerun, fakekubeclient, logger)
+	if err != nil {
+		t.Fatalf("Somehow had error initializing artifact storage run out of fake client: %s", err)
+	}
+
+	// The rerun uses the storage of the PipelineRun it reruns
+	expectedArtifactPVC := &v1alpha1.ArtifactPVC{
+		Name: "pipelineruntest",
+	}
+	if diff := cmp.Diff(pvc, expectedArtifactPVC); diff != "" {
+		t.Fatalf("want %v, but got %v", expectedArtifactPVC, pvc)
+	}
+	if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims("foo").Get("pipelineruntest-pvc", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected the PVC of the rerun PipelineRun to be used but got %v", err)
+	}
+}
+
 func TestGetArtifactStorageWithConfigMap(t *testing.T) {
 	logger := logtesting.TestLogger(t)
 	prName := "pipelineruntest"
@@ -53,7 +53,7 @@ func InitializeArtifactStorage(pr *v1alpha1.PipelineRun, c kubernetes.Interface,
 		if err != nil {
 			return nil, err
 		}
-		return &v1alpha1.ArtifactPVC{Name: pr.Name}, nil
+		return &v1alpha1.ArtifactPVC{Name: pr.GetArtifactStorageName()}, nil
 	}
 
 	return NewArtifactBucketConfigFromConfigMap(configMap)
@@ -157,6 +157,8 @@ func getPVCSpec(pr *v1alpha1.PipelineRun) *corev1.PersistentVolumeClaim {
 	}
 }
 
+// getPVCName returns the name of the PVC used by pr, which is the PVC of the PipelineRun it
+// reruns, if any.
 func getPVCName(pr *v1alpha1.PipelineRun) string {
-	return fmt.Sprintf("%s-pvc", pr.Name)
+	return fmt.Sprintf("%s-pvc", pr.GetArtifactStorageName())
 }
@@ -23,6 +23,8 @@ type PipelineResourceExpansion interface{}
 
 type PipelineRunExpansion interface{}
 
+type RunExpansion interface{}
+
 type TaskExpansion interface{}
 
 type TaskRunExpansion interface{}
@@ -28,6 +28,7 @@ type TektonV1alpha1Interface interface {
 	PipelinesGetter
 	PipelineResourcesGetter
 	PipelineRunsGetter
+	RunsGetter
 	TasksGetter
 	TaskRunsGetter
 }
@@ -53,6 +54,10 @@ func (c *TektonV1alpha1Client) PipelineRuns(namespace string) PipelineRunInterfa
 	return newPipelineRuns(c, namespace)
 }
 
+func (c *TektonV1alpha1Client) Runs(namespace string) RunInterface {
+	return newRuns(c, namespace)
+}
+
 func (c *TektonV1alpha1Client) Tasks(namespace string) TaskInterface {
 	return newTasks(c, namespace)
 }
@@ -17,7 +17,13 @@ limitations under the License.
 package entrypoint
 
 import (
+	"context"
 	"fmt"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 )
 
 // Entrypointer holds fields for running commands with redirected
@@ -33,6 +39,16 @@ type Entrypointer struct {
 	// PostFile is the file to write when complete. If not specified, no
 	// file is written.
 	PostFile string
+	// ResultsDir is the directory the results of the Task are written to.
+	// If not specified, no results are reported.
+	ResultsDir string
+	// Timeout is the time after which the command is killed. If not
+	// specified, the command can run for as long as it needs.
+	Timeout time.Duration
+	// OnError is what happens when the command fails. If it is "continue",
+	// the exit code of the command is reported and the post file is written
+	// as if it had succeeded.
+	OnError v1alpha1.OnErrorType
 
 	// Waiter encapsulates waiting for files to exist.
 	Waiter Waiter
@@ -40,6 +56,8 @@ type Entrypointer struct {
 	Runner Runner
 	// PostWriter encapsulates writing files when complete.
 	PostWriter PostWriter
+	// ResultsWriter encapsulates reporting the results when complete.
+	ResultsWriter ResultsWriter
 }
 
 // Waiter encapsulates waiting for files to exist.
@@ -50,7 +68,8 @@ type Waiter interface {
 
 // Runner encapsulates running commands.
 type Runner interface {
-	Run(args ...string) error
+	// Run runs the command, which is killed once ctx is done.
+	Run(ctx context.Context, args ...string) error
 }
 
 // PostWriter encapsulates writing a file when complete.
@@ -59,8 +78,18 @@ type PostWriter interface {
 	Write(file string)
 }
 
-// Go optionally waits for a file, runs the command, and writes a
-// post file.
+// ResultsWriter encapsulates reporting the results of the Task.
+type ResultsWriter interface {
+	// ReadResults returns the results written to files in dir.
+	ReadResults(dir string) ([]v1alpha1.TaskRunResult, error)
+	// WriteResults reports the results, along with the reserved entries
+	// reporting why the step terminated or the exit code of its command.
+	WriteResults(results []v1alpha1.TaskRunResult) error
+}
+
+// Go optionally waits for a file, runs the command, reports the results
+// and writes a post file. If the command runs for longer than the timeout,
+// it is killed and the step is reported as timed out.
 func (e Entrypointer) Go() error {
 	if e.WaitFile != "" {
 		if err := e.Waiter.Wait(e.WaitFile); err != nil {
@@ -75,7 +104,43 @@ func (e Entrypointer) Go() error {
 		e.Args = append([]string{e.Entrypoint}, e.Args...)
 	}
 
-	err := e.Runner.Run(e.Args...)
+	ctx := context.Background()
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+	// The reason, exit code and results are all reported at once, since
+	// they share the termination message of the container
+	var results []v1alpha1.TaskRunResult
+	err := e.Runner.Run(ctx, e.Args...)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("step timed out after %s", e.Timeout)
+		results = append(results, v1alpha1.TaskRunResult{
+			Name:  v1alpha1.StepReasonResultName,
+			Value: v1alpha1.StepReasonTimedOut,
+		})
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok && e.OnError == v1alpha1.StepOnErrorContinue {
+		// The next steps are run as if this one had succeeded
+		err = nil
+		results = append(results, v1alpha1.TaskRunResult{
+			Name:  v1alpha1.StepExitCodeResultName,
+			Value: strconv.Itoa(exitErr.ExitCode()),
+		})
+	}
+
+	// Report the results written by the command, if it succeeded
+	if err == nil && e.ResultsDir != "" {
+		var taskResults []v1alpha1.TaskRunResult
+		taskResults, err = e.ResultsWriter.ReadResults(e.ResultsDir)
+		results = append(results, taskResults...)
+	}
+	if len(results) > 0 {
+		if werr := e.ResultsWriter.WriteResults(results); werr != nil && err == nil {
+			err = werr
+		}
+	}
 
 	// Write the post file *no matter what*
 	e.WritePostFile(e.PostFile, err)
@@ -17,19 +17,24 @@ limitations under the License.
 package entrypoint
 
 import (
+	"context"
 	"fmt"
+	"os/exec"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 )
 
 func TestEntrypointerFailures(t *testing.T) {
 	for _, c := range []struct {
-		desc, waitFile, postFile string
-		waiter                   Waiter
-		runner                   Runner
-		expectedError            string
+		desc, waitFile, postFile, resultsDir string
+		waiter                               Waiter
+		runner                               Runner
+		resultsWriter                        ResultsWriter
+		expectedError                        string
 	}{{
 		desc:          "failing runner with no postFile",
 		runner:        &fakeErrorRunner{},
@@ -50,6 +55,12 @@ func TestEntrypointerFailures(t *testing.T) {
 		waiter:        &fakeErrorWaiter{},
 		expectedError: "waiter failed",
 		postFile:      "bar",
+	}, {
+		desc:          "failing results writer",
+		resultsDir:    "results",
+		resultsWriter: &fakeErrorResultsWriter{},
+		expectedError: "results writer failed",
+		postFile:      "foo",
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			fw := c.waiter
@@ -60,15 +71,21 @@ func TestEntrypointerFailures(t *testing.T) {
 			if fr == nil {
 				fr = &fakeRunner{}
 			}
+			frw := c.resultsWriter
+			if frw == nil {
+				frw = &fakeResultsWriter{}
+			}
 			fpw := &fakePostWriter{}
 			err := Entrypointer{
-				Entrypoint: "echo",
-				WaitFile:   c.waitFile,
-				PostFile:   c.postFile,
-				Args:       []string{"some", "args"},
-				Waiter:     fw,
-				Runner:     fr,
-				PostWriter: fpw,
+				Entrypoint:    "echo",
+				WaitFile:      c.waitFile,
+				PostFile:      c.postFile,
+				ResultsDir:    c.resultsDir,
+				Args:          []string{"some", "args"},
+				Waiter:        fw,
+				Runner:        fr,
+				PostWriter:    fpw,
+				ResultsWriter: frw,
 			}.Go()
 			if err == nil {
 				t.Fatalf("Entrpointer didn't fail")
@@ -93,8 +110,8 @@ func TestEntrypointerFailures(t *testing.T) {
 
 func TestEntrypointer(t *testing.T) {
 	for _, c := range []struct {
-		desc, entrypoint, waitFile, postFile string
-		args                                 []string
+		desc, entrypoint, waitFile, postFile, resultsDir string
+		args                                             []string
 	}{{
 		desc: "do nothing",
 	}, {
@@ -112,22 +129,28 @@ func TestEntrypointer(t *testing.T) {
 	}, {
 		desc:     "post file",
 		postFile: "writeme",
+	}, {
+		desc:       "results dir",
+		resultsDir: "results",
 	}, {
 		desc:       "all together now",
 		entrypoint: "echo", args: []string{"some", "args"},
-		waitFile: "waitforme",
-		postFile: "writeme",
+		waitFile:   "waitforme",
+		postFile:   "writeme",
+		resultsDir: "results",
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
-			fw, fr, fpw := &fakeWaiter{}, &fakeRunner{}, &fakePostWriter{}
+			fw, fr, fpw, frw := &fakeWaiter{}, &fakeRunner{}, &fakePostWriter{}, &fakeResultsWriter{}
 			err := Entrypointer{
-				Entrypoint: c.entrypoint,
-				WaitFile:   c.waitFile,
-				PostFile:   c.postFile,
-				Args:       c.args,
-				Waiter:     fw,
-				Runner:     fr,
-				PostWriter: fpw,
+				Entrypoint:    c.entrypoint,
+				WaitFile:      c.waitFile,
+				PostFile:      c.postFile,
+				ResultsDir:    c.resultsDir,
+				Args:          c.args,
+				Waiter:        fw,
+				Runner:        fr,
+				PostWriter:    fpw,
+				ResultsWriter: frw,
 			}.Go()
 			if err != nil {
 				t.Fatalf("Entrypointer failed: %v", err)
@@ -169,6 +192,119 @@ func TestEntrypointer(t *testing.T) {
 			if c.postFile == "" && fpw.wrote != nil {
 				t.Errorf("Wrote post file when not required")
 			}
+
+			if c.resultsDir != "" {
+				if frw.dir == nil {
+					t.Error("Wanted results reported, got nil")
+				} else if *frw.dir != c.resultsDir {
+					t.Errorf("Reported results from %q, want %q", *frw.dir, c.resultsDir)
+				}
+			}
+			if c.resultsDir == "" && frw.dir != nil {
+				t.Errorf("Reported results when not required")
+			}
+		})
+	}
+}
+
+func TestEntrypointerTimeout(t *testing.T) {
+	fr, fpw, frw := &fakeHangingRunner{}, &fakePostWriter{}, &fakeResultsWriter{}
+	err := Entrypointer{
+		Entrypoint:    "sleep",
+		PostFile:      "writeme",
+		ResultsDir:    "results",
+		Timeout:       10 * time.Millisecond,
+		Args:          []string{"1h"},
+		Waiter:        &fakeWaiter{},
+		Runner:        fr,
+		PostWriter:    fpw,
+		ResultsWriter: frw,
+	}.Go()
+	if err == nil {
+		t.Fatalf("Entrypointer didn't fail")
+	}
+	if d := cmp.Diff("step timed out after 10ms", err.Error()); d != "" {
+		t.Errorf("Entrypointer error diff -want, +got: %v", d)
+	}
+
+	// The next steps are skipped and the step reports that it timed out
+	if fpw.wrote == nil || *fpw.wrote != "writeme.err" {
+		t.Errorf("Wrote post file %v, want %q", fpw.wrote, "writeme.err")
+	}
+	want := [][]v1alpha1.TaskRunResult{{{
+		Name:  v1alpha1.StepReasonResultName,
+		Value: v1alpha1.StepReasonTimedOut,
+	}}}
+	if d := cmp.Diff(want, frw.wrote); d != "" {
+		t.Errorf("Reported results diff -want, +got: %v", d)
+	}
+	if frw.dir != nil {
+		t.Errorf("Read results of a step which timed out")
+	}
+}
+
+func TestEntrypointerOnErrorContinue(t *testing.T) {
+	for _, c := range []struct {
+		desc         string
+		onError      v1alpha1.OnErrorType
+		wantErr      bool
+		resultsDir   string
+		results      []v1alpha1.TaskRunResult
+		wantPostFile string
+		wantResults  [][]v1alpha1.TaskRunResult
+	}{{
+		desc:         "stop and fail by default",
+		wantErr:      true,
+		wantPostFile: "writeme.err",
+	}, {
+		desc:         "stop and fail",
+		onError:      v1alpha1.StepOnErrorStopAndFail,
+		wantErr:      true,
+		wantPostFile: "writeme.err",
+	}, {
+		desc:         "continue",
+		onError:      v1alpha1.StepOnErrorContinue,
+		wantPostFile: "writeme",
+		wantResults: [][]v1alpha1.TaskRunResult{{{
+			Name:  v1alpha1.StepExitCodeResultName,
+			Value: "3",
+		}}},
+	}, {
+		desc:         "continue with results",
+		onError:      v1alpha1.StepOnErrorContinue,
+		resultsDir:   "results",
+		results:      []v1alpha1.TaskRunResult{{Name: "status", Value: "failed"}},
+		wantPostFile: "writeme",
+		wantResults: [][]v1alpha1.TaskRunResult{{{
+			Name:  v1alpha1.StepExitCodeResultName,
+			Value: "3",
+		}, {
+			Name:  "status",
+			Value: "failed",
+		}}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			fpw, frw := &fakePostWriter{}, &fakeResultsWriter{read: c.results}
+			err := Entrypointer{
+				Entrypoint:    "lint",
+				PostFile:      "writeme",
+				ResultsDir:    c.resultsDir,
+				OnError:       c.onError,
+				Waiter:        &fakeWaiter{},
+				Runner:        &fakeExitRunner{},
+				PostWriter:    fpw,
+				ResultsWriter: frw,
+			}.Go()
+			if (err != nil) != c.wantErr {
+				t.Errorf("Expected error to be %t but got %v", c.wantErr, err)
+			}
+			if fpw.wrote == nil || *fpw.wrote != c.wantPostFile {
+				t.Errorf("Wrote post file %v, want %q", fpw.wrote, c.wantPostFile)
+			}
+			// The exit code and results share a single termination message
+			if d := cmp.Diff(c.wantResults, frw.wrote); d != "" {
+				t.Errorf("Reported results diff -want, +got: %v", d)
+			}
 		})
 	}
 }
@@ -182,7 +318,7 @@ func (f *fakeWaiter) Wait(file string) error {
 
 type fakeRunner struct{ args *[]string }
 
-func (f *fakeRunner) Run(args ...string) error {
+func (f *fakeRunner) Run(ctx context.Context, args ...string) error {
 	f.args = &args
 	return nil
 }
@@ -200,7 +336,52 @@ func (f *fakeErrorWaiter) Wait(file string) error {
 
 type fakeErrorRunner struct{ args *[]string }
 
-func (f *fakeErrorRunner) Run(args ...string) error {
+func (f *fakeErrorRunner) Run(ctx context.Context, args ...string) error {
 	f.args = &args
 	return fmt.Errorf("runner failed")
 }
+
+// fakeHangingRunner runs a command which only stops once it is killed.
+type fakeHangingRunner struct{ args *[]string }
+
+func (f *fakeHangingRunner) Run(ctx context.Context, args ...string) error {
+	f.args = &args
+	<-ctx.Done()
+	return fmt.Errorf("signal: killed")
+}
+
+// fakeExitRunner runs a command which exits with a non-zero code.
+type fakeExitRunner struct{ args *[]string }
+
+func (f *fakeExitRunner) Run(ctx context.Context, args ...string) error {
+	f.args = &args
+	return exec.Command("sh", "-c", "exit 3").Run()
+}
+
+// fakeResultsWriter reads the results in read, and records every write.
+type fakeResultsWriter struct {
+	dir   *string
+	read  []v1alpha1.TaskRunResult
+	wrote [][]v1alpha1.TaskRunResult
+}
+
+func (f *fakeResultsWriter) ReadResults(dir string) ([]v1alpha1.TaskRunResult, error) {
+	f.dir = &dir
+	return f.read, nil
+}
+
+func (f *fakeResultsWriter) WriteResults(results []v1alpha1.TaskRunResult) error {
+	f.wrote = append(f.wrote, results)
+	return nil
+}
+
+type fakeErrorResultsWriter struct{ dir *string }
+
+func (f *fakeErrorResultsWriter) ReadResults(dir string) ([]v1alpha1.TaskRunResult, error) {
+	f.dir = &dir
+	return nil, fmt.Errorf("results writer failed")
+}
+
+func (f *fakeErrorResultsWriter) WriteResults(results []v1alpha1.TaskRunResult) error {
+	return fmt.Errorf("results writer failed")
+}
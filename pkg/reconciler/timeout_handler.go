@@ -128,7 +128,8 @@ func (t *TimeoutSet) checkPipelineRunTimeouts(namespace string) {
 	}
 	for _, pipelineRun := range pipelineRuns.Items {
 		pipelineRun := pipelineRun
-		if pipelineRun.IsDone() || pipelineRun.IsCancelled() {
+		// The timeout of a paused pipelinerun is only tracked again once it is resumed
+		if pipelineRun.IsDone() || pipelineRun.IsCancelled() || pipelineRun.IsPaused() {
 			continue
 		}
 		go t.WaitPipelineRun(&pipelineRun)
@@ -201,14 +202,15 @@ func (t *TimeoutSet) WaitTaskRun(tr *v1alpha1.TaskRun) {
 }
 
 // WaitPipelineRun function creates a blocking function for pipelinerun to wait for
-// 1. Stop signal, 2. pipelinerun to complete or 3. pipelinerun to time out
+// 1. Stop signal, 2. pipelinerun to complete, or to be paused, or 3. pipelinerun to time out.
+// The time the pipelinerun spent paused doesn't count towards its timeout.
 func (t *TimeoutSet) WaitPipelineRun(pr *v1alpha1.PipelineRun) {
 	timeout := getTimeout(pr.Spec.Timeout)
 
 	runtime := time.Duration(0)
 	t.StatusLock(pr)
-	if pr.Status.StartTime != nil && !pr.Status.StartTime.Time.IsZero() {
-		runtime = time.Since(pr.Status.StartTime.Time)
+	if startTime := pr.TimeoutStartTime(); startTime != nil && !startTime.Time.IsZero() {
+		runtime = time.Since(startTime.Time)
 	}
 	t.StatusUnlock(pr)
 	timeout -= runtime
@@ -159,8 +159,31 @@ func TestPipelinRunCheckTimeouts(t *testing.T) {
 			Status: corev1.ConditionUnknown}),
 		),
 	)
+	prPaused := tb.PipelineRun("test-pipeline-paused", testNs,
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunTimeout(&metav1.Duration{Duration: 1 * time.Second}),
+			tb.PipelineRunPaused,
+		),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown}),
+			tb.PipelineRunStartTime(time.Now().AddDate(0, 0, -1)),
+			tb.PipelineRunPausedTime(time.Now().AddDate(0, 0, -1)),
+		),
+	)
+	prResumed := tb.PipelineRun("test-pipeline-resumed", testNs,
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunTimeout(&metav1.Duration{Duration: 1 * time.Hour}),
+		),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown}),
+			tb.PipelineRunStartTime(time.Now().AddDate(0, 0, -1)),
+			tb.PipelineRunPausedDuration(24*time.Hour),
+		),
+	)
 	d := test.Data{
-		PipelineRuns: []*v1alpha1.PipelineRun{prTimeout, prRunning, prDone, prCancelled},
+		PipelineRuns: []*v1alpha1.PipelineRun{prTimeout, prRunning, prDone, prCancelled, prPaused, prResumed},
 		Pipelines:    []*v1alpha1.Pipeline{simplePipeline},
 		Tasks:        []*v1alpha1.Task{ts},
 		Namespaces: []*corev1.Namespace{{
@@ -203,6 +226,14 @@ func TestPipelinRunCheckTimeouts(t *testing.T) {
 		name:           "pr-cancel",
 		pr:             prCancelled,
 		expectCallback: false,
+	}, {
+		name:           "pr-paused",
+		pr:             prPaused,
+		expectCallback: false,
+	}, {
+		name:           "pr-resumed",
+		pr:             prResumed,
+		expectCallback: false,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			if err := wait.PollImmediate(1*time.Second, 5*time.Second, func() (bool, error) {
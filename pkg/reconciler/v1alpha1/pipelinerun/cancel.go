@@ -31,25 +31,67 @@ import (
 
 // cancelPipelineRun makrs the PipelineRun as cancelled and any resolved taskrun too.
 func cancelPipelineRun(pr *v1alpha1.PipelineRun, pipelineState []*resources.ResolvedPipelineRunTask, clientSet clientset.Interface) error {
-	pr.Status.SetCondition(&apis.Condition{
+	pr.Status.SetCondition(getCancelledCondition(pr))
+	// update pr completed time
+	pr.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	return cancelTaskRuns(pr, pipelineState, clientSet)
+}
+
+// getCancelledCondition returns the Condition of a PipelineRun which was cancelled.
+func getCancelledCondition(pr *v1alpha1.PipelineRun) *apis.Condition {
+	return &apis.Condition{
 		Type:    apis.ConditionSucceeded,
 		Status:  corev1.ConditionFalse,
 		Reason:  "PipelineRunCancelled",
 		Message: fmt.Sprintf("PipelineRun %q was cancelled", pr.Name),
-	})
-	// update pr completed time
-	pr.Status.CompletionTime = &metav1.Time{Time: time.Now()}
-	errs := []string{}
+	}
+}
+
+// cancelTaskRuns marks the resolved taskruns, and the taskruns checking their conditions, as
+// cancelled if they haven't finished executing and haven't already been cancelled. The child
+// pipelineruns of the tasks running pipelines, and the runs of the custom tasks, are cancelled
+// the same way.
+func cancelTaskRuns(pr *v1alpha1.PipelineRun, pipelineState []*resources.ResolvedPipelineRunTask, clientSet clientset.Interface) error {
+	taskRuns := []*v1alpha1.TaskRun{}
+	pipelineRuns := []*v1alpha1.PipelineRun{}
+	runs := []*v1alpha1.Run{}
 	for _, rprt := range pipelineState {
-		if rprt.TaskRun == nil {
-			// No taskrun yet, pass
-			continue
+		if rprt.TaskRun != nil && !rprt.TaskRun.IsDone() && !rprt.TaskRun.IsCancelled() {
+			taskRuns = append(taskRuns, rprt.TaskRun)
+		}
+		if rprt.PipelineRun != nil && !rprt.PipelineRun.IsDone() && !rprt.PipelineRun.IsCancelled() {
+			pipelineRuns = append(pipelineRuns, rprt.PipelineRun)
 		}
-		rprt.TaskRun.Spec.Status = v1alpha1.TaskRunSpecStatusCancelled
-		if _, err := clientSet.TektonV1alpha1().TaskRuns(pr.Namespace).UpdateStatus(rprt.TaskRun); err != nil {
+		if rprt.Run != nil && !rprt.Run.IsDone() && !rprt.Run.IsCancelled() {
+			runs = append(runs, rprt.Run)
+		}
+		for _, rcc := range rprt.ResolvedConditionChecks {
+			if rcc.TaskRun != nil && !rcc.TaskRun.IsDone() && !rcc.TaskRun.IsCancelled() {
+				taskRuns = append(taskRuns, rcc.TaskRun)
+			}
+		}
+	}
+	errs := []string{}
+	for _, tr := range taskRuns {
+		tr.Spec.Status = v1alpha1.TaskRunSpecStatusCancelled
+		if _, err := clientSet.TektonV1alpha1().TaskRuns(pr.Namespace).UpdateStatus(tr); err != nil {
+			errs = append(errs, err.Error())
+		}
+		if _, err := clientSet.TektonV1alpha1().TaskRuns(pr.Namespace).Update(tr); err != nil {
 			errs = append(errs, err.Error())
 		}
-		if _, err := clientSet.TektonV1alpha1().TaskRuns(pr.Namespace).Update(rprt.TaskRun); err != nil {
+	}
+	for _, child := range pipelineRuns {
+		child = child.DeepCopy()
+		child.Spec.Status = v1alpha1.PipelineRunSpecStatusCancelled
+		if _, err := clientSet.TektonV1alpha1().PipelineRuns(pr.Namespace).Update(child); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	for _, run := range runs {
+		run = run.DeepCopy()
+		run.Spec.Status = v1alpha1.RunSpecStatusCancelled
+		if _, err := clientSet.TektonV1alpha1().Runs(pr.Namespace).Update(run); err != nil {
 			errs = append(errs, err.Error())
 		}
 	}
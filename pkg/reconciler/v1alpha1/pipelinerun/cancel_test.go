@@ -33,6 +33,7 @@ func TestCancelPipelineRun(t *testing.T) {
 		pipelineRun   *v1alpha1.PipelineRun
 		pipelineState []*resources.ResolvedPipelineRunTask
 		taskRuns      []*v1alpha1.TaskRun
+		runs          []*v1alpha1.Run
 	}{{
 		name: "no-resolved-taskrun",
 		pipelineRun: tb.PipelineRun("test-pipeline-run-cancelled", "foo",
@@ -64,6 +65,19 @@ func TestCancelPipelineRun(t *testing.T) {
 			{TaskRunName: "t2", TaskRun: tb.TaskRun("t2", "foo")},
 		},
 		taskRuns: []*v1alpha1.TaskRun{tb.TaskRun("t1", "foo"), tb.TaskRun("t2", "foo")},
+	}, {
+		name: "resolved-run",
+		pipelineRun: tb.PipelineRun("test-pipeline-run-cancelled", "foo",
+			tb.PipelineRunSpec("test-pipeline",
+				tb.PipelineRunCancelled,
+			),
+		),
+		pipelineState: []*resources.ResolvedPipelineRunTask{
+			{TaskRunName: "t1", TaskRun: tb.TaskRun("t1", "foo")},
+			{TaskRunName: "r1", Run: &v1alpha1.Run{ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "foo"}}},
+		},
+		taskRuns: []*v1alpha1.TaskRun{tb.TaskRun("t1", "foo")},
+		runs:     []*v1alpha1.Run{{ObjectMeta: metav1.ObjectMeta{Name: "r1", Namespace: "foo"}}},
 	}}
 	for _, tc := range testCases {
 		tc := tc
@@ -71,6 +85,7 @@ func TestCancelPipelineRun(t *testing.T) {
 			d := test.Data{
 				PipelineRuns: []*v1alpha1.PipelineRun{tc.pipelineRun},
 				TaskRuns:     tc.taskRuns,
+				Runs:         tc.runs,
 			}
 			c, _ := test.SeedTestData(d)
 			err := cancelPipelineRun(tc.pipelineRun, tc.pipelineState, c.Pipeline)
@@ -91,6 +106,15 @@ func TestCancelPipelineRun(t *testing.T) {
 					t.Errorf("expected task %q to be marked as cancelled, was %q", tr.Name, tr.Spec.Status)
 				}
 			}
+			runs, err := c.Pipeline.TektonV1alpha1().Runs("foo").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, r := range runs.Items {
+				if r.Spec.Status != v1alpha1.RunSpecStatusCancelled {
+					t.Errorf("expected run %q to be marked as cancelled, was %q", r.Name, r.Spec.Status)
+				}
+			}
 		})
 	}
 }
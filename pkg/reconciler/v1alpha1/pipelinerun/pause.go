@@ -0,0 +1,62 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// updatePausedTime records the time at which the PipelineRun was paused, and stops tracking
+// its timeout, which doesn't run while it is paused. Once the PipelineRun is resumed, the time
+// it spent paused is added to its paused duration and its timeout is tracked again from there.
+// A PipelineRun cancelled while it is paused isn't resumed, so its timeout isn't tracked again.
+func (c *Reconciler) updatePausedTime(pr *v1alpha1.PipelineRun) {
+	switch {
+	case pr.IsPaused() && pr.Status.PausedTime == nil:
+		c.Logger.Infof("PipelineRun %s was paused", pr.Name)
+		pr.Status.PausedTime = &metav1.Time{Time: time.Now()}
+		c.timeoutHandler.Release(pr)
+	case !pr.IsPaused() && pr.Status.PausedTime != nil:
+		paused := time.Since(pr.Status.PausedTime.Time)
+		if pr.Status.PausedDuration != nil {
+			paused += pr.Status.PausedDuration.Duration
+		}
+		pr.Status.PausedDuration = &metav1.Duration{Duration: paused}
+		pr.Status.PausedTime = nil
+		if !pr.IsCancelled() {
+			c.Logger.Infof("PipelineRun %s was resumed", pr.Name)
+			go c.timeoutHandler.WaitPipelineRun(pr)
+		}
+	}
+}
+
+// getPausedCondition returns the Condition of a PipelineRun which is paused before all of its
+// tasks have been run.
+func getPausedCondition(pr *v1alpha1.PipelineRun) *apis.Condition {
+	return &apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionUnknown,
+		Reason:  ReasonPaused,
+		Message: fmt.Sprintf("PipelineRun %q is paused", pr.Name),
+	}
+}
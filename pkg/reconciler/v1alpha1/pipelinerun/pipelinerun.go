@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/knative/pkg/apis"
@@ -36,6 +37,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/pipelinerun/config"
 	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/pipelinerun/resources"
 	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/volumeclaim"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -63,6 +65,24 @@ const (
 	// ReasonInvalidGraph indicates that the reason for the failure status is that the
 	// associated Pipeline is an invalid graph (a.k.a wrong order, cycle, …)
 	ReasonInvalidGraph = "PipelineInvalidGraph"
+	// ReasonInvalidTaskResultReference indicates that the reason for the failure status is
+	// that one of the Tasks uses a result which wasn't reported by the Task it references
+	ReasonInvalidTaskResultReference = "InvalidTaskResultReference"
+	// ReasonInvalidWorkspaceBindings indicates that the reason for the failure status is that the
+	// PipelineRun doesn't bind all the workspaces declared by the Pipeline, or binds other ones
+	ReasonInvalidWorkspaceBindings = "InvalidWorkspaceBindings"
+	// ReasonCouldntRerun indicates that the reason for the failure status is that the previous
+	// PipelineRun which the PipelineRun reruns couldn't be found or can't be rerun
+	ReasonCouldntRerun = "CouldntRerunPipelineRun"
+	// ReasonPendingApproval indicates that the reason for the inprogress status is that some of
+	// the next tasks are waiting for the approval of one of their approvers
+	ReasonPendingApproval = "PipelineRunPendingApproval"
+	// ReasonApprovalRejected indicates that the reason for the failure status is that one of the
+	// approvers rejected one of the tasks
+	ReasonApprovalRejected = "PipelineRunApprovalRejected"
+	// ReasonPaused indicates that the reason for the inprogress status is that the PipelineRun
+	// was paused, so no new tasks are started until it is resumed
+	ReasonPaused = "PipelineRunPaused"
 	// pipelineRunAgentName defines logging agent name for PipelineRun Controller
 	pipelineRunAgentName = "pipeline-controller"
 	// pipelineRunControllerName defines name for PipelineRun Controller
@@ -89,6 +109,7 @@ type Reconciler struct {
 	taskLister        listers.TaskLister
 	clusterTaskLister listers.ClusterTaskLister
 	resourceLister    listers.PipelineResourceLister
+	runLister         listers.RunLister
 	tracker           tracker.Interface
 	configStore       configStore
 	timeoutHandler    *reconciler.TimeoutSet
@@ -106,6 +127,7 @@ func NewController(
 	clusterTaskInformer informers.ClusterTaskInformer,
 	taskRunInformer informers.TaskRunInformer,
 	resourceInformer informers.PipelineResourceInformer,
+	runInformer informers.RunInformer,
 	timeoutHandler *reconciler.TimeoutSet,
 ) *controller.Impl {
 
@@ -117,6 +139,7 @@ func NewController(
 		clusterTaskLister: clusterTaskInformer.Lister(),
 		taskRunLister:     taskRunInformer.Lister(),
 		resourceLister:    resourceInformer.Lister(),
+		runLister:         runInformer.Lister(),
 		timeoutHandler:    timeoutHandler,
 	}
 
@@ -133,6 +156,14 @@ func NewController(
 	taskRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		UpdateFunc: controller.PassNew(impl.EnqueueControllerOf),
 	})
+	// The PipelineRuns running the Pipelines of PipelineTasks are owned by the PipelineRun running them
+	pipelineRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: controller.PassNew(impl.EnqueueControllerOf),
+	})
+	// The Runs of the custom tasks are updated by the controllers implementing them
+	runInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: controller.PassNew(impl.EnqueueControllerOf),
+	})
 
 	r.Logger.Info("Setting up ConfigMap receivers")
 	r.configStore = config.NewStore(r.Logger.Named("config-store"))
@@ -187,6 +218,8 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 			return err
 		}
 
+		c.updatePausedTime(pr)
+
 		// Reconcile this copy of the pipelinerun and then write back any status or label
 		// updates regardless of whether the reconciliation errored out.
 		if err = c.reconcile(ctx, pr); err != nil {
@@ -219,19 +252,26 @@ func (c *Reconciler) Reconcile(ctx context.Context, key string) error {
 }
 
 func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) error {
-	p, err := c.pipelineLister.Pipelines(pr.Namespace).Get(pr.Spec.PipelineRef.Name)
+	pipelineMeta, pipelineSpec, err := resources.GetPipelineData(pr, c.pipelineLister.Pipelines(pr.Namespace).Get)
 	if err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
+		message := fmt.Sprintf("Pipeline %s can't be found:%s",
+			fmt.Sprintf("%s/%s", pr.Namespace, pr.Spec.PipelineRef.Name), err)
+		if pr.Spec.PipelineRef.Name == "" {
+			// The PipelineRun doesn't reference a Pipeline, so its embedded spec couldn't be used
+			message = fmt.Sprintf("PipelineRun %s's embedded Pipeline can't be used: %s",
+				fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), err)
+		}
 		pr.Status.SetCondition(&apis.Condition{
-			Type:   apis.ConditionSucceeded,
-			Status: corev1.ConditionFalse,
-			Reason: ReasonCouldntGetPipeline,
-			Message: fmt.Sprintf("Pipeline %s can't be found:%s",
-				fmt.Sprintf("%s/%s", pr.Namespace, pr.Spec.PipelineRef.Name), err),
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonCouldntGetPipeline,
+			Message: message,
 		})
 		return nil
 	}
 
+	p := &v1alpha1.Pipeline{ObjectMeta: *pipelineMeta, Spec: *pipelineSpec}
 	p = p.DeepCopy()
 
 	d, err := v1alpha1.BuildDAG(p.Spec.Tasks)
@@ -254,7 +294,19 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 			Status: corev1.ConditionFalse,
 			Reason: ReasonInvalidBindings,
 			Message: fmt.Sprintf("PipelineRun %s doesn't bind Pipeline %s's PipelineResources correctly: %s",
-				fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), fmt.Sprintf("%s/%s", pr.Namespace, pr.Spec.PipelineRef.Name), err),
+				fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), fmt.Sprintf("%s/%s", p.Namespace, p.Name), err),
+		})
+		return nil
+	}
+
+	if err := resources.ValidateWorkspaceBindings(p, pr); err != nil {
+		// This Run has failed, so we need to mark it as failed and stop reconciling it
+		pr.Status.SetCondition(&apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: ReasonInvalidWorkspaceBindings,
+			Message: fmt.Sprintf("PipelineRun %s doesn't bind Pipeline %s's Workspaces correctly: %s",
+				fmt.Sprintf("%s/%s", pr.Namespace, pr.Name), fmt.Sprintf("%s/%s", p.Namespace, p.Name), err),
 		})
 		return nil
 	}
@@ -269,7 +321,24 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 	for key, value := range p.ObjectMeta.Labels {
 		pr.ObjectMeta.Labels[key] = value
 	}
-	pr.ObjectMeta.Labels[pipeline.GroupName+pipeline.PipelineLabelKey] = p.Name
+	if pr.Spec.PipelineRef.Name != "" {
+		pr.ObjectMeta.Labels[pipeline.GroupName+pipeline.PipelineLabelKey] = p.Name
+	}
+
+	// A rerun starts from the TaskRuns of the previous PipelineRun which can be reused, and
+	// shares its artifact storage, which is recorded in its labels the first time it's reconciled
+	if pr.Spec.RerunOf != nil && pr.ObjectMeta.Labels[pipeline.GroupName+pipeline.ArtifactStorageLabelKey] == "" {
+		if err := c.startRerun(pr, d); err != nil {
+			c.Logger.Errorf("PipelineRun %s can't rerun PipelineRun %s: %v", pr.Name, pr.Spec.RerunOf.Name, err)
+			pr.Status.SetCondition(&apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  ReasonCouldntRerun,
+				Message: fmt.Sprintf("PipelineRun %s can't rerun PipelineRun %s: %s", pr.Name, pr.Spec.RerunOf.Name, err),
+			})
+			return nil
+		}
+	}
 
 	pipelineState, err := resources.ResolvePipelineRun(
 		*pr,
@@ -279,8 +348,9 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 		func(name string) (v1alpha1.TaskInterface, error) {
 			return c.clusterTaskLister.Get(name)
 		},
+		c.pipelineLister.Pipelines(pr.Namespace).Get,
 		c.resourceLister.PipelineResources(pr.Namespace).Get,
-		p.Spec.Tasks, providedResources,
+		append(p.Spec.Tasks, p.Spec.Finally...), providedResources,
 	)
 	if err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
@@ -293,6 +363,14 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 				Message: fmt.Sprintf("Pipeline %s can't be Run; it contains Tasks that don't exist: %s",
 					fmt.Sprintf("%s/%s", p.Namespace, p.Name), err),
 			})
+		case *resources.PipelineNotFoundError:
+			pr.Status.SetCondition(&apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+				Reason: ReasonCouldntGetPipeline,
+				Message: fmt.Sprintf("Pipeline %s can't be Run; it contains Tasks running Pipelines that don't exist: %s",
+					fmt.Sprintf("%s/%s", p.Namespace, p.Name), err),
+			})
 		case *resources.ResourceNotFoundError:
 			pr.Status.SetCondition(&apis.Condition{
 				Type:   apis.ConditionSucceeded,
@@ -325,7 +403,15 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 	}
 
 	for _, rprt := range pipelineState {
+		// The child PipelineRuns validate the Pipelines they run themselves, and the custom tasks are
+		// validated by the controllers implementing them
+		if rprt.IsPipeline() || rprt.IsCustomTask() {
+			continue
+		}
 		err := taskrun.ValidateResolvedTaskResources(rprt.PipelineTask.Params, rprt.ResolvedTaskResources)
+		if err == nil {
+			err = taskrun.ValidateWorkspaces(rprt.ResolvedTaskResources.TaskSpec.Workspaces, resources.GetTaskRunWorkspaces(pr, rprt.PipelineTask))
+		}
 		if err != nil {
 			c.Logger.Errorf("Failed to validate pipelinerun %q with error %v", pr.Name, err)
 			pr.Status.SetCondition(&apis.Condition{
@@ -343,17 +429,63 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 	if err != nil {
 		return fmt.Errorf("Error getting TaskRuns for Pipeline %s: %s", p.Name, err)
 	}
+	err = resources.ResolveChildPipelineRuns(c.pipelineRunLister.PipelineRuns(pr.Namespace).Get, pipelineState)
+	if err != nil {
+		return fmt.Errorf("Error getting child PipelineRuns for Pipeline %s: %s", p.Name, err)
+	}
+	err = resources.ResolveRuns(c.runLister.Runs(pr.Namespace).Get, pipelineState)
+	if err != nil {
+		return fmt.Errorf("Error getting Runs for Pipeline %s: %s", p.Name, err)
+	}
+
+	// The finally tasks were resolved after the tasks of the DAG and are scheduled separately
+	allTasksState := pipelineState
+	pipelineState, finallyState := allTasksState.SplitFinally(p.Spec.Finally)
 
 	// If the pipelinerun is cancelled, cancel tasks and update status
+	var dagCondition *apis.Condition
 	if pr.IsCancelled() {
-		return cancelPipelineRun(pr, pipelineState, c.PipelineClientSet)
+		if len(finallyState) == 0 {
+			return cancelPipelineRun(pr, pipelineState, c.PipelineClientSet)
+		}
+		// The finally tasks still need to be run once the cancelled tasks have stopped
+		if err := cancelTaskRuns(pr, pipelineState, c.PipelineClientSet); err != nil {
+			return err
+		}
+		dagCondition = getCancelledCondition(pr)
+	} else if len(finallyState) > 0 {
+		// Once the PipelineRun has failed no more tasks are started, only the finally tasks
+		if cond := resources.GetPipelineConditionStatus(pr.Name, pipelineState, c.Logger, pr.TimeoutStartTime(), pr.Spec.Timeout, p.Spec.FailurePolicy); cond.IsFalse() {
+			dagCondition = cond
+		}
 	}
 
-	candidateTasks, err := dag.GetSchedulable(d, pipelineState.SuccessfulPipelineTaskNames()...)
-	if err != nil {
-		c.Logger.Errorf("Error getting potential next tasks for valid pipelinerun %s: %v", pr.Name, err)
+	rprts := []*resources.ResolvedPipelineRunTask{}
+	approvals := resources.ApprovalState{}
+	if dagCondition == nil {
+		// The tasks which run after a failed task are skipped, while the independent ones are still run
+		if p.Spec.FailurePolicy == v1alpha1.FailurePolicyRunAllIndependent {
+			pipelineState.MarkTasksAfterFailures(d)
+		}
+		pipelineState.MarkSkippedTasks(d)
+
+		candidateTasks, err := dag.GetSchedulable(d, pipelineState.SuccessfulPipelineTaskNames()...)
+		if err != nil {
+			c.Logger.Errorf("Error getting potential next tasks for valid pipelinerun %s: %v", pr.Name, err)
+		}
+		// The tasks gated behind an approval are only started once they have been approved
+		rprts, approvals = resources.ResolveApprovals(pipelineState.GetNextTasks(candidateTasks), pr.Spec.Approvals)
+		if approvals.Rejected != nil {
+			c.Logger.Infof("PipelineTask %s of pipelinerun %s was rejected by %s", approvals.Rejected.PipelineTask, pr.Name, approvals.Rejected.Approver)
+			dagCondition = getApprovalRejectedCondition(approvals.Rejected)
+			rprts = nil
+		}
+	}
+	pr.Status.PendingApprovals = approvals.Pending
+	// While the PipelineRun is paused, its running tasks are left to finish but no new ones are started
+	if pr.IsPaused() {
+		rprts = nil
 	}
-	rprts := pipelineState.GetNextTasks(candidateTasks)
 
 	var as artifacts.ArtifactStorageInterface
 	if as, err = artifacts.InitializeArtifactStorage(pr, c.KubeClientSet, c.Logger); err != nil {
@@ -361,10 +493,42 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 		return err
 	}
 
+	// The claims of the workspaces are shared by all of the TaskRuns of the PipelineRun
+	if err := volumeclaim.CreatePersistentVolumeClaimsForWorkspaces(c.KubeClientSet, pr.Spec.Workspaces, pr.GetOwnerReference()[0], pr.Namespace); err != nil {
+		c.Logger.Errorf("Failed to create PVCs for workspaces of pipelinerun %q: %v", pr.Name, err)
+		return err
+	}
+
+	// The results used by the next tasks were reported by the tasks they run after
+	if err := applyTaskResults(rprts, pipelineState); err != nil {
+		c.Logger.Errorf("Failed to resolve the task results used by pipelinerun %q: %v", pr.Name, err)
+		pr.Status.SetCondition(getInvalidTaskResultReferenceCondition(err))
+		return nil
+	}
+
 	for _, rprt := range rprts {
 		if rprt != nil {
+			if !rprt.ResolvedConditionChecks.IsSuccess() {
+				// The conditions guarding this task haven't all been met yet
+				if err := c.createConditionChecks(rprt, pr); err != nil {
+					return err
+				}
+				continue
+			}
+			if rprt.IsPipeline() {
+				if err := c.createChildPipelineRun(rprt, pr, getPipelineTaskTimeout(pr, rprt.PipelineTask)); err != nil {
+					return err
+				}
+				continue
+			}
+			if rprt.IsCustomTask() {
+				if err := c.createRun(rprt, pr); err != nil {
+					return err
+				}
+				continue
+			}
 			c.Logger.Infof("Creating a new TaskRun object %s", rprt.TaskRunName)
-			rprt.TaskRun, err = c.createTaskRun(c.Logger, rprt, pr, as.StorageBasePath(pr))
+			rprt.TaskRun, err = c.createTaskRun(c.Logger, rprt, pr, as.StorageBasePath(pr), getPipelineTaskTimeout(pr, rprt.PipelineTask))
 			if err != nil {
 				c.Recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunCreationFailed", "Failed to create TaskRun %q: %v", rprt.TaskRunName, err)
 				return fmt.Errorf("error creating TaskRun called %s for PipelineTask %s from PipelineRun %s: %s", rprt.TaskRunName, rprt.PipelineTask.Name, pr.Name, err)
@@ -373,30 +537,282 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1alpha1.PipelineRun) er
 	}
 	before := pr.Status.GetCondition(apis.ConditionSucceeded)
 	c.timeoutHandler.StatusLock(pr)
-	after := resources.GetPipelineConditionStatus(pr.Name, pipelineState, c.Logger, pr.Status.StartTime, pr.Spec.Timeout)
+	if dagCondition == nil {
+		dagCondition = resources.GetPipelineConditionStatus(pr.Name, pipelineState, c.Logger, pr.TimeoutStartTime(), pr.Spec.Timeout, p.Spec.FailurePolicy)
+		if dagCondition.IsUnknown() && len(approvals.Pending) > 0 {
+			dagCondition = getPendingApprovalCondition(approvals.Pending)
+		}
+	}
+	after := dagCondition
+	if len(finallyState) > 0 {
+		finallyTasks := finallyState.GetNextFinallyTasks(dagCondition, pipelineState)
+		if pr.IsPaused() {
+			finallyTasks = nil
+		}
+		for _, rprt := range finallyTasks {
+			resources.ApplyFinallyTaskResults(rprt, pipelineState)
+		}
+		if err := c.createFinallyTaskRuns(finallyTasks, pr, as); err != nil {
+			c.timeoutHandler.StatusUnlock(pr)
+			return err
+		}
+		after = finallyState.GetFinallyConditionStatus(pr.Name, dagCondition, pipelineState, c.Logger)
+	}
+	if pr.IsPaused() && after.IsUnknown() {
+		after = getPausedCondition(pr)
+	}
 	pr.Status.SetCondition(after)
 	c.timeoutHandler.StatusUnlock(pr)
 	reconciler.EmitEvent(c.Recorder, before, after, pr)
 
-	updateTaskRunsStatus(pr, pipelineState)
+	updateTaskRunsStatus(pr, allTasksState)
+	if !after.IsUnknown() {
+		pr.Status.PipelineResults = resources.GetPipelineResults(p.Spec.Results, allTasksState)
+	}
 
 	c.Logger.Infof("PipelineRun %s status is being set to %s", pr.Name, pr.Status.GetCondition(apis.ConditionSucceeded))
 	return nil
 }
 
-func updateTaskRunsStatus(pr *v1alpha1.PipelineRun, pipelineState []*resources.ResolvedPipelineRunTask) {
+// createFinallyTaskRuns creates the TaskRuns for the finally tasks in finallyTasks, which
+// are started once the other tasks have stopped executing.
+func (c *Reconciler) createFinallyTaskRuns(finallyTasks []*resources.ResolvedPipelineRunTask, pr *v1alpha1.PipelineRun, as artifacts.ArtifactStorageInterface) error {
+	var err error
+	for _, rprt := range finallyTasks {
+		if rprt.IsPipeline() {
+			if err := c.createChildPipelineRun(rprt, pr, getFinallyTaskTimeout(pr, rprt.PipelineTask)); err != nil {
+				return err
+			}
+			continue
+		}
+		if rprt.IsCustomTask() {
+			if err := c.createRun(rprt, pr); err != nil {
+				return err
+			}
+			continue
+		}
+		c.Logger.Infof("Creating a new TaskRun object %s for finally task %s", rprt.TaskRunName, rprt.PipelineTask.Name)
+		rprt.TaskRun, err = c.createTaskRun(c.Logger, rprt, pr, as.StorageBasePath(pr), getFinallyTaskTimeout(pr, rprt.PipelineTask))
+		if err != nil {
+			c.Recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunCreationFailed", "Failed to create TaskRun %q: %v", rprt.TaskRunName, err)
+			return fmt.Errorf("error creating TaskRun called %s for finally PipelineTask %s from PipelineRun %s: %s", rprt.TaskRunName, rprt.PipelineTask.Name, pr.Name, err)
+		}
+	}
+	return nil
+}
+
+// startRerun adds the status of the TaskRuns, child PipelineRuns and Runs of the PipelineRun
+// rerun by pr which can be reused to the status of pr, and makes pr use the same artifact storage.
+// pr becomes an owner of the reused runs, so that they aren't garbage collected with the previous
+// PipelineRun.
+func (c *Reconciler) startRerun(pr *v1alpha1.PipelineRun, d *v1alpha1.DAG) error {
+	previous, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(pr.Spec.RerunOf.Name)
+	if err != nil {
+		return err
+	}
+	if err := resources.ValidateRerun(previous, pr); err != nil {
+		return err
+	}
+	reused := resources.GetReusedRuns(previous, d)
+	if pr.Status.TaskRuns == nil {
+		pr.Status.TaskRuns = make(map[string]*v1alpha1.PipelineRunTaskRunStatus)
+	}
+	for name, status := range reused.TaskRuns {
+		tr, err := c.taskRunLister.TaskRuns(pr.Namespace).Get(name)
+		if err != nil {
+			return err
+		}
+		if refs, ok := addOwnerReference(tr.OwnerReferences, pr); ok {
+			tr = tr.DeepCopy()
+			tr.OwnerReferences = refs
+			if _, err := c.PipelineClientSet.TektonV1alpha1().TaskRuns(pr.Namespace).Update(tr); err != nil {
+				return err
+			}
+		}
+		c.Logger.Infof("PipelineRun %s reuses TaskRun %s of PipelineTask %s", pr.Name, name, status.PipelineTaskName)
+		pr.Status.TaskRuns[name] = status
+	}
+	if len(reused.ChildPipelineRuns) > 0 && pr.Status.ChildPipelineRuns == nil {
+		pr.Status.ChildPipelineRuns = make(map[string]*v1alpha1.PipelineRunChildStatus)
+	}
+	for name, status := range reused.ChildPipelineRuns {
+		child, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(name)
+		if err != nil {
+			return err
+		}
+		if refs, ok := addOwnerReference(child.OwnerReferences, pr); ok {
+			child = child.DeepCopy()
+			child.OwnerReferences = refs
+			if _, err := c.PipelineClientSet.TektonV1alpha1().PipelineRuns(pr.Namespace).Update(child); err != nil {
+				return err
+			}
+		}
+		c.Logger.Infof("PipelineRun %s reuses PipelineRun %s of PipelineTask %s", pr.Name, name, status.PipelineTaskName)
+		pr.Status.ChildPipelineRuns[name] = status
+	}
+	if len(reused.Runs) > 0 && pr.Status.Runs == nil {
+		pr.Status.Runs = make(map[string]*v1alpha1.PipelineRunRunStatus)
+	}
+	for name, status := range reused.Runs {
+		run, err := c.runLister.Runs(pr.Namespace).Get(name)
+		if err != nil {
+			return err
+		}
+		if refs, ok := addOwnerReference(run.OwnerReferences, pr); ok {
+			run = run.DeepCopy()
+			run.OwnerReferences = refs
+			if _, err := c.PipelineClientSet.TektonV1alpha1().Runs(pr.Namespace).Update(run); err != nil {
+				return err
+			}
+		}
+		c.Logger.Infof("PipelineRun %s reuses Run %s of PipelineTask %s", pr.Name, name, status.PipelineTaskName)
+		pr.Status.Runs[name] = status
+	}
+	pr.ObjectMeta.Labels[pipeline.GroupName+pipeline.ArtifactStorageLabelKey] = previous.GetArtifactStorageName()
+	return nil
+}
+
+// addOwnerReference returns refs with an owner reference to pr, and whether it had to be added.
+// The reference isn't a controller one, since the previous PipelineRun still controls the run.
+func addOwnerReference(refs []metav1.OwnerReference, pr *v1alpha1.PipelineRun) ([]metav1.OwnerReference, bool) {
+	for _, ref := range refs {
+		if ref.UID == pr.UID {
+			return refs, false
+		}
+	}
+	ref := pr.GetOwnerReference()[0]
+	ref.Controller = nil
+	ref.BlockOwnerDeletion = nil
+	return append(append([]metav1.OwnerReference{}, refs...), ref), true
+}
+
+// applyTaskResults replaces the references to task results in the params of each of rprts
+// with the values reported by the TaskRuns in pipelineState.
+func applyTaskResults(rprts []*resources.ResolvedPipelineRunTask, pipelineState resources.PipelineRunState) error {
+	for _, rprt := range rprts {
+		if rprt == nil {
+			continue
+		}
+		if err := resources.ApplyTaskResults(rprt, pipelineState); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getInvalidTaskResultReferenceCondition(err error) *apis.Condition {
+	return &apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionFalse,
+		Reason:  ReasonInvalidTaskResultReference,
+		Message: err.Error(),
+	}
+}
+
+func getPendingApprovalCondition(pending []v1alpha1.PipelineRunPendingApproval) *apis.Condition {
+	tasks := []string{}
+	for _, p := range pending {
+		tasks = append(tasks, p.PipelineTask)
+	}
+	return &apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionUnknown,
+		Reason:  ReasonPendingApproval,
+		Message: fmt.Sprintf("Waiting for the approval of PipelineTasks %s", strings.Join(tasks, ", ")),
+	}
+}
+
+func getApprovalRejectedCondition(rejected *v1alpha1.PipelineRunApproval) *apis.Condition {
+	return &apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionFalse,
+		Reason:  ReasonApprovalRejected,
+		Message: fmt.Sprintf("PipelineTask %s was rejected by %s", rejected.PipelineTask, rejected.Approver),
+	}
+}
+
+func updateTaskRunsStatus(pr *v1alpha1.PipelineRun, pipelineState resources.PipelineRunState) {
 	for _, rprt := range pipelineState {
-		if rprt.TaskRun != nil {
-			prtrs := pr.Status.TaskRuns[rprt.TaskRun.Name]
-			if prtrs == nil {
-				prtrs = &v1alpha1.PipelineRunTaskRunStatus{
-					PipelineTaskName: rprt.PipelineTask.Name,
-				}
-				pr.Status.TaskRuns[rprt.TaskRun.Name] = prtrs
+		if !rprt.IsStarted() && !rprt.ResolvedConditionChecks.HasStarted() {
+			continue
+		}
+		if rprt.IsPipeline() {
+			updateChildPipelineRunStatus(pr, rprt)
+			continue
+		}
+		if rprt.IsCustomTask() {
+			updateRunStatus(pr, rprt)
+			continue
+		}
+		prtrs := pr.Status.TaskRuns[rprt.TaskRunName]
+		if prtrs == nil {
+			prtrs = &v1alpha1.PipelineRunTaskRunStatus{
+				PipelineTaskName: rprt.PipelineTask.Name,
 			}
+			pr.Status.TaskRuns[rprt.TaskRunName] = prtrs
+		}
+		if rprt.TaskRun != nil {
 			prtrs.Status = &rprt.TaskRun.Status
 		}
+		prtrs.ConditionChecks = getConditionChecksStatus(prtrs.ConditionChecks, rprt)
+	}
+	pr.Status.SkippedTasks = pipelineState.GetSkippedTasks()
+}
+
+// updateChildPipelineRunStatus sets the status of the child PipelineRun of rprt, which runs a
+// Pipeline, in the status of pr.
+func updateChildPipelineRunStatus(pr *v1alpha1.PipelineRun, rprt *resources.ResolvedPipelineRunTask) {
+	if pr.Status.ChildPipelineRuns == nil {
+		pr.Status.ChildPipelineRuns = make(map[string]*v1alpha1.PipelineRunChildStatus)
+	}
+	prcs := pr.Status.ChildPipelineRuns[rprt.TaskRunName]
+	if prcs == nil {
+		prcs = &v1alpha1.PipelineRunChildStatus{
+			PipelineTaskName: rprt.PipelineTask.Name,
+		}
+		pr.Status.ChildPipelineRuns[rprt.TaskRunName] = prcs
+	}
+	if rprt.PipelineRun != nil {
+		prcs.Status = &rprt.PipelineRun.Status
+	}
+	prcs.ConditionChecks = getConditionChecksStatus(prcs.ConditionChecks, rprt)
+}
+
+// updateRunStatus sets the status of the Run of rprt, which runs a custom task, in the status
+// of pr.
+func updateRunStatus(pr *v1alpha1.PipelineRun, rprt *resources.ResolvedPipelineRunTask) {
+	if pr.Status.Runs == nil {
+		pr.Status.Runs = make(map[string]*v1alpha1.PipelineRunRunStatus)
+	}
+	prrs := pr.Status.Runs[rprt.TaskRunName]
+	if prrs == nil {
+		prrs = &v1alpha1.PipelineRunRunStatus{
+			PipelineTaskName: rprt.PipelineTask.Name,
+		}
+		pr.Status.Runs[rprt.TaskRunName] = prrs
+	}
+	if rprt.Run != nil {
+		prrs.Status = &rprt.Run.Status
+	}
+	prrs.ConditionChecks = getConditionChecksStatus(prrs.ConditionChecks, rprt)
+}
+
+// getConditionChecksStatus adds the status of each of the condition checks of rprt which were
+// started to checks.
+func getConditionChecksStatus(checks map[string]*v1alpha1.PipelineRunConditionCheckStatus, rprt *resources.ResolvedPipelineRunTask) map[string]*v1alpha1.PipelineRunConditionCheckStatus {
+	for _, rcc := range rprt.ResolvedConditionChecks {
+		if rcc.TaskRun == nil {
+			continue
+		}
+		if checks == nil {
+			checks = make(map[string]*v1alpha1.PipelineRunConditionCheckStatus)
+		}
+		checks[rcc.ConditionCheckName] = &v1alpha1.PipelineRunConditionCheckStatus{
+			ConditionName: rcc.Condition.Name,
+			Status:        &rcc.TaskRun.Status,
+		}
 	}
+	return checks
 }
 
 func (c *Reconciler) updateTaskRunsStatusDirectly(pr *v1alpha1.PipelineRun) error {
@@ -411,42 +827,65 @@ func (c *Reconciler) updateTaskRunsStatusDirectly(pr *v1alpha1.PipelineRun) erro
 		} else {
 			prtrs.Status = &tr.Status
 		}
+		if err := c.updateConditionChecksStatusDirectly(pr, prtrs.ConditionChecks); err != nil {
+			return err
+		}
+	}
+	for childName := range pr.Status.ChildPipelineRuns {
+		prcs := pr.Status.ChildPipelineRuns[childName]
+		child, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(childName)
+		if err != nil {
+			// If the PipelineRun isn't found, it just means it won't be run
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving child PipelineRun %s: %s", childName, err)
+			}
+		} else {
+			prcs.Status = &child.Status
+		}
+		if err := c.updateConditionChecksStatusDirectly(pr, prcs.ConditionChecks); err != nil {
+			return err
+		}
+	}
+	for runName := range pr.Status.Runs {
+		prrs := pr.Status.Runs[runName]
+		run, err := c.runLister.Runs(pr.Namespace).Get(runName)
+		if err != nil {
+			// If the Run isn't found, it just means it won't be run
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving Run %s: %s", runName, err)
+			}
+		} else {
+			prrs.Status = &run.Status
+		}
+		if err := c.updateConditionChecksStatusDirectly(pr, prrs.ConditionChecks); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func (c *Reconciler) createTaskRun(logger *zap.SugaredLogger, rprt *resources.ResolvedPipelineRunTask, pr *v1alpha1.PipelineRun, storageBasePath string) (*v1alpha1.TaskRun, error) {
-	var taskRunTimeout = &metav1.Duration{Duration: 0 * time.Second}
-	if pr.Spec.Timeout != nil {
-		pTimeoutTime := pr.Status.StartTime.Add(pr.Spec.Timeout.Duration)
-		if time.Now().After(pTimeoutTime) {
-			// Just in case something goes awry and we're creating the TaskRun after it should have already timed out,
-			// set a timeout of 0.
-			taskRunTimeout := pTimeoutTime.Sub(time.Now())
-			if taskRunTimeout < 0 {
-				taskRunTimeout = 0
+func (c *Reconciler) updateConditionChecksStatusDirectly(pr *v1alpha1.PipelineRun, checks map[string]*v1alpha1.PipelineRunConditionCheckStatus) error {
+	for checkName, check := range checks {
+		tr, err := c.taskRunLister.TaskRuns(pr.Namespace).Get(checkName)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving condition check TaskRun %s: %s", checkName, err)
 			}
 		} else {
-			taskRunTimeout = pr.Spec.Timeout
+			check.Status = &tr.Status
 		}
-	} else {
-		taskRunTimeout = nil
-	}
-
-	// Propagate labels from PipelineRun to TaskRun.
-	labels := make(map[string]string, len(pr.ObjectMeta.Labels)+1)
-	for key, val := range pr.ObjectMeta.Labels {
-		labels[key] = val
 	}
-	labels[pipeline.GroupName+pipeline.PipelineRunLabelKey] = pr.Name
+	return nil
+}
 
+func (c *Reconciler) createTaskRun(logger *zap.SugaredLogger, rprt *resources.ResolvedPipelineRunTask, pr *v1alpha1.PipelineRun, storageBasePath string, timeout *metav1.Duration) (*v1alpha1.TaskRun, error) {
 	tr := &v1alpha1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            rprt.TaskRunName,
 			Namespace:       pr.Namespace,
 			OwnerReferences: pr.GetOwnerReference(),
-			Labels:          labels,
+			Labels:          getPipelineTaskRunLabels(pr, rprt.PipelineTask.Name),
 		},
 		Spec: v1alpha1.TaskRunSpec{
 			TaskRef: &v1alpha1.TaskRef{
@@ -455,18 +894,202 @@ func (c *Reconciler) createTaskRun(logger *zap.SugaredLogger, rprt *resources.Re
 			Inputs: v1alpha1.TaskRunInputs{
 				Params: rprt.PipelineTask.Params,
 			},
-			ServiceAccount: pr.Spec.ServiceAccount,
-			Timeout:        taskRunTimeout,
+			ServiceAccount: pr.GetServiceAccount(rprt.PipelineTask.Name),
+			Timeout:        timeout,
+			Retries:        rprt.PipelineTask.Retries,
 			NodeSelector:   pr.Spec.NodeSelector,
 			Tolerations:    pr.Spec.Tolerations,
 			Affinity:       pr.Spec.Affinity,
+			PodTemplate:    pr.Spec.PodTemplate,
+			Workspaces:     resources.GetTaskRunWorkspaces(pr, rprt.PipelineTask),
 		}}
+	if rprt.PipelineTask.TaskSpec != nil {
+		tr.Spec.TaskRef = nil
+		tr.Spec.TaskSpec = rprt.PipelineTask.TaskSpec
+	}
 
-	resources.WrapSteps(&tr.Spec, rprt.PipelineTask, rprt.ResolvedTaskResources.Inputs, rprt.ResolvedTaskResources.Outputs, storageBasePath)
+	resources.WrapSteps(&tr.Spec, rprt.PipelineTask, rprt.ResolvedTaskResources.Inputs, rprt.ResolvedTaskResources.Outputs, pr.Spec.Resources, storageBasePath)
 
 	return c.PipelineClientSet.TektonV1alpha1().TaskRuns(pr.Namespace).Create(tr)
 }
 
+// createChildPipelineRun creates the child PipelineRun of rprt, which runs a Pipeline, with the
+// params of rprt and the PipelineResources and workspaces it binds from pr.
+func (c *Reconciler) createChildPipelineRun(rprt *resources.ResolvedPipelineRunTask, pr *v1alpha1.PipelineRun, timeout *metav1.Duration) error {
+	c.Logger.Infof("Creating a new child PipelineRun object %s", rprt.TaskRunName)
+	child := &v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rprt.TaskRunName,
+			Namespace:       pr.Namespace,
+			OwnerReferences: pr.GetOwnerReference(),
+			Labels:          getChildRunLabels(pr),
+			Annotations: map[string]string{
+				pipeline.GroupName + pipeline.AncestorPipelinesAnnotationKey: strings.Join(resources.GetAncestorPipelines(pr), ","),
+			},
+		},
+		Spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: v1alpha1.PipelineRef{
+				Name: rprt.ResolvedTaskResources.TaskName,
+			},
+			Resources:      resources.GetChildPipelineRunResources(rprt, pr.Spec.Resources),
+			Params:         rprt.PipelineTask.Params,
+			ServiceAccount: pr.GetServiceAccount(rprt.PipelineTask.Name),
+			Timeout:        timeout,
+			NodeSelector:   pr.Spec.NodeSelector,
+			Tolerations:    pr.Spec.Tolerations,
+			Affinity:       pr.Spec.Affinity,
+			PodTemplate:    pr.Spec.PodTemplate,
+			Workspaces:     resources.GetTaskRunWorkspaces(pr, rprt.PipelineTask),
+		}}
+	var err error
+	rprt.PipelineRun, err = c.PipelineClientSet.TektonV1alpha1().PipelineRuns(pr.Namespace).Create(child)
+	if err != nil {
+		c.Recorder.Eventf(pr, corev1.EventTypeWarning, "PipelineRunCreationFailed", "Failed to create child PipelineRun %q: %v", rprt.TaskRunName, err)
+		return fmt.Errorf("error creating child PipelineRun called %s for PipelineTask %s from PipelineRun %s: %s", rprt.TaskRunName, rprt.PipelineTask.Name, pr.Name, err)
+	}
+	return nil
+}
+
+// createRun creates the Run of rprt, which runs a custom task, with the params of rprt. The
+// Run is then executed by the controller implementing the kind of the custom task.
+func (c *Reconciler) createRun(rprt *resources.ResolvedPipelineRunTask, pr *v1alpha1.PipelineRun) error {
+	c.Logger.Infof("Creating a new Run object %s", rprt.TaskRunName)
+	ref := rprt.PipelineTask.TaskRef
+	run := &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rprt.TaskRunName,
+			Namespace:       pr.Namespace,
+			OwnerReferences: pr.GetOwnerReference(),
+			Labels:          getChildRunLabels(pr),
+		},
+		Spec: v1alpha1.RunSpec{
+			Ref:            &ref,
+			Params:         rprt.PipelineTask.Params,
+			ServiceAccount: pr.GetServiceAccount(rprt.PipelineTask.Name),
+		}}
+	var err error
+	rprt.Run, err = c.PipelineClientSet.TektonV1alpha1().Runs(pr.Namespace).Create(run)
+	if err != nil {
+		c.Recorder.Eventf(pr, corev1.EventTypeWarning, "RunCreationFailed", "Failed to create Run %q: %v", rprt.TaskRunName, err)
+		return fmt.Errorf("error creating Run called %s for PipelineTask %s from PipelineRun %s: %s", rprt.TaskRunName, rprt.PipelineTask.Name, pr.Name, err)
+	}
+	return nil
+}
+
+// createConditionChecks creates a TaskRun for each of the conditions guarding rprt
+// which runs a check container and hasn't been started yet.
+func (c *Reconciler) createConditionChecks(rprt *resources.ResolvedPipelineRunTask, pr *v1alpha1.PipelineRun) error {
+	for _, rcc := range rprt.ResolvedConditionChecks {
+		if rcc.Condition.Check == nil || rcc.TaskRun != nil {
+			continue
+		}
+		c.Logger.Infof("Creating a new condition check TaskRun object %s", rcc.ConditionCheckName)
+		tr := &v1alpha1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            rcc.ConditionCheckName,
+				Namespace:       pr.Namespace,
+				OwnerReferences: pr.GetOwnerReference(),
+				Labels:          getTaskRunLabels(pr),
+			},
+			Spec: v1alpha1.TaskRunSpec{
+				TaskSpec: &v1alpha1.TaskSpec{
+					Steps: []v1alpha1.Step{{Container: *rcc.Condition.Check}},
+				},
+				ServiceAccount: pr.GetServiceAccount(rprt.PipelineTask.Name),
+				Timeout:        getPipelineTaskTimeout(pr, rprt.PipelineTask),
+				NodeSelector:   pr.Spec.NodeSelector,
+				Tolerations:    pr.Spec.Tolerations,
+				Affinity:       pr.Spec.Affinity,
+				PodTemplate:    pr.Spec.PodTemplate,
+			}}
+		var err error
+		rcc.TaskRun, err = c.PipelineClientSet.TektonV1alpha1().TaskRuns(pr.Namespace).Create(tr)
+		if err != nil {
+			c.Recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunCreationFailed", "Failed to create condition check TaskRun %q: %v", rcc.ConditionCheckName, err)
+			return fmt.Errorf("error creating condition check TaskRun called %s for PipelineTask %s from PipelineRun %s: %s", rcc.ConditionCheckName, rprt.PipelineTask.Name, pr.Name, err)
+		}
+	}
+	return nil
+}
+
+// getTaskRunLabels returns the labels to propagate from the PipelineRun to its TaskRuns.
+func getTaskRunLabels(pr *v1alpha1.PipelineRun) map[string]string {
+	labels := make(map[string]string, len(pr.ObjectMeta.Labels)+1)
+	for key, val := range pr.ObjectMeta.Labels {
+		labels[key] = val
+	}
+	labels[pipeline.GroupName+pipeline.PipelineRunLabelKey] = pr.Name
+	return labels
+}
+
+// getPipelineTaskRunLabels returns the labels of the TaskRuns created for the PipelineTask
+// named pipelineTaskName, which are the labels of the other TaskRuns and the PipelineTask name.
+func getPipelineTaskRunLabels(pr *v1alpha1.PipelineRun, pipelineTaskName string) map[string]string {
+	labels := getTaskRunLabels(pr)
+	labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey] = pipelineTaskName
+	return labels
+}
+
+// getChildRunLabels returns the labels of the child PipelineRuns and the Runs created for pr,
+// which are the labels of its TaskRuns without the artifact storage: a child PipelineRun
+// initializes its own, and a Run doesn't use any.
+func getChildRunLabels(pr *v1alpha1.PipelineRun) map[string]string {
+	labels := getTaskRunLabels(pr)
+	delete(labels, pipeline.GroupName+pipeline.ArtifactStorageLabelKey)
+	return labels
+}
+
+// getTaskRunTimeout returns the timeout to set on the TaskRuns created for the PipelineRun.
+func getTaskRunTimeout(pr *v1alpha1.PipelineRun) *metav1.Duration {
+	var taskRunTimeout = &metav1.Duration{Duration: 0 * time.Second}
+	if pr.Spec.Timeout != nil {
+		pTimeoutTime := pr.TimeoutStartTime().Add(pr.Spec.Timeout.Duration)
+		if time.Now().After(pTimeoutTime) {
+			// Just in case something goes awry and we're creating the TaskRun after it should have already timed out,
+			// set a timeout of 0.
+			taskRunTimeout := pTimeoutTime.Sub(time.Now())
+			if taskRunTimeout < 0 {
+				taskRunTimeout = 0
+			}
+		} else {
+			taskRunTimeout = pr.Spec.Timeout
+		}
+	} else {
+		taskRunTimeout = nil
+	}
+	return taskRunTimeout
+}
+
+// getPipelineTaskTimeout returns the timeout to set on the TaskRun, or child PipelineRun, created
+// for pt. This is the timeout of pt clipped to the time remaining before the PipelineRun times
+// out, or the timeout of the TaskRuns created for the PipelineRun if pt doesn't have one.
+func getPipelineTaskTimeout(pr *v1alpha1.PipelineRun, pt *v1alpha1.PipelineTask) *metav1.Duration {
+	if pt.Timeout == nil {
+		return getTaskRunTimeout(pr)
+	}
+	if pr.Spec.Timeout == nil {
+		return pt.Timeout
+	}
+	remaining := time.Until(pr.TimeoutStartTime().Add(pr.Spec.Timeout.Duration))
+	if remaining < 0 {
+		remaining = 0
+	}
+	if pt.Timeout.Duration > remaining {
+		return &metav1.Duration{Duration: remaining}
+	}
+	return pt.Timeout
+}
+
+// getFinallyTaskTimeout returns the timeout to set on the TaskRun, or child PipelineRun, created
+// for the finally task pt. Since the finally tasks run even once the PipelineRun has timed out,
+// this is the timeout of pt, or the whole timeout of the PipelineRun if pt doesn't have one.
+func getFinallyTaskTimeout(pr *v1alpha1.PipelineRun, pt *v1alpha1.PipelineTask) *metav1.Duration {
+	if pt.Timeout != nil {
+		return pt.Timeout
+	}
+	return pr.Spec.Timeout
+}
+
 func (c *Reconciler) updateStatus(pr *v1alpha1.PipelineRun) (*v1alpha1.PipelineRun, error) {
 	newPr, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(pr.Name)
 	if err != nil {
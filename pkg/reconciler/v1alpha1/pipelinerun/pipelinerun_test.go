@@ -15,6 +15,7 @@ package pipelinerun
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -37,6 +38,7 @@ import (
 	"go.uber.org/zap/zaptest/observer"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	ktesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
 )
@@ -70,6 +72,7 @@ func getPipelineRunController(d test.Data, recorder record.EventRecorder) test.T
 			i.ClusterTask,
 			i.TaskRun,
 			i.PipelineResource,
+			i.Run,
 			th,
 		),
 		Logs:      logs,
@@ -210,6 +213,7 @@ func TestReconcile(t *testing.T) {
 		),
 		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
 		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-success"),
+		tb.TaskRunLabel("tekton.dev/pipelineTask", "unit-test-1"),
 		tb.TaskRunSpec(
 			tb.TaskRunTaskRef("unit-test-task"),
 			tb.TaskRunServiceAccount("test-sa"),
@@ -264,6 +268,8 @@ func TestReconcile_InvalidPipelineRuns(t *testing.T) {
 		tb.Task("a-task-that-exists", "foo"),
 		tb.Task("a-task-that-needs-params", "foo", tb.TaskSpec(
 			tb.TaskInputs(tb.InputsParam("some-param")))),
+		tb.Task("a-task-that-needs-a-workspace", "foo", tb.TaskSpec(
+			tb.TaskWorkspace("source", "", "", false))),
 	}
 	ps := []*v1alpha1.Pipeline{
 		tb.Pipeline("pipeline-missing-tasks", "foo", tb.PipelineSpec(
@@ -278,6 +284,12 @@ func TestReconcile_InvalidPipelineRuns(t *testing.T) {
 		tb.Pipeline("a-pipeline-that-should-be-caught-by-admission-control", "foo", tb.PipelineSpec(
 			tb.PipelineTask("some-task", "a-task-that-exists",
 				tb.PipelineTaskInputResource("needed-resource", "a-resource")))),
+		tb.Pipeline("a-pipeline-with-a-workspace", "foo", tb.PipelineSpec(
+			tb.PipelineWorkspace("shared", ""),
+			tb.PipelineTask("some-task", "a-task-that-needs-a-workspace",
+				tb.PipelineTaskWorkspace("source", "shared")))),
+		tb.Pipeline("a-pipeline-with-an-unmapped-workspace", "foo", tb.PipelineSpec(
+			tb.PipelineTask("some-task", "a-task-that-needs-a-workspace"))),
 	}
 	prs := []*v1alpha1.PipelineRun{
 		tb.PipelineRun("invalid-pipeline", "foo", tb.PipelineRunSpec("pipeline-not-exist")),
@@ -287,6 +299,9 @@ func TestReconcile_InvalidPipelineRuns(t *testing.T) {
 		tb.PipelineRun("pipeline-resources-dont-exist", "foo", tb.PipelineRunSpec("a-fine-pipeline",
 			tb.PipelineRunResourceBinding("a-resource", tb.PipelineResourceBindingRef("missing-resource")))),
 		tb.PipelineRun("pipeline-resources-not-declared", "foo", tb.PipelineRunSpec("a-pipeline-that-should-be-caught-by-admission-control")),
+		tb.PipelineRun("pipeline-workspaces-not-bound", "foo", tb.PipelineRunSpec("a-pipeline-with-a-workspace")),
+		tb.PipelineRun("pipeline-task-workspaces-not-mapped", "foo", tb.PipelineRunSpec("a-pipeline-with-an-unmapped-workspace")),
+		tb.PipelineRun("embedded-pipeline-missing", "foo"),
 	}
 	d := test.Data{
 		Tasks:        ts,
@@ -297,11 +312,13 @@ func TestReconcile_InvalidPipelineRuns(t *testing.T) {
 		name        string
 		pipelineRun *v1alpha1.PipelineRun
 		reason      string
+		message     string
 	}{
 		{
 			name:        "invalid-pipeline-shd-be-stop-reconciling",
 			pipelineRun: prs[0],
 			reason:      ReasonCouldntGetPipeline,
+			message:     "Pipeline foo/pipeline-not-exist can't be found",
 		}, {
 			name:        "invalid-pipeline-run-missing-tasks-shd-stop-reconciling",
 			pipelineRun: prs[1],
@@ -322,6 +339,19 @@ func TestReconcile_InvalidPipelineRuns(t *testing.T) {
 			name:        "invalid-pipeline-missing-declared-resource-shd-stop-reconciling",
 			pipelineRun: prs[5],
 			reason:      ReasonFailedValidation,
+		}, {
+			name:        "invalid-pipeline-run-workspaces-not-bound-shd-stop-reconciling",
+			pipelineRun: prs[6],
+			reason:      ReasonInvalidWorkspaceBindings,
+		}, {
+			name:        "invalid-pipeline-task-workspaces-not-mapped-shd-stop-reconciling",
+			pipelineRun: prs[7],
+			reason:      ReasonFailedValidation,
+		}, {
+			name:        "invalid-embedded-pipeline-shd-stop-reconciling",
+			pipelineRun: prs[8],
+			reason:      ReasonCouldntGetPipeline,
+			message:     "PipelineRun foo/embedded-pipeline-missing's embedded Pipeline can't be used",
 		},
 	}
 
@@ -353,6 +383,9 @@ func TestReconcile_InvalidPipelineRuns(t *testing.T) {
 			if condition != nil && condition.Reason != tc.reason {
 				t.Errorf("Expected failure to be because of reason %q but was %s", tc.reason, condition.Reason)
 			}
+			if condition != nil && !strings.HasPrefix(condition.Message, tc.message) {
+				t.Errorf("Expected failure message to start with %q but was %q", tc.message, condition.Message)
+			}
 		})
 	}
 }
@@ -621,6 +654,173 @@ func TestReconcileOnCancelledPipelineRun(t *testing.T) {
 	}
 }
 
+func TestReconcileOnPausedPipelineRun(t *testing.T) {
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-paused", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunPaused,
+		),
+	)}
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-paused"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling paused PipelineRun but saw %s", err)
+	}
+
+	// No new TaskRuns are started while the PipelineRun is paused
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			t.Errorf("Expected no TaskRun to be created for paused PipelineRun but got %v", a.(ktesting.CreateAction).GetObject())
+		}
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-paused", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if reconciledRun.Status.PausedTime == nil {
+		t.Errorf("Expected the time at which the PipelineRun was paused to be set")
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsUnknown() || condition.Reason != ReasonPaused {
+		t.Errorf("Expected PipelineRun to be paused but condition was %v", condition)
+	}
+}
+
+func TestReconcileOnResumedPipelineRun(t *testing.T) {
+	// The PipelineRun was started a day ago and spent most of it paused, which doesn't
+	// count towards its timeout
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-resumed", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunTimeout(&metav1.Duration{Duration: 1 * time.Hour}),
+		),
+		tb.PipelineRunStatus(
+			tb.PipelineRunStartTime(time.Now().AddDate(0, 0, -1)),
+			tb.PipelineRunPausedDuration(12*time.Hour),
+			tb.PipelineRunPausedTime(time.Now().Add(-12*time.Hour)),
+		),
+	)}
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-resumed"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling resumed PipelineRun but saw %s", err)
+	}
+
+	// The PipelineRun picks up where it left off
+	created := 0
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			created++
+		}
+	}
+	if created != 1 {
+		t.Errorf("Expected a TaskRun to be created for resumed PipelineRun but %d were created", created)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-resumed", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if reconciledRun.Status.PausedTime != nil {
+		t.Errorf("Expected the PipelineRun not to be paused anymore but it was paused at %v", reconciledRun.Status.PausedTime)
+	}
+	if reconciledRun.Status.PausedDuration == nil || reconciledRun.Status.PausedDuration.Duration < 24*time.Hour {
+		t.Errorf("Expected the PipelineRun to have been paused for a day but got %v", reconciledRun.Status.PausedDuration)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsUnknown() || condition.Reason != resources.ReasonRunning {
+		t.Errorf("Expected PipelineRun to be running but condition was %v", condition)
+	}
+}
+
+func TestReconcileOnPausedPipelineRunCancelled(t *testing.T) {
+	// The PipelineRun is cancelled while it is paused, instead of being resumed
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-paused-cancelled", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunTimeout(&metav1.Duration{Duration: 1 * time.Hour}),
+			tb.PipelineRunCancelled,
+		),
+		tb.PipelineRunStatus(
+			tb.PipelineRunStartTime(time.Now().Add(-2*time.Hour)),
+			tb.PipelineRunPausedTime(time.Now().Add(-90*time.Minute)),
+		),
+	)}
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-paused-cancelled"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling cancelled PipelineRun but saw %s", err)
+	}
+
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			t.Errorf("Expected no TaskRun to be created for cancelled PipelineRun but got %v", a.(ktesting.CreateAction).GetObject())
+		}
+	}
+
+	// The time spent paused is still recorded
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-paused-cancelled", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if reconciledRun.Status.PausedTime != nil {
+		t.Errorf("Expected the PipelineRun not to be paused anymore but it was paused at %v", reconciledRun.Status.PausedTime)
+	}
+	if reconciledRun.Status.PausedDuration == nil || reconciledRun.Status.PausedDuration.Duration < 90*time.Minute {
+		t.Errorf("Expected the PipelineRun to have been paused for 90m but got %v", reconciledRun.Status.PausedDuration)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != "PipelineRunCancelled" {
+		t.Errorf("Expected PipelineRun to be cancelled but condition was %v", condition)
+	}
+}
+
 func TestReconcileWithTimeout(t *testing.T) {
 	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
 		tb.PipelineTask("hello-world-1", "hello-world"),
@@ -680,18 +880,21 @@ func TestReconcileWithTimeout(t *testing.T) {
 	}
 }
 
-func TestReconcilePropagateLabels(t *testing.T) {
+func TestReconcileWithFinallyTasksAfterTimeout(t *testing.T) {
 	names.TestingSeed()
 
 	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
 		tb.PipelineTask("hello-world-1", "hello-world"),
+		tb.PipelineFinallyTask("cleanup", "hello-world"),
+		tb.PipelineFinallyTask("notify", "hello-world", tb.PipelineTaskTimeout(10*time.Minute)),
 	))}
-	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-labels", "foo",
-		tb.PipelineRunLabel("PipelineRunLabel", "PipelineRunValue"),
-		tb.PipelineRunLabel("tekton.dev/pipeline", "WillNotBeUsed"),
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-finally-timeout", "foo",
 		tb.PipelineRunSpec("test-pipeline",
 			tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunTimeout(&metav1.Duration{Duration: 12 * time.Hour}),
 		),
+		tb.PipelineRunStatus(
+			tb.PipelineRunStartTime(time.Now().AddDate(0, 0, -1))),
 	)}
 	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
 
@@ -708,37 +911,2196 @@ func TestReconcilePropagateLabels(t *testing.T) {
 	c := testAssets.Controller
 	clients := testAssets.Clients
 
-	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-with-labels")
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-finally-timeout"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// The finally tasks still run once the PipelineRun has timed out, with their own timeout
+	timeouts := map[string]time.Duration{}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			tr := a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+			timeouts[tr.Labels["tekton.dev/pipelineTask"]] = tr.Spec.Timeout.Duration
+		}
+	}
+	expected := map[string]time.Duration{
+		"cleanup": 12 * time.Hour,
+		"notify":  10 * time.Minute,
+	}
+	if d := cmp.Diff(expected, timeouts); d != "" {
+		t.Errorf("Unexpected timeouts of the created TaskRuns -want, +got: %v", d)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-finally-timeout", metav1.GetOptions{})
 	if err != nil {
-		t.Errorf("Did not expect to see error when reconciling completed PipelineRun but saw %s", err)
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to keep running its finally tasks but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
 	}
+}
 
-	// Check that the PipelineRun was reconciled correctly
-	_, err = clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-with-labels", metav1.GetOptions{})
+func TestReconcileWithPipelineTaskTimeout(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world", tb.PipelineTaskTimeout(10*time.Minute)),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-task-timeout", "foo",
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunTimeout(&metav1.Duration{Duration: 1 * time.Hour}),
+		),
+		tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now())),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-with-task-timeout")
 	if err != nil {
-		t.Fatalf("Somehow had error getting completed reconciled run out of fake client: %s", err)
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
 	}
 
-	// Check that the expected TaskRun was created
+	// The TaskRun should be given the timeout of its PipelineTask
 	actual := clients.Pipeline.Actions()[0].(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
-	if actual == nil {
-		t.Errorf("Expected a TaskRun to be created, but it wasn't.")
+	if actual.Spec.Timeout == nil || actual.Spec.Timeout.Duration != 10*time.Minute {
+		t.Errorf("Expected the TaskRun timeout to be 10m but got %v", actual.Spec.Timeout)
 	}
-	expectedTaskRun := tb.TaskRun("test-pipeline-run-with-labels-hello-world-1-9l9zj", "foo",
-		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-with-labels",
-			tb.OwnerReferenceAPIVersion("tekton.dev/v1alpha1"),
-			tb.Controller, tb.BlockOwnerDeletion,
+}
+
+func TestReconcileWithPodTemplate(t *testing.T) {
+	template := &v1alpha1.PodTemplate{
+		SchedulerName:    "custom-scheduler",
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry-creds"}},
+	}
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-pod-template", "foo",
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunPodTemplate(template),
 		),
-		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
-		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-with-labels"),
-		tb.TaskRunLabel("PipelineRunLabel", "PipelineRunValue"),
-		tb.TaskRunSpec(
-			tb.TaskRunTaskRef("hello-world"),
-			tb.TaskRunServiceAccount("test-sa"),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-with-pod-template")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// The TaskRun should be given the pod template of the PipelineRun
+	actual := clients.Pipeline.Actions()[0].(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+	if d := cmp.Diff(template, actual.Spec.PodTemplate); d != "" {
+		t.Errorf("Unexpected TaskRun pod template -want, +got: %v", d)
+	}
+}
+
+func TestReconcileWithTaskServiceAccounts(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("unit-test", "hello-world"),
+		tb.PipelineTask("deploy", "hello-world"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-task-sa", "foo",
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunServiceAccountTask("deploy", "deploy-sa"),
 		),
-	)
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
 
-	if d := cmp.Diff(actual, expectedTaskRun); d != "" {
-		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, d)
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-with-task-sa")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// Only the TaskRun of deploy should be run with its own service account
+	expected := map[string]string{
+		"unit-test": "test-sa",
+		"deploy":    "deploy-sa",
+	}
+	actual := map[string]string{}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() != "create" {
+			continue
+		}
+		if tr, ok := a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun); ok {
+			for task := range expected {
+				if strings.HasPrefix(tr.Name, "test-pipeline-run-with-task-sa-"+task+"-") {
+					actual[task] = tr.Spec.ServiceAccount
+				}
+			}
+		}
+	}
+	if d := cmp.Diff(expected, actual); d != "" {
+		t.Errorf("Unexpected TaskRun service accounts -want, +got: %v", d)
+	}
+}
+
+func TestReconcileWithResourceSpec(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineDeclaredResource("git-repo", "git"),
+		tb.PipelineTask("unit-test-1", "unit-test-task",
+			tb.PipelineTaskInputResource("workspace", "git-repo"),
+		),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-resource-spec", "foo",
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunResourceBinding("git-repo", tb.PipelineResourceBindingResourceSpec(
+				v1alpha1.PipelineResourceTypeGit,
+				tb.PipelineResourceSpecParam("url", "https://github.com/kristoff/reindeer"),
+				tb.PipelineResourceSpecParam("revision", "master"),
+			)),
+		),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("unit-test-task", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsResource("workspace", v1alpha1.PipelineResourceTypeGit)),
+	))}
+
+	// No PipelineResource is created: the TaskRun is given the spec of the binding
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-with-resource-spec")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	actual := clients.Pipeline.Actions()[0].(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+	expected := []v1alpha1.TaskResourceBinding{{
+		Name: "workspace",
+		ResourceSpec: &v1alpha1.PipelineResourceSpec{
+			Type: v1alpha1.PipelineResourceTypeGit,
+			Params: []v1alpha1.ResourceParam{
+				{Name: "url", Value: "https://github.com/kristoff/reindeer"},
+				{Name: "revision", Value: "master"},
+			},
+		},
+	}}
+	if d := cmp.Diff(expected, actual.Spec.Inputs.Resources); d != "" {
+		t.Errorf("Unexpected TaskRun input resources -want, +got: %v", d)
+	}
+}
+
+func TestGetPipelineTaskTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		prTimeout   *metav1.Duration
+		ptTimeout   *metav1.Duration
+		startTime   time.Time
+		wantTimeout *metav1.Duration
+	}{{
+		name:        "pipelinerun timeout",
+		prTimeout:   &metav1.Duration{Duration: time.Hour},
+		startTime:   time.Now(),
+		wantTimeout: &metav1.Duration{Duration: time.Hour},
+	}, {
+		name:        "pipelinetask timeout",
+		prTimeout:   &metav1.Duration{Duration: time.Hour},
+		ptTimeout:   &metav1.Duration{Duration: 10 * time.Minute},
+		startTime:   time.Now(),
+		wantTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+	}, {
+		name:        "pipelinetask timeout without pipelinerun timeout",
+		ptTimeout:   &metav1.Duration{Duration: 10 * time.Minute},
+		startTime:   time.Now(),
+		wantTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+	}, {
+		name:        "pipelinetask timeout past the pipelinerun timeout",
+		prTimeout:   &metav1.Duration{Duration: time.Hour},
+		ptTimeout:   &metav1.Duration{Duration: 10 * time.Minute},
+		startTime:   time.Now().Add(-2 * time.Hour),
+		wantTimeout: &metav1.Duration{Duration: 0},
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := tb.PipelineRun("pipelinerun", "foo",
+				tb.PipelineRunSpec("pipeline", tb.PipelineRunTimeout(tc.prTimeout)),
+				tb.PipelineRunStatus(tb.PipelineRunStartTime(tc.startTime)),
+			)
+			pt := &v1alpha1.PipelineTask{Name: "task", Timeout: tc.ptTimeout}
+			if d := cmp.Diff(tc.wantTimeout, getPipelineTaskTimeout(pr, pt)); d != "" {
+				t.Errorf("Unexpected timeout -want, +got: %v", d)
+			}
+		})
+	}
+}
+
+func TestGetPipelineTaskTimeout_Clipped(t *testing.T) {
+	pr := tb.PipelineRun("pipelinerun", "foo",
+		tb.PipelineRunSpec("pipeline", tb.PipelineRunTimeout(&metav1.Duration{Duration: time.Hour})),
+		tb.PipelineRunStatus(tb.PipelineRunStartTime(time.Now().Add(-55*time.Minute))),
+	)
+	pt := &v1alpha1.PipelineTask{Name: "task", Timeout: &metav1.Duration{Duration: 10 * time.Minute}}
+
+	// Only 5 minutes remain before the PipelineRun times out
+	timeout := getPipelineTaskTimeout(pr, pt)
+	if timeout.Duration > 5*time.Minute || timeout.Duration < 4*time.Minute {
+		t.Errorf("Expected the timeout to be clipped to the remaining 5m but got %s", timeout.Duration)
+	}
+}
+
+func TestGetChildRunLabels(t *testing.T) {
+	pr := tb.PipelineRun("pipelinerun-rerun", "foo",
+		tb.PipelineRunLabel("PipelineRunLabel", "PipelineRunValue"),
+		tb.PipelineRunLabel("tekton.dev/artifactStorage", "pipelinerun"),
+		tb.PipelineRunSpec("pipeline"),
+	)
+
+	// The child runs don't use the artifact storage of the PipelineRun rerun by pr
+	expected := map[string]string{
+		"PipelineRunLabel":       "PipelineRunValue",
+		"tekton.dev/pipelineRun": "pipelinerun-rerun",
+	}
+	if d := cmp.Diff(expected, getChildRunLabels(pr)); d != "" {
+		t.Errorf("Unexpected labels -want, +got: %v", d)
+	}
+	if s := getTaskRunLabels(pr)["tekton.dev/artifactStorage"]; s != "pipelinerun" {
+		t.Errorf("Expected the TaskRuns to keep using the artifact storage of pipelinerun but got %q", s)
+	}
+}
+
+func TestReconcilePropagateLabels(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-labels", "foo",
+		tb.PipelineRunLabel("PipelineRunLabel", "PipelineRunValue"),
+		tb.PipelineRunLabel("tekton.dev/pipeline", "WillNotBeUsed"),
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccount("test-sa"),
+		),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-with-labels")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling completed PipelineRun but saw %s", err)
+	}
+
+	// Check that the PipelineRun was reconciled correctly
+	_, err = clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-with-labels", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting completed reconciled run out of fake client: %s", err)
+	}
+
+	// Check that the expected TaskRun was created
+	actual := clients.Pipeline.Actions()[0].(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+	if actual == nil {
+		t.Errorf("Expected a TaskRun to be created, but it wasn't.")
+	}
+	expectedTaskRun := tb.TaskRun("test-pipeline-run-with-labels-hello-world-1-9l9zj", "foo",
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-with-labels",
+			tb.OwnerReferenceAPIVersion("tekton.dev/v1alpha1"),
+			tb.Controller, tb.BlockOwnerDeletion,
+		),
+		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
+		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-with-labels"),
+		tb.TaskRunLabel("tekton.dev/pipelineTask", "hello-world-1"),
+		tb.TaskRunLabel("PipelineRunLabel", "PipelineRunValue"),
+		tb.TaskRunSpec(
+			tb.TaskRunTaskRef("hello-world"),
+			tb.TaskRunServiceAccount("test-sa"),
+		),
+	)
+
+	if d := cmp.Diff(actual, expectedTaskRun); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, d)
+	}
+}
+
+func TestReconcilePropagateRetries(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "hello-world", tb.Retries(3)),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-retries", "foo",
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccount("test-sa"),
+		),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-with-retries")
+	if err != nil {
+		t.Errorf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// Check that the expected TaskRun was created
+	actual := clients.Pipeline.Actions()[0].(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+	if actual == nil {
+		t.Fatalf("Expected a TaskRun to be created, but it wasn't.")
+	}
+	expectedTaskRun := tb.TaskRun("test-pipeline-run-with-retries-hello-world-1-9l9zj", "foo",
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-with-retries",
+			tb.OwnerReferenceAPIVersion("tekton.dev/v1alpha1"),
+			tb.Controller, tb.BlockOwnerDeletion,
+		),
+		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
+		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-with-retries"),
+		tb.TaskRunLabel("tekton.dev/pipelineTask", "hello-world-1"),
+		tb.TaskRunSpec(
+			tb.TaskRunTaskRef("hello-world"),
+			tb.TaskRunServiceAccount("test-sa"),
+			tb.TaskRunRetries(3),
+		),
+	)
+
+	if d := cmp.Diff(actual, expectedTaskRun); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, d)
+	}
+}
+
+func TestReconcileWithConditionCheck(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineParam("branch"),
+		tb.PipelineTask("deploy", "hello-world",
+			tb.PipelineTaskCondition("is-main", tb.ConditionCheck("busybox",
+				tb.Command("test"), tb.Args("${params.branch}", "=", "main"))),
+			tb.PipelineTaskTimeout(10*time.Minute)),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-with-conditions", "foo",
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunParam("branch", "main"),
+		),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-with-conditions"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// Only the condition check TaskRun should have been created
+	actual := clients.Pipeline.Actions()[0].(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+	if !strings.HasPrefix(actual.Name, "test-pipeline-run-with-conditions-deploy-9l9zj-is-main-") {
+		t.Errorf("Expected a condition check TaskRun to be created but got %s", actual.Name)
+	}
+	expectedSpec := v1alpha1.TaskRunSpec{
+		TaskSpec: &v1alpha1.TaskSpec{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:    "is-main",
+				Image:   "busybox",
+				Command: []string{"test"},
+				Args:    []string{"main", "=", "main"},
+			}}},
+		},
+		ServiceAccount: "test-sa",
+		// The condition check is limited by the timeout of the PipelineTask it guards
+		Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+	}
+	if d := cmp.Diff(expectedSpec, actual.Spec); d != "" {
+		t.Errorf("Unexpected condition check TaskRun spec: %s", d)
+	}
+	for _, a := range clients.Pipeline.Actions()[1:] {
+		if a.GetVerb() == "create" {
+			t.Errorf("Expected only the condition check TaskRun to be created but saw %v", a)
+		}
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-with-conditions", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	prtrs, ok := reconciledRun.Status.TaskRuns["test-pipeline-run-with-conditions-deploy-9l9zj"]
+	if !ok {
+		t.Fatalf("Expected the PipelineRun status to track the guarded TaskRun but got %v", reconciledRun.Status.TaskRuns)
+	}
+	if check, ok := prtrs.ConditionChecks[actual.Name]; !ok || check.ConditionName != "is-main" {
+		t.Errorf("Expected the PipelineRun status to track condition check %s but got %v", actual.Name, prtrs.ConditionChecks)
+	}
+}
+
+func TestReconcileWithUnmetConditionExpression(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineParam("branch"),
+		tb.PipelineTask("deploy", "hello-world",
+			tb.PipelineTaskCondition("is-main",
+				tb.ConditionExpression("${params.branch}", v1alpha1.ConditionOperatorIn, "main"))),
+		tb.PipelineTask("notify", "hello-world", tb.RunAfter("deploy")),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-skipped", "foo",
+		tb.PipelineRunSpec("test-pipeline",
+			tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunParam("branch", "feature"),
+		),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-skipped"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			t.Errorf("Expected no TaskRun to be created but saw %v", a)
+		}
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-skipped", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+		t.Errorf("Expected PipelineRun with only skipped tasks to succeed but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	expectedSkipped := []v1alpha1.SkippedTask{{
+		Name:   "deploy",
+		Reason: `condition "is-main" was not met: "feature" in [main]`,
+	}, {
+		Name:   "notify",
+		Reason: `parent task "deploy" was skipped`,
+	}}
+	if d := cmp.Diff(expectedSkipped, reconciledRun.Status.SkippedTasks); d != "" {
+		t.Errorf("Unexpected skipped tasks: %s", d)
+	}
+}
+
+func TestReconcileWithFinallyTasksAfterFailure(t *testing.T) {
+	names.TestingSeed()
+
+	prtrs := map[string]*v1alpha1.PipelineRunTaskRunStatus{
+		"test-pipeline-run-finally-unit-test": {
+			PipelineTaskName: "unit-test",
+			Status:           &v1alpha1.TaskRunStatus{},
+		},
+	}
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("unit-test", "hello-world"),
+		tb.PipelineTask("lint", "hello-world", tb.RunAfter("unit-test")),
+		tb.PipelineFinallyTask("cleanup", "hello-world"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-finally", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(prtrs)),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-finally-unit-test", "foo",
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+			})),
+		),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-finally"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// Only the finally task should be started once a task has failed
+	created := []*v1alpha1.TaskRun{}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			created = append(created, a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun))
+		}
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected only the finally TaskRun to be created but %d TaskRuns were created", len(created))
+	}
+	if created[0].Name != "test-pipeline-run-finally-cleanup-mz4c7" {
+		t.Errorf("Expected the finally TaskRun to be created but got %s", created[0].Name)
+	}
+
+	// The PipelineRun keeps running until its finally tasks are done
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-finally", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to still be running but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	if _, ok := reconciledRun.Status.TaskRuns["test-pipeline-run-finally-cleanup-mz4c7"]; !ok {
+		t.Errorf("Expected the finally TaskRun to be in the PipelineRun status but got %v", reconciledRun.Status.TaskRuns)
+	}
+}
+
+func TestReconcileWithFailurePolicyRunAllIndependent(t *testing.T) {
+	names.TestingSeed()
+
+	prtrs := map[string]*v1alpha1.PipelineRunTaskRunStatus{
+		"test-pipeline-run-failure-policy-unit-test": {
+			PipelineTaskName: "unit-test",
+			Status:           &v1alpha1.TaskRunStatus{},
+		},
+	}
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("unit-test", "hello-world"),
+		tb.PipelineTask("deploy", "hello-world", tb.RunAfter("unit-test")),
+		tb.PipelineTask("lint", "hello-world"),
+		tb.PipelineFailurePolicy(v1alpha1.FailurePolicyRunAllIndependent),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-failure-policy", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(prtrs)),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-failure-policy-unit-test", "foo",
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+			})),
+		),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-failure-policy"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// The independent task is still started once a task has failed
+	created := []*v1alpha1.TaskRun{}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			created = append(created, a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun))
+		}
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected only the lint TaskRun to be created but %d TaskRuns were created", len(created))
+	}
+	if !strings.HasPrefix(created[0].Name, "test-pipeline-run-failure-policy-lint-") {
+		t.Errorf("Expected the lint TaskRun to be created but got %s", created[0].Name)
+	}
+
+	// The PipelineRun keeps running until no more tasks can be run
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-failure-policy", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to still be running but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	expectedSkipped := []v1alpha1.SkippedTask{{
+		Name:   "deploy",
+		Reason: `parent task "unit-test" failed`,
+	}}
+	if d := cmp.Diff(expectedSkipped, reconciledRun.Status.SkippedTasks); d != "" {
+		t.Errorf("Unexpected skipped tasks -want, +got: %v", d)
+	}
+}
+
+func TestReconcileCancelledWithFinallyTasks(t *testing.T) {
+	prtrs := map[string]*v1alpha1.PipelineRunTaskRunStatus{
+		"test-pipeline-run-cancelled-unit-test": {
+			PipelineTaskName: "unit-test",
+			Status:           &v1alpha1.TaskRunStatus{},
+		},
+	}
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("unit-test", "hello-world"),
+		tb.PipelineFinallyTask("cleanup", "hello-world"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-cancelled", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunCancelled,
+		),
+		tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(prtrs)),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo")}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-cancelled-unit-test", "foo",
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+			})),
+		),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-cancelled"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	tr, err := clients.Pipeline.Tekton().TaskRuns("foo").Get("test-pipeline-run-cancelled-unit-test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting TaskRun out of fake client: %s", err)
+	}
+	if !tr.IsCancelled() {
+		t.Errorf("Expected TaskRun %s to be cancelled", tr.Name)
+	}
+
+	// The finally task can't be started until the cancelled TaskRun has stopped
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			t.Errorf("Expected no TaskRun to be created but saw %v", a)
+		}
+	}
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-cancelled", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected cancelled PipelineRun with finally tasks to still be running but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	if reconciledRun.Status.CompletionTime != nil {
+		t.Errorf("Expected no CompletionTime on PipelineRun with running finally tasks but was %v", reconciledRun.Status.CompletionTime)
+	}
+}
+
+func TestReconcileWithEmbeddedPipelineSpec(t *testing.T) {
+	names.TestingSeed()
+
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-embedded", "foo",
+		tb.PipelineRunSpec("",
+			tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunParam("greeting", "hello"),
+			tb.PipelineRunPipelineSpec(
+				tb.PipelineParam("greeting"),
+				tb.PipelineTask("hello-world-1", "hello-world",
+					tb.PipelineTaskParam("message", "${params.greeting}")),
+			),
+		),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsParam("message")),
+	))}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-embedded"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// Check that the expected TaskRun was created
+	actual := clients.Pipeline.Actions()[0].(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+	if actual == nil {
+		t.Fatalf("Expected a TaskRun to be created, but it wasn't.")
+	}
+	expectedTaskRun := tb.TaskRun("test-pipeline-run-embedded-hello-world-1-9l9zj", "foo",
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-embedded",
+			tb.OwnerReferenceAPIVersion("tekton.dev/v1alpha1"),
+			tb.Controller, tb.BlockOwnerDeletion,
+		),
+		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-embedded"),
+		tb.TaskRunLabel("tekton.dev/pipelineTask", "hello-world-1"),
+		tb.TaskRunSpec(
+			tb.TaskRunTaskRef("hello-world"),
+			tb.TaskRunServiceAccount("test-sa"),
+			tb.TaskRunInputs(tb.TaskRunInputsParam("message", "hello")),
+		),
+	)
+	if d := cmp.Diff(actual, expectedTaskRun); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, d)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-embedded", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun with embedded spec to be running but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+func TestReconcileWithEmbeddedTaskSpec(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("hello-world-1", "", tb.PipelineTaskSpec(
+			tb.Step("echo", "busybox", tb.Command("echo"), tb.Args("hello")),
+		)),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-embedded-task", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+	)}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-embedded-task"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// Check that the expected TaskRun was created with the embedded TaskSpec
+	actual := clients.Pipeline.Actions()[0].(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+	if actual == nil {
+		t.Fatalf("Expected a TaskRun to be created, but it wasn't.")
+	}
+	expectedTaskRun := tb.TaskRun("test-pipeline-run-embedded-task-hello-world-1-9l9zj", "foo",
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run-embedded-task",
+			tb.OwnerReferenceAPIVersion("tekton.dev/v1alpha1"),
+			tb.Controller, tb.BlockOwnerDeletion,
+		),
+		tb.TaskRunLabel("tekton.dev/pipeline", "test-pipeline"),
+		tb.TaskRunLabel("tekton.dev/pipelineRun", "test-pipeline-run-embedded-task"),
+		tb.TaskRunLabel("tekton.dev/pipelineTask", "hello-world-1"),
+		tb.TaskRunSpec(
+			tb.TaskRunTaskSpec(tb.Step("echo", "busybox", tb.Command("echo"), tb.Args("hello"))),
+			tb.TaskRunServiceAccount("test-sa"),
+		),
+	)
+	if d := cmp.Diff(actual, expectedTaskRun); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, d)
+	}
+}
+
+func TestReconcileWithTaskResults(t *testing.T) {
+	names.TestingSeed()
+
+	prtrs := map[string]*v1alpha1.PipelineRunTaskRunStatus{
+		"test-pipeline-run-results-build": {
+			PipelineTaskName: "build",
+			Status:           &v1alpha1.TaskRunStatus{},
+		},
+	}
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("build", "hello-world"),
+		tb.PipelineTask("deploy", "hello-world",
+			tb.PipelineTaskParam("image", "gcr.io/foo/bar@${tasks.build.results.digest}")),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-results", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(prtrs)),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsParam("image", tb.ParamDefault("busybox"))),
+		tb.TaskResult("digest", "the digest of the image"),
+	))}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-results-build", "foo",
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			}), tb.TaskRunTaskResult("digest", "sha256:abcd")),
+		),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-results"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// The TaskRun of the task running after build is passed the result of build
+	actual := clients.Pipeline.Actions()[0].(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+	if actual == nil {
+		t.Fatalf("Expected a TaskRun to be created, but it wasn't.")
+	}
+	expectedParams := []v1alpha1.Param{{Name: "image", Value: *v1alpha1.NewArrayOrString("gcr.io/foo/bar@sha256:abcd")}}
+	if d := cmp.Diff(expectedParams, actual.Spec.Inputs.Params); d != "" {
+		t.Errorf("Unexpected params for TaskRun %s -want, +got: %v", actual.Name, d)
+	}
+
+	// The Pipeline itself isn't modified
+	if ps[0].Spec.Tasks[1].Params[0].Value.StringVal != "gcr.io/foo/bar@${tasks.build.results.digest}" {
+		t.Errorf("Expected the Pipeline not to be modified but params were %v", ps[0].Spec.Tasks[1].Params)
+	}
+}
+
+func TestReconcileWithMissingTaskResults(t *testing.T) {
+	prtrs := map[string]*v1alpha1.PipelineRunTaskRunStatus{
+		"test-pipeline-run-missing-results-build": {
+			PipelineTaskName: "build",
+			Status:           &v1alpha1.TaskRunStatus{},
+		},
+	}
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("build", "hello-world"),
+		tb.PipelineTask("deploy", "hello-world",
+			tb.PipelineTaskParam("image", "gcr.io/foo/bar@${tasks.build.results.digest}")),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-missing-results", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(prtrs)),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsParam("image", tb.ParamDefault("busybox"))),
+	))}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-missing-results-build", "foo",
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			})),
+		),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-missing-results"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			t.Errorf("Expected no TaskRun to be created when a result is missing but saw %v", a)
+		}
+	}
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-missing-results", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != ReasonInvalidTaskResultReference {
+		t.Errorf("Expected PipelineRun to fail with reason %s but condition was %v", ReasonInvalidTaskResultReference, condition)
+	}
+}
+
+func TestReconcileWithFinallyTaskResultsAfterFailure(t *testing.T) {
+	names.TestingSeed()
+
+	prtrs := map[string]*v1alpha1.PipelineRunTaskRunStatus{
+		"test-pipeline-run-finally-results-build": {
+			PipelineTaskName: "build",
+			Status:           &v1alpha1.TaskRunStatus{},
+		},
+	}
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("build", "hello-world"),
+		tb.PipelineFinallyTask("notify", "hello-world",
+			tb.PipelineTaskParam("image", "gcr.io/foo/bar@${tasks.build.results.digest}")),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-finally-results", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(prtrs)),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsParam("image", tb.ParamDefault("busybox"))),
+		tb.TaskResult("digest", "the digest of the image"),
+	))}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-finally-results-build", "foo",
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+			})),
+		),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-finally-results"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// The finally task still runs, without the param using the result build didn't report
+	created := []*v1alpha1.TaskRun{}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			created = append(created, a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun))
+		}
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected only the finally TaskRun to be created but %d TaskRuns were created", len(created))
+	}
+	if created[0].Name != "test-pipeline-run-finally-results-notify-9l9zj" {
+		t.Errorf("Expected the finally TaskRun to be created but got %s", created[0].Name)
+	}
+	if len(created[0].Spec.Inputs.Params) != 0 {
+		t.Errorf("Expected the param using the missing result to be omitted but params were %v", created[0].Spec.Inputs.Params)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-finally-results", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to still be running but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+func TestReconcileWithPipelineResults(t *testing.T) {
+	prtrs := map[string]*v1alpha1.PipelineRunTaskRunStatus{
+		"test-pipeline-run-pipeline-results-build": {
+			PipelineTaskName: "build",
+			Status:           &v1alpha1.TaskRunStatus{},
+		},
+	}
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("build", "hello-world"),
+		tb.PipelineResult("image", "gcr.io/foo/bar@${tasks.build.results.digest}"),
+		tb.PipelineResult("version", "${tasks.build.results.version}"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-pipeline-results", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(prtrs)),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskResult("digest", "the digest of the image"),
+		tb.TaskResult("version", "the version of the image"),
+	))}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-pipeline-results-build", "foo",
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			}), tb.TaskRunTaskResult("digest", "sha256:abcd")),
+		),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-pipeline-results"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-pipeline-results", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+		t.Fatalf("Expected PipelineRun to succeed but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	// The version result isn't set since build didn't report the result it uses
+	expectedResults := []v1alpha1.PipelineRunResult{{
+		Name:  "image",
+		Value: "gcr.io/foo/bar@sha256:abcd",
+	}}
+	if d := cmp.Diff(expectedResults, reconciledRun.Status.PipelineResults); d != "" {
+		t.Errorf("Unexpected pipeline results -want, +got: %v", d)
+	}
+}
+
+func TestReconcileWithWorkspaces(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineWorkspace("shared", "the shared source"),
+		tb.PipelineWorkspace("config", ""),
+		tb.PipelineTask("build", "hello-world",
+			tb.PipelineTaskWorkspace("source", "shared"),
+			tb.PipelineTaskWorkspace("settings", "config")),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-workspaces", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunWorkspace("shared", tb.WorkspaceBindingSubPath("src"),
+				tb.WorkspaceBindingVolumeClaimTemplate(&corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "mypvc"},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					},
+				})),
+			tb.PipelineRunWorkspace("config", tb.WorkspaceBindingConfigMap("myconfig")),
+		),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskWorkspace("source", "", "", false),
+		tb.TaskWorkspace("settings", "", "/settings", true),
+	))}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-workspaces"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// The claim of the shared workspace is created once for the PipelineRun
+	pvcs, err := clients.Kube.CoreV1().PersistentVolumeClaims("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error listing claims: %v", err)
+	}
+	var claimName string
+	for _, pvc := range pvcs.Items {
+		if strings.HasPrefix(pvc.Name, "mypvc-") {
+			claimName = pvc.Name
+			if len(pvc.OwnerReferences) != 1 || pvc.OwnerReferences[0].Name != prs[0].Name {
+				t.Errorf("Expected the claim to be owned by the PipelineRun but owner references were %v", pvc.OwnerReferences)
+			}
+		}
+	}
+	if claimName == "" {
+		t.Fatalf("Expected a claim to be created for the shared workspace but claims were %v", pvcs.Items)
+	}
+
+	// The workspaces of the Task are bound to the workspaces of the PipelineRun they are mapped to
+	actual := clients.Pipeline.Actions()[0].(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun)
+	if actual == nil {
+		t.Fatalf("Expected a TaskRun to be created, but it wasn't.")
+	}
+	expectedWorkspaces := []v1alpha1.WorkspaceBinding{{
+		Name:    "source",
+		SubPath: "src",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: claimName,
+		},
+	}, {
+		Name: "settings",
+		ConfigMap: &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "myconfig"},
+		},
+	}}
+	if d := cmp.Diff(expectedWorkspaces, actual.Spec.Workspaces); d != "" {
+		t.Errorf("Unexpected workspaces for TaskRun %s -want, +got: %v", actual.Name, d)
+	}
+}
+
+func TestReconcileWithMatrix(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineParam("versions", tb.PipelineParamType(v1alpha1.ParamTypeArray)),
+		tb.PipelineTask("unit-test", "hello-world",
+			tb.PipelineTaskParam("platform", "linux"),
+			tb.PipelineTaskMatrix("version", "${params.versions}")),
+		tb.PipelineTask("publish", "hello-world", tb.RunAfter("unit-test")),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-matrix", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunParam("versions", "1.12", "1.13")),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(
+			tb.InputsParam("platform", tb.ParamDefault("linux")),
+			tb.InputsParam("version", tb.ParamDefault("1.13")),
+		),
+	))}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-matrix"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// A TaskRun is created for each combination of the matrix
+	created := []*v1alpha1.TaskRun{}
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			created = append(created, a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun))
+		}
+	}
+	if len(created) != 2 {
+		t.Fatalf("Expected a TaskRun to be created for each combination but %d TaskRuns were created", len(created))
+	}
+	for i, version := range []string{"1.12", "1.13"} {
+		expectedName := fmt.Sprintf("test-pipeline-run-matrix-unit-test-%d", i)
+		if created[i].Name != expectedName {
+			t.Errorf("Expected TaskRun %s to be created but got %s", expectedName, created[i].Name)
+		}
+		expectedParams := []v1alpha1.Param{
+			{Name: "platform", Value: *v1alpha1.NewArrayOrString("linux")},
+			{Name: "version", Value: *v1alpha1.NewArrayOrString(version)},
+		}
+		if d := cmp.Diff(expectedParams, created[i].Spec.Inputs.Params); d != "" {
+			t.Errorf("Unexpected params for TaskRun %s -want, +got: %v", created[i].Name, d)
+		}
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-matrix", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	for _, tr := range created {
+		if prtrs, ok := reconciledRun.Status.TaskRuns[tr.Name]; !ok || prtrs.PipelineTaskName != "unit-test" {
+			t.Errorf("Expected TaskRun %s of unit-test to be in the PipelineRun status but got %v", tr.Name, reconciledRun.Status.TaskRuns)
+		}
+	}
+}
+
+func TestReconcileWithMatrixPartiallyDone(t *testing.T) {
+	prtrs := map[string]*v1alpha1.PipelineRunTaskRunStatus{
+		"test-pipeline-run-matrix-unit-test-0": {
+			PipelineTaskName: "unit-test",
+			Status:           &v1alpha1.TaskRunStatus{},
+		},
+		"test-pipeline-run-matrix-unit-test-1": {
+			PipelineTaskName: "unit-test",
+			Status:           &v1alpha1.TaskRunStatus{},
+		},
+	}
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("unit-test", "hello-world",
+			tb.PipelineTaskMatrix("version", "1.12", "1.13")),
+		tb.PipelineTask("publish", "hello-world", tb.RunAfter("unit-test")),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-matrix", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunTaskRunsStatus(prtrs)),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsParam("version", tb.ParamDefault("1.13"))),
+	))}
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-matrix-unit-test-0", "foo",
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			})),
+		),
+		tb.TaskRun("test-pipeline-run-matrix-unit-test-1", "foo",
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+			})),
+		),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-matrix"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// The tasks running after the matrix wait for all of its combinations to be done
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			t.Errorf("Expected no TaskRun to be created while a combination is running but got %v", a.(ktesting.CreateAction).GetObject())
+		}
+	}
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-matrix", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to still be running but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+func TestReconcileWithChildPipeline(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1alpha1.Pipeline{
+		tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineTask("build", "child-pipeline",
+				tb.PipelineTaskRefKind(v1alpha1.PipelineKind),
+				tb.PipelineTaskParam("version", "1.13")),
+			tb.PipelineTask("publish", "hello-world", tb.RunAfter("build")),
+		)),
+		tb.Pipeline("child-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineParam("version"),
+			tb.PipelineTask("unit-test", "hello-world"),
+		)),
+	}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-child", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec())}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-child"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// A child PipelineRun, owned by the PipelineRun, is created instead of a TaskRun
+	var created []*v1alpha1.PipelineRun
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			pr, ok := a.(ktesting.CreateAction).GetObject().(*v1alpha1.PipelineRun)
+			if !ok {
+				t.Fatalf("Expected only a child PipelineRun to be created but got %v", a.(ktesting.CreateAction).GetObject())
+			}
+			created = append(created, pr)
+		}
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected a child PipelineRun to be created but %d were created", len(created))
+	}
+	child := created[0]
+	if child.Name != "test-pipeline-run-child-build-9l9zj" {
+		t.Errorf("Expected child PipelineRun test-pipeline-run-child-build-9l9zj to be created but got %s", child.Name)
+	}
+	if len(child.OwnerReferences) != 1 || child.OwnerReferences[0].Name != "test-pipeline-run-child" {
+		t.Errorf("Expected the child PipelineRun to be owned by the PipelineRun but owner references were %v", child.OwnerReferences)
+	}
+	if child.Labels[pipeline.GroupName+pipeline.PipelineRunLabelKey] != "test-pipeline-run-child" {
+		t.Errorf("Expected the child PipelineRun to be labelled with the PipelineRun but labels were %v", child.Labels)
+	}
+	if a := child.Annotations[pipeline.GroupName+pipeline.AncestorPipelinesAnnotationKey]; a != "test-pipeline" {
+		t.Errorf("Expected the child PipelineRun to record that it runs under test-pipeline but got %q", a)
+	}
+	if child.Spec.PipelineRef.Name != "child-pipeline" || child.Spec.ServiceAccount != "test-sa" {
+		t.Errorf("Expected the child PipelineRun to run child-pipeline as test-sa but got %v", child.Spec)
+	}
+	expectedParams := []v1alpha1.Param{{Name: "version", Value: *v1alpha1.NewArrayOrString("1.13")}}
+	if d := cmp.Diff(expectedParams, child.Spec.Params); d != "" {
+		t.Errorf("Unexpected params of the child PipelineRun -want, +got: %v", d)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-child", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if s, ok := reconciledRun.Status.ChildPipelineRuns[child.Name]; !ok || s.PipelineTaskName != "build" {
+		t.Errorf("Expected child PipelineRun %s of build to be in the PipelineRun status but got %v", child.Name, reconciledRun.Status.ChildPipelineRuns)
+	}
+	if len(reconciledRun.Status.TaskRuns) != 0 {
+		t.Errorf("Expected no TaskRun in the PipelineRun status but got %v", reconciledRun.Status.TaskRuns)
+	}
+}
+
+func TestReconcileWithChildPipelineDone(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{
+		tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineTask("build", "child-pipeline", tb.PipelineTaskRefKind(v1alpha1.PipelineKind)),
+			tb.PipelineTask("publish", "hello-world",
+				tb.PipelineTaskParam("image", "${tasks.build.results.image}")),
+		)),
+		tb.Pipeline("child-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineTask("unit-test", "hello-world"),
+		)),
+	}
+	child := tb.PipelineRun("test-pipeline-run-child-build-abcde", "foo",
+		tb.PipelineRunSpec("child-pipeline"),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionTrue,
+		})),
+	)
+	child.Status.PipelineResults = []v1alpha1.PipelineRunResult{{Name: "image", Value: "gcr.io/foo/bar"}}
+	parent := tb.PipelineRun("test-pipeline-run-child", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+		})),
+	)
+	parent.Status.ChildPipelineRuns = map[string]*v1alpha1.PipelineRunChildStatus{
+		child.Name: {PipelineTaskName: "build"},
+	}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsParam("image")),
+	))}
+
+	d := test.Data{
+		PipelineRuns: []*v1alpha1.PipelineRun{parent, child},
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-child"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// Once the child PipelineRun has succeeded, the tasks after it are run with its results
+	var created []*v1alpha1.TaskRun
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			created = append(created, a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun))
+		}
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected a TaskRun to be created for publish but %d TaskRuns were created", len(created))
+	}
+	expectedParams := []v1alpha1.Param{{Name: "image", Value: *v1alpha1.NewArrayOrString("gcr.io/foo/bar")}}
+	if d := cmp.Diff(expectedParams, created[0].Spec.Inputs.Params); d != "" {
+		t.Errorf("Unexpected params for TaskRun %s -want, +got: %v", created[0].Name, d)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-child", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	s, ok := reconciledRun.Status.ChildPipelineRuns[child.Name]
+	if !ok || s.Status == nil || !s.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+		t.Errorf("Expected the status of the child PipelineRun to be in the PipelineRun status but got %v", reconciledRun.Status.ChildPipelineRuns)
+	}
+}
+
+func TestReconcileWithCustomTask(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("approve", "release-approval",
+			tb.PipelineTaskRefCustomTask("example.dev/v1", "Approval"),
+			tb.PipelineTaskParam("approvers", "alice", "bob")),
+		tb.PipelineTask("publish", "hello-world", tb.RunAfter("approve")),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-custom-task", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec())}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-custom-task"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// A Run, owned by the PipelineRun, is created for the controller of the custom task
+	var created []*v1alpha1.Run
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			run, ok := a.(ktesting.CreateAction).GetObject().(*v1alpha1.Run)
+			if !ok {
+				t.Fatalf("Expected only a Run to be created but got %v", a.(ktesting.CreateAction).GetObject())
+			}
+			created = append(created, run)
+		}
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected a Run to be created but %d were created", len(created))
+	}
+	run := created[0]
+	if run.Name != "test-pipeline-run-custom-task-approve-9l9zj" {
+		t.Errorf("Expected Run test-pipeline-run-custom-task-approve-9l9zj to be created but got %s", run.Name)
+	}
+	if len(run.OwnerReferences) != 1 || run.OwnerReferences[0].Name != "test-pipeline-run-custom-task" {
+		t.Errorf("Expected the Run to be owned by the PipelineRun but owner references were %v", run.OwnerReferences)
+	}
+	expectedSpec := v1alpha1.RunSpec{
+		Ref: &v1alpha1.TaskRef{
+			APIVersion: "example.dev/v1",
+			Kind:       "Approval",
+			Name:       "release-approval",
+		},
+		Params:         []v1alpha1.Param{{Name: "approvers", Value: *v1alpha1.NewArrayOrString("alice", "bob")}},
+		ServiceAccount: "test-sa",
+	}
+	if d := cmp.Diff(expectedSpec, run.Spec); d != "" {
+		t.Errorf("Unexpected spec of the Run -want, +got: %v", d)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-custom-task", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if s, ok := reconciledRun.Status.Runs[run.Name]; !ok || s.PipelineTaskName != "approve" {
+		t.Errorf("Expected Run %s of approve to be in the PipelineRun status but got %v", run.Name, reconciledRun.Status.Runs)
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to still be running but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+func TestReconcileWithCustomTaskDone(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("approve", "release-approval",
+			tb.PipelineTaskRefCustomTask("example.dev/v1", "Approval")),
+		tb.PipelineTask("publish", "hello-world",
+			tb.PipelineTaskParam("approver", "${tasks.approve.results.approver}")),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-custom-task", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+		})),
+	)}
+	prs[0].Status.Runs = map[string]*v1alpha1.PipelineRunRunStatus{
+		"test-pipeline-run-custom-task-approve-abcde": {PipelineTaskName: "approve"},
+	}
+	runs := []*v1alpha1.Run{{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-custom-task-approve-abcde", Namespace: "foo"},
+		Spec: v1alpha1.RunSpec{
+			Ref: &v1alpha1.TaskRef{APIVersion: "example.dev/v1", Kind: "Approval", Name: "release-approval"},
+		},
+		Status: v1alpha1.RunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}},
+			},
+			Results: []v1alpha1.RunResult{{Name: "approver", Value: "alice"}},
+		},
+	}}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsParam("approver")),
+	))}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		Runs:         runs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-custom-task"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// Once the Run has succeeded, the tasks after it are run with its results
+	var created []*v1alpha1.TaskRun
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			created = append(created, a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun))
+		}
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected a TaskRun to be created for publish but %d TaskRuns were created", len(created))
+	}
+	expectedParams := []v1alpha1.Param{{Name: "approver", Value: *v1alpha1.NewArrayOrString("alice")}}
+	if d := cmp.Diff(expectedParams, created[0].Spec.Inputs.Params); d != "" {
+		t.Errorf("Unexpected params for TaskRun %s -want, +got: %v", created[0].Name, d)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-custom-task", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	s, ok := reconciledRun.Status.Runs[runs[0].Name]
+	if !ok || s.Status == nil || !s.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+		t.Errorf("Expected the status of the Run to be in the PipelineRun status but got %v", reconciledRun.Status.Runs)
+	}
+}
+
+func TestReconcileWithPendingApproval(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("deploy", "hello-world", tb.PipelineTaskApproval("alice", "bob")),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-approval", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec())}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-approval"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// The task isn't started before it has been approved
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			t.Errorf("Expected nothing to be created before the approval but got %v", a.(ktesting.CreateAction).GetObject())
+		}
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-approval", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	expectedPending := []v1alpha1.PipelineRunPendingApproval{{PipelineTask: "deploy", Approvers: []string{"alice", "bob"}}}
+	if d := cmp.Diff(expectedPending, reconciledRun.Status.PendingApprovals); d != "" {
+		t.Errorf("Unexpected pending approvals -want, +got: %v", d)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsUnknown() || condition.Reason != ReasonPendingApproval {
+		t.Errorf("Expected PipelineRun to be waiting for approval but condition was %v", condition)
+	}
+}
+
+func TestReconcileWithApprovalDecision(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		decision      v1alpha1.ApprovalDecision
+		wantTaskRuns  int
+		wantCondition apis.Condition
+	}{{
+		name:         "approved",
+		decision:     v1alpha1.ApprovalDecisionApproved,
+		wantTaskRuns: 1,
+		wantCondition: apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  resources.ReasonRunning,
+			Message: "Not all Tasks in the Pipeline have finished executing",
+		},
+	}, {
+		name:     "rejected",
+		decision: v1alpha1.ApprovalDecisionRejected,
+		wantCondition: apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonApprovalRejected,
+			Message: "PipelineTask deploy was rejected by bob",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+				tb.PipelineTask("deploy", "hello-world", tb.PipelineTaskApproval("alice", "bob")),
+			))}
+			prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-approval", "foo",
+				tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+					tb.PipelineRunApproval("deploy", tc.decision, "bob")),
+				tb.PipelineRunStatus(tb.PipelineRunPendingApproval("deploy", "alice", "bob")),
+			)}
+			ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec())}
+
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+			}
+
+			// create fake recorder for testing
+			fr := record.NewFakeRecorder(2)
+
+			testAssets := getPipelineRunController(d, fr)
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-approval"); err != nil {
+				t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+			}
+
+			taskRuns := 0
+			for _, a := range clients.Pipeline.Actions() {
+				if a.GetVerb() == "create" {
+					if _, ok := a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun); ok {
+						taskRuns++
+					}
+				}
+			}
+			if taskRuns != tc.wantTaskRuns {
+				t.Errorf("Expected %d TaskRuns to be created but %d were created", tc.wantTaskRuns, taskRuns)
+			}
+
+			reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-approval", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+			}
+			if len(reconciledRun.Status.PendingApprovals) != 0 {
+				t.Errorf("Expected no pending approvals but got %v", reconciledRun.Status.PendingApprovals)
+			}
+			condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+			condition.LastTransitionTime = tc.wantCondition.LastTransitionTime
+			if d := cmp.Diff(&tc.wantCondition, condition); d != "" {
+				t.Errorf("Unexpected condition of the PipelineRun -want, +got: %v", d)
+			}
+		})
+	}
+}
+
+func TestReconcileRerun(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("build", "hello-world"),
+		tb.PipelineTask("test", "hello-world", tb.RunAfter("build")),
+	))}
+	previous := tb.PipelineRun("test-pipeline-run", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+		})),
+	)
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-build-abcde", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			})),
+		),
+		tb.TaskRun("test-pipeline-run-test-abcde", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+			})),
+		),
+	}
+	previous.Status.TaskRuns = map[string]*v1alpha1.PipelineRunTaskRunStatus{
+		trs[0].Name: {PipelineTaskName: "build", Status: &trs[0].Status},
+		trs[1].Name: {PipelineTaskName: "test", Status: &trs[1].Status},
+	}
+	rerun := tb.PipelineRun("test-pipeline-run-rerun", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunRerunOf("test-pipeline-run")),
+	)
+	rerun.UID = "test-pipeline-run-rerun-uid"
+	prs := []*v1alpha1.PipelineRun{previous, rerun}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec())}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-rerun"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// The TaskRun of build is reused, so only test is run again, with the artifact storage
+	// of the previous PipelineRun
+	var created []*v1alpha1.TaskRun
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			created = append(created, a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun))
+		}
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected a TaskRun to be created for test but %d TaskRuns were created", len(created))
+	}
+	if !strings.HasPrefix(created[0].Name, "test-pipeline-run-rerun-test-") || created[0].OwnerReferences[0].Name != "test-pipeline-run-rerun" {
+		t.Errorf("Expected a TaskRun of test to be created for the rerun but got %v", created[0])
+	}
+	if s := created[0].Labels["tekton.dev/artifactStorage"]; s != "test-pipeline-run" {
+		t.Errorf("Expected the TaskRun to use the artifact storage of test-pipeline-run but got %q", s)
+	}
+	if created[0].GetPipelineRunPVCName() != "test-pipeline-run-pvc" {
+		t.Errorf("Expected the TaskRun to use the PVC of test-pipeline-run but got %q", created[0].GetPipelineRunPVCName())
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-rerun", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if s, ok := reconciledRun.Status.TaskRuns["test-pipeline-run-build-abcde"]; !ok || s.PipelineTaskName != "build" {
+		t.Errorf("Expected the TaskRun of build to be reused but got %v", reconciledRun.Status.TaskRuns)
+	}
+	if _, ok := reconciledRun.Status.TaskRuns["test-pipeline-run-test-abcde"]; ok {
+		t.Errorf("Expected the failed TaskRun of test not to be reused but got %v", reconciledRun.Status.TaskRuns)
+	}
+	if s := reconciledRun.Labels["tekton.dev/artifactStorage"]; s != "test-pipeline-run" {
+		t.Errorf("Expected the rerun to use the artifact storage of test-pipeline-run but got %q", s)
+	}
+
+	// The rerun also owns the reused TaskRun, so that deleting the previous PipelineRun doesn't
+	// garbage collect it
+	reused, err := clients.Pipeline.Tekton().TaskRuns("foo").Get("test-pipeline-run-build-abcde", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reused TaskRun out of fake client: %s", err)
+	}
+	if len(reused.OwnerReferences) != 2 || reused.OwnerReferences[1].UID != rerun.UID || reused.OwnerReferences[1].Controller != nil {
+		t.Errorf("Expected the rerun to be added to the owners of the reused TaskRun but got %v", reused.OwnerReferences)
+	}
+}
+
+func TestReconcileRerunWithChildPipelineAndRun(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{
+		tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineTask("scan", "child-pipeline", tb.PipelineTaskRefKind(v1alpha1.PipelineKind)),
+			tb.PipelineTask("approve", "release-approval",
+				tb.PipelineTaskRefCustomTask("example.dev/v1", "Approval")),
+			tb.PipelineTask("publish", "hello-world", tb.RunAfter("scan", "approve")),
+		)),
+		tb.Pipeline("child-pipeline", "foo", tb.PipelineSpec(
+			tb.PipelineTask("unit-test", "hello-world"),
+		)),
+	}
+	succeeded := duckv1beta1.Status{
+		Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}},
+	}
+	previous := tb.PipelineRun("test-pipeline-run", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+		})),
+	)
+	previous.UID = "test-pipeline-run-uid"
+	child := tb.PipelineRun("test-pipeline-run-scan-abcde", "foo",
+		tb.PipelineRunSpec("child-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+	)
+	child.OwnerReferences = previous.GetOwnerReference()
+	child.Status.Status = succeeded
+	runs := []*v1alpha1.Run{{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-pipeline-run-approve-abcde",
+			Namespace:       "foo",
+			OwnerReferences: previous.GetOwnerReference(),
+		},
+		Spec: v1alpha1.RunSpec{
+			Ref: &v1alpha1.TaskRef{APIVersion: "example.dev/v1", Kind: "Approval", Name: "release-approval"},
+		},
+		Status: v1alpha1.RunStatus{Status: succeeded},
+	}}
+	trs := []*v1alpha1.TaskRun{tb.TaskRun("test-pipeline-run-publish-abcde", "foo",
+		tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run"),
+		tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+		tb.TaskRunStatus(tb.Condition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+		})),
+	)}
+	previous.Status.TaskRuns = map[string]*v1alpha1.PipelineRunTaskRunStatus{
+		trs[0].Name: {PipelineTaskName: "publish", Status: &trs[0].Status},
+	}
+	previous.Status.ChildPipelineRuns = map[string]*v1alpha1.PipelineRunChildStatus{
+		child.Name: {PipelineTaskName: "scan", Status: &child.Status},
+	}
+	previous.Status.Runs = map[string]*v1alpha1.PipelineRunRunStatus{
+		runs[0].Name: {PipelineTaskName: "approve", Status: &runs[0].Status},
+	}
+	rerun := tb.PipelineRun("test-pipeline-run-rerun", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunRerunOf("test-pipeline-run")),
+	)
+	rerun.UID = "test-pipeline-run-rerun-uid"
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec())}
+
+	d := test.Data{
+		PipelineRuns: []*v1alpha1.PipelineRun{previous, child, rerun},
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+		Runs:         runs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-rerun"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// The child PipelineRun of scan and the Run of approve are reused, so only publish is run again
+	var created []runtime.Object
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			created = append(created, a.(ktesting.CreateAction).GetObject())
+		}
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected only a TaskRun to be created for publish but got %v", created)
+	}
+	if tr, ok := created[0].(*v1alpha1.TaskRun); !ok || !strings.HasPrefix(tr.Name, "test-pipeline-run-rerun-publish-") {
+		t.Errorf("Expected a TaskRun of publish to be created for the rerun but got %v", created[0])
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-rerun", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	if s, ok := reconciledRun.Status.ChildPipelineRuns[child.Name]; !ok || s.PipelineTaskName != "scan" {
+		t.Errorf("Expected the child PipelineRun of scan to be reused but got %v", reconciledRun.Status.ChildPipelineRuns)
+	}
+	if s, ok := reconciledRun.Status.Runs[runs[0].Name]; !ok || s.PipelineTaskName != "approve" {
+		t.Errorf("Expected the Run of approve to be reused but got %v", reconciledRun.Status.Runs)
+	}
+
+	// The rerun also owns the reused child PipelineRun and Run
+	reusedChild, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get(child.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reused PipelineRun out of fake client: %s", err)
+	}
+	if len(reusedChild.OwnerReferences) != 2 || reusedChild.OwnerReferences[1].UID != rerun.UID {
+		t.Errorf("Expected the rerun to be added to the owners of the reused PipelineRun but got %v", reusedChild.OwnerReferences)
+	}
+	reusedRun, err := clients.Pipeline.Tekton().Runs("foo").Get(runs[0].Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reused Run out of fake client: %s", err)
+	}
+	if len(reusedRun.OwnerReferences) != 2 || reusedRun.OwnerReferences[1].UID != rerun.UID {
+		t.Errorf("Expected the rerun to be added to the owners of the reused Run but got %v", reusedRun.OwnerReferences)
+	}
+}
+
+func TestReconcileRerunWithMatrix(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("unit-test", "hello-world",
+			tb.PipelineTaskMatrix("version", "1.12", "1.13")),
+		tb.PipelineTask("publish", "hello-world", tb.RunAfter("unit-test")),
+	))}
+	previous := tb.PipelineRun("test-pipeline-run", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa")),
+		tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+		})),
+	)
+	trs := []*v1alpha1.TaskRun{
+		tb.TaskRun("test-pipeline-run-unit-test-0", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			})),
+		),
+		tb.TaskRun("test-pipeline-run-unit-test-1", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			})),
+		),
+		tb.TaskRun("test-pipeline-run-publish-abcde", "foo",
+			tb.TaskRunOwnerReference("PipelineRun", "test-pipeline-run"),
+			tb.TaskRunSpec(tb.TaskRunTaskRef("hello-world")),
+			tb.TaskRunStatus(tb.Condition(apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+			})),
+		),
+	}
+	previous.Status.TaskRuns = map[string]*v1alpha1.PipelineRunTaskRunStatus{
+		trs[0].Name: {PipelineTaskName: "unit-test", Status: &trs[0].Status},
+		trs[1].Name: {PipelineTaskName: "unit-test", Status: &trs[1].Status},
+		trs[2].Name: {PipelineTaskName: "publish", Status: &trs[2].Status},
+	}
+	prs := []*v1alpha1.PipelineRun{previous, tb.PipelineRun("test-pipeline-run-rerun", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunRerunOf("test-pipeline-run")),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec(
+		tb.TaskInputs(tb.InputsParam("version", tb.ParamDefault("1.13"))),
+	))}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-rerun"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	// The TaskRuns of all of the combinations of the matrix are reused, so only publish is run again
+	var created []*v1alpha1.TaskRun
+	for _, a := range clients.Pipeline.Actions() {
+		if a.GetVerb() == "create" {
+			created = append(created, a.(ktesting.CreateAction).GetObject().(*v1alpha1.TaskRun))
+		}
+	}
+	if len(created) != 1 {
+		t.Fatalf("Expected a TaskRun to be created for publish but %d TaskRuns were created", len(created))
+	}
+	if !strings.HasPrefix(created[0].Name, "test-pipeline-run-rerun-publish-") {
+		t.Errorf("Expected a TaskRun of publish to be created for the rerun but got %s", created[0].Name)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-rerun", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	for _, name := range []string{"test-pipeline-run-unit-test-0", "test-pipeline-run-unit-test-1"} {
+		if s, ok := reconciledRun.Status.TaskRuns[name]; !ok || s.PipelineTaskName != "unit-test" {
+			t.Errorf("Expected the TaskRun %s of the matrix to be reused but got %v", name, reconciledRun.Status.TaskRuns)
+		}
+	}
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to still be running but condition was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+func TestReconcileRerunOfMissingPipelineRun(t *testing.T) {
+	ps := []*v1alpha1.Pipeline{tb.Pipeline("test-pipeline", "foo", tb.PipelineSpec(
+		tb.PipelineTask("build", "hello-world"),
+	))}
+	prs := []*v1alpha1.PipelineRun{tb.PipelineRun("test-pipeline-run-rerun", "foo",
+		tb.PipelineRunSpec("test-pipeline", tb.PipelineRunServiceAccount("test-sa"),
+			tb.PipelineRunRerunOf("test-pipeline-run")),
+	)}
+	ts := []*v1alpha1.Task{tb.Task("hello-world", "foo", tb.TaskSpec())}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+
+	// create fake recorder for testing
+	fr := record.NewFakeRecorder(2)
+
+	testAssets := getPipelineRunController(d, fr)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), "foo/test-pipeline-run-rerun"); err != nil {
+		t.Fatalf("Did not expect to see error when reconciling PipelineRun but saw %s", err)
+	}
+
+	reconciledRun, err := clients.Pipeline.Tekton().PipelineRuns("foo").Get("test-pipeline-run-rerun", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Somehow had error getting reconciled run out of fake client: %s", err)
+	}
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != ReasonCouldntRerun {
+		t.Errorf("Expected PipelineRun to fail because it couldn't rerun test-pipeline-run but condition was %v", condition)
 	}
 }
@@ -23,36 +23,85 @@ import (
 // ApplyParameters applies the params from a PipelineRun.Params to a PipelineSpec.
 func ApplyParameters(p *v1alpha1.Pipeline, pr *v1alpha1.PipelineRun) *v1alpha1.Pipeline {
 	// This assumes that the PipelineRun inputs have been validated against what the Pipeline requests.
-	replacements := map[string]string{}
+	stringReplacements := map[string]string{}
+	arrayReplacements := map[string][]string{}
+	setReplacement := func(name string, value v1alpha1.ArrayOrString) {
+		key := fmt.Sprintf("params.%s", name)
+		if value.Type == v1alpha1.ParamTypeArray {
+			arrayReplacements[key] = value.ArrayVal
+			delete(stringReplacements, key)
+		} else {
+			stringReplacements[key] = value.StringVal
+			delete(arrayReplacements, key)
+		}
+	}
 	// Set all the default replacements
 	for _, p := range p.Spec.Params {
-		if p.Default != "" {
-			replacements[fmt.Sprintf("params.%s", p.Name)] = p.Default
+		if p.Default != nil {
+			setReplacement(p.Name, *p.Default)
 		}
 	}
 	// Set and overwrite params with the ones from the PipelineRun
 	for _, p := range pr.Spec.Params {
-		replacements[fmt.Sprintf("params.%s", p.Name)] = p.Value
+		setReplacement(p.Name, p.Value)
 	}
 
-	return ApplyReplacements(p, replacements)
+	return ApplyReplacements(p, stringReplacements, arrayReplacements)
 }
 
 // ApplyReplacements replaces placeholders for declared parameters with the specified replacements.
-func ApplyReplacements(p *v1alpha1.Pipeline, replacements map[string]string) *v1alpha1.Pipeline {
+// The elements of the array params and of the command and args of the condition checks which are
+// exactly a placeholder of arrayReplacements are expanded into all of the elements of the array.
+func ApplyReplacements(p *v1alpha1.Pipeline, stringReplacements map[string]string, arrayReplacements map[string][]string) *v1alpha1.Pipeline {
 	p = p.DeepCopy()
 
-	tasks := p.Spec.Tasks
+	applyTaskReplacements(p.Spec.Tasks, stringReplacements, arrayReplacements)
+	applyTaskReplacements(p.Spec.Finally, stringReplacements, arrayReplacements)
 
+	return p
+}
+
+func applyTaskReplacements(tasks []v1alpha1.PipelineTask, stringReplacements map[string]string, arrayReplacements map[string][]string) {
 	for i := range tasks {
 		params := tasks[i].Params
 
 		for j := range params {
-			params[j].Value = templating.ApplyReplacements(params[j].Value, replacements)
+			params[j].Value.ApplyReplacements(stringReplacements, arrayReplacements)
 		}
 
 		tasks[i].Params = params
+
+		for j := range tasks[i].Matrix {
+			tasks[i].Matrix[j].Value.ApplyReplacements(stringReplacements, arrayReplacements)
+		}
+
+		for j := range tasks[i].Conditions {
+			c := &tasks[i].Conditions[j]
+			if c.Expression != nil {
+				c.Expression.Input = templating.ApplyReplacements(c.Expression.Input, stringReplacements)
+				for k := range c.Expression.Values {
+					c.Expression.Values[k] = templating.ApplyReplacements(c.Expression.Values[k], stringReplacements)
+				}
+			}
+			if c.Check != nil {
+				c.Check.Image = templating.ApplyReplacements(c.Check.Image, stringReplacements)
+				c.Check.Command = applyArrayReplacements(c.Check.Command, stringReplacements, arrayReplacements)
+				c.Check.Args = applyArrayReplacements(c.Check.Args, stringReplacements, arrayReplacements)
+				for k := range c.Check.Env {
+					c.Check.Env[k].Value = templating.ApplyReplacements(c.Check.Env[k].Value, stringReplacements)
+				}
+			}
+		}
 	}
+}
 
-	return p
+func applyArrayReplacements(in []string, stringReplacements map[string]string, arrayReplacements map[string][]string) []string {
+	if in == nil {
+		return nil
+	}
+	out := []string{}
+	for _, s := range in {
+		out = append(out, templating.ApplyArrayReplacements(s, stringReplacements, arrayReplacements)...)
+	}
+	return out
 }
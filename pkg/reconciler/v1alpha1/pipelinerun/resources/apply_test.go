@@ -69,6 +69,50 @@ func TestApplyParameters(t *testing.T) {
 						tb.PipelineTaskParam("first-task-first-param", "${input.workspace.default-value}"),
 					))),
 		},
+		{
+			name: "array parameters",
+			original: tb.Pipeline("test-pipeline", "foo",
+				tb.PipelineSpec(
+					tb.PipelineParam("first-param", tb.PipelineParamType(v1alpha1.ParamTypeArray), tb.PipelineParamDefault("default", "array", "value")),
+					tb.PipelineParam("second-param", tb.PipelineParamType(v1alpha1.ParamTypeArray)),
+					tb.PipelineTask("first-task-1", "first-task",
+						tb.PipelineTaskParam("first-task-first-param", "firstelement", "${params.first-param}"),
+						tb.PipelineTaskParam("first-task-second-param", "first", "${params.second-param}"),
+					))),
+			run: tb.PipelineRun("test-pipeline-run", "foo",
+				tb.PipelineRunSpec("test-pipeline",
+					tb.PipelineRunParam("second-param", "second-value", "array"))),
+			expected: tb.Pipeline("test-pipeline", "foo",
+				tb.PipelineSpec(
+					tb.PipelineParam("first-param", tb.PipelineParamType(v1alpha1.ParamTypeArray), tb.PipelineParamDefault("default", "array", "value")),
+					tb.PipelineParam("second-param", tb.PipelineParamType(v1alpha1.ParamTypeArray)),
+					tb.PipelineTask("first-task-1", "first-task",
+						tb.PipelineTaskParam("first-task-first-param", "firstelement", "default", "array", "value"),
+						tb.PipelineTaskParam("first-task-second-param", "first", "second-value", "array"),
+					))),
+		},
+		{
+			name: "matrix parameters",
+			original: tb.Pipeline("test-pipeline", "foo",
+				tb.PipelineSpec(
+					tb.PipelineParam("versions", tb.PipelineParamType(v1alpha1.ParamTypeArray)),
+					tb.PipelineParam("platform", tb.PipelineParamDefault("linux")),
+					tb.PipelineTask("first-task-1", "first-task",
+						tb.PipelineTaskMatrix("version", "${params.versions}"),
+						tb.PipelineTaskMatrix("platform", "${params.platform}", "windows"),
+					))),
+			run: tb.PipelineRun("test-pipeline-run", "foo",
+				tb.PipelineRunSpec("test-pipeline",
+					tb.PipelineRunParam("versions", "1.12", "1.13"))),
+			expected: tb.Pipeline("test-pipeline", "foo",
+				tb.PipelineSpec(
+					tb.PipelineParam("versions", tb.PipelineParamType(v1alpha1.ParamTypeArray)),
+					tb.PipelineParam("platform", tb.PipelineParamDefault("linux")),
+					tb.PipelineTask("first-task-1", "first-task",
+						tb.PipelineTaskMatrix("version", "1.12", "1.13"),
+						tb.PipelineTaskMatrix("platform", "linux", "windows"),
+					))),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,68 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+// ApprovalState holds the decisions given about the next tasks of a PipelineRun which
+// are gated behind an approval.
+type ApprovalState struct {
+	// Pending are the PipelineTasks still waiting for a decision
+	Pending []v1alpha1.PipelineRunPendingApproval
+	// Rejected is the first decision rejecting one of the PipelineTasks, if any
+	Rejected *v1alpha1.PipelineRunApproval
+}
+
+// ResolveApprovals returns the tasks in rprts which can be started, because they don't
+// need an approval or have been approved in approvals, and the state of the approvals
+// of the other ones. The tasks of a PipelineTask with a matrix share the same approval.
+func ResolveApprovals(rprts []*ResolvedPipelineRunTask, approvals []v1alpha1.PipelineRunApproval) ([]*ResolvedPipelineRunTask, ApprovalState) {
+	decisions := map[string]v1alpha1.PipelineRunApproval{}
+	for _, a := range approvals {
+		decisions[a.PipelineTask] = a
+	}
+
+	approved := []*ResolvedPipelineRunTask{}
+	state := ApprovalState{}
+	seen := map[string]struct{}{}
+	for _, rprt := range rprts {
+		if rprt.PipelineTask.Approval == nil {
+			approved = append(approved, rprt)
+			continue
+		}
+		decision, ok := decisions[rprt.PipelineTask.Name]
+		switch {
+		case ok && decision.Decision == v1alpha1.ApprovalDecisionApproved:
+			approved = append(approved, rprt)
+		case ok:
+			if state.Rejected == nil {
+				state.Rejected = &decision
+			}
+		default:
+			if _, ok := seen[rprt.PipelineTask.Name]; !ok {
+				seen[rprt.PipelineTask.Name] = struct{}{}
+				state.Pending = append(state.Pending, v1alpha1.PipelineRunPendingApproval{
+					PipelineTask: rprt.PipelineTask.Name,
+					Approvers:    rprt.PipelineTask.Approval.Approvers,
+				})
+			}
+		}
+	}
+	return approved, state
+}
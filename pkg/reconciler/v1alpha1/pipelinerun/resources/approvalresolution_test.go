@@ -0,0 +1,88 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+func approvalTask(name string, approvers ...string) *v1alpha1.PipelineTask {
+	return &v1alpha1.PipelineTask{
+		Name:     name,
+		TaskRef:  v1alpha1.TaskRef{Name: "task"},
+		Approval: &v1alpha1.PipelineTaskApproval{Approvers: approvers},
+	}
+}
+
+func TestResolveApprovals(t *testing.T) {
+	build := &ResolvedPipelineRunTask{TaskRunName: "pr-build", PipelineTask: &v1alpha1.PipelineTask{Name: "build"}}
+	deploy := &ResolvedPipelineRunTask{TaskRunName: "pr-deploy", PipelineTask: approvalTask("deploy", "alice", "bob")}
+	// The TaskRuns of a PipelineTask with a matrix share its approval
+	releaseA := &ResolvedPipelineRunTask{TaskRunName: "pr-release-a", PipelineTask: approvalTask("release", "carol")}
+	releaseB := &ResolvedPipelineRunTask{TaskRunName: "pr-release-b", PipelineTask: approvalTask("release", "carol")}
+	rprts := []*ResolvedPipelineRunTask{build, deploy, releaseA, releaseB}
+
+	tests := []struct {
+		name         string
+		approvals    []v1alpha1.PipelineRunApproval
+		wantApproved []*ResolvedPipelineRunTask
+		wantState    ApprovalState
+	}{{
+		name:         "no decisions",
+		wantApproved: []*ResolvedPipelineRunTask{build},
+		wantState: ApprovalState{
+			Pending: []v1alpha1.PipelineRunPendingApproval{
+				{PipelineTask: "deploy", Approvers: []string{"alice", "bob"}},
+				{PipelineTask: "release", Approvers: []string{"carol"}},
+			},
+		},
+	}, {
+		name: "approved",
+		approvals: []v1alpha1.PipelineRunApproval{
+			{PipelineTask: "deploy", Decision: v1alpha1.ApprovalDecisionApproved, Approver: "bob"},
+			{PipelineTask: "release", Decision: v1alpha1.ApprovalDecisionApproved, Approver: "carol"},
+		},
+		wantApproved: []*ResolvedPipelineRunTask{build, deploy, releaseA, releaseB},
+	}, {
+		name: "rejected",
+		approvals: []v1alpha1.PipelineRunApproval{
+			{PipelineTask: "deploy", Decision: v1alpha1.ApprovalDecisionRejected, Approver: "alice"},
+		},
+		wantApproved: []*ResolvedPipelineRunTask{build},
+		wantState: ApprovalState{
+			Pending: []v1alpha1.PipelineRunPendingApproval{
+				{PipelineTask: "release", Approvers: []string{"carol"}},
+			},
+			Rejected: &v1alpha1.PipelineRunApproval{PipelineTask: "deploy", Decision: v1alpha1.ApprovalDecisionRejected, Approver: "alice"},
+		},
+	}}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			approved, state := ResolveApprovals(rprts, tc.approvals)
+			if d := cmp.Diff(tc.wantApproved, approved); d != "" {
+				t.Errorf("Unexpected approved tasks -want, +got: %v", d)
+			}
+			if d := cmp.Diff(tc.wantState, state); d != "" {
+				t.Errorf("Unexpected state of the approvals -want, +got: %v", d)
+			}
+		})
+	}
+}
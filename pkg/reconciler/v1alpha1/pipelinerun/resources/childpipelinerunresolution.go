@@ -0,0 +1,190 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/names"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// MaxChildPipelineDepth is the maximum number of Pipelines run above a child PipelineRun.
+const MaxChildPipelineDepth = 10
+
+// GetPipelineRun is a function that will retrieve the PipelineRun name.
+type GetPipelineRun func(name string) (*v1alpha1.PipelineRun, error)
+
+// PipelineNotFoundError indicates that the resolution failed because a Pipeline referenced by a
+// PipelineTask couldn't be retrieved
+type PipelineNotFoundError struct {
+	Name string
+	Msg  string
+}
+
+func (e *PipelineNotFoundError) Error() string {
+	return fmt.Sprintf("Couldn't retrieve Pipeline %q: %s", e.Name, e.Msg)
+}
+
+// IsPipeline returns true if the PipelineTask of t runs another Pipeline, in a child PipelineRun
+// named TaskRunName, instead of a Task.
+func (t *ResolvedPipelineRunTask) IsPipeline() bool {
+	return t.PipelineTask != nil && t.PipelineTask.TaskRef.Kind == v1alpha1.PipelineKind && t.PipelineTask.TaskSpec == nil &&
+		!t.PipelineTask.TaskRef.IsCustomTask()
+}
+
+// IsStarted returns true if the TaskRun, the child PipelineRun or the Run running t has been created.
+func (t *ResolvedPipelineRunTask) IsStarted() bool {
+	return t.TaskRun != nil || t.PipelineRun != nil || t.Run != nil
+}
+
+// getSucceededCondition returns the Succeeded condition of the TaskRun, the child PipelineRun or
+// the Run running t, or nil if it hasn't been created yet.
+func (t *ResolvedPipelineRunTask) getSucceededCondition() *apis.Condition {
+	switch {
+	case t.TaskRun != nil:
+		return t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
+	case t.PipelineRun != nil:
+		return t.PipelineRun.Status.GetCondition(apis.ConditionSucceeded)
+	case t.Run != nil:
+		return t.Run.Status.GetCondition(apis.ConditionSucceeded)
+	}
+	return nil
+}
+
+// isDone returns true if the TaskRun, the child PipelineRun or the Run running t has finished executing.
+func (t *ResolvedPipelineRunTask) isDone() bool {
+	return t.IsStarted() && !t.getSucceededCondition().IsUnknown()
+}
+
+// isFailed returns true if the TaskRun, the child PipelineRun or the Run running t has failed.
+func (t *ResolvedPipelineRunTask) isFailed() bool {
+	return t.IsStarted() && t.getSucceededCondition().IsFalse()
+}
+
+// describeRun returns the kind and the name of the TaskRun, the child PipelineRun or the Run running t.
+func (t *ResolvedPipelineRunTask) describeRun() string {
+	if t.IsPipeline() {
+		return fmt.Sprintf("PipelineRun %s", t.TaskRunName)
+	}
+	if t.IsCustomTask() {
+		return fmt.Sprintf("Run %s", t.TaskRunName)
+	}
+	return fmt.Sprintf("TaskRun %s", t.TaskRunName)
+}
+
+// resolveChildPipeline ensures that the Pipeline run by the PipelineTask pt can be retrieved
+// with getPipeline, and that it isn't run by pipelineRun or any of the PipelineRuns above it,
+// which are limited to MaxChildPipelineDepth.
+func resolveChildPipeline(pipelineRun v1alpha1.PipelineRun, getPipeline GetPipeline, pt v1alpha1.PipelineTask) error {
+	ancestors := GetAncestorPipelines(&pipelineRun)
+	for _, name := range ancestors {
+		if pt.TaskRef.Name == name {
+			return fmt.Errorf("PipelineTask %s can't run Pipeline %s, which it is part of", pt.Name, pt.TaskRef.Name)
+		}
+	}
+	if len(ancestors) >= MaxChildPipelineDepth {
+		return fmt.Errorf("PipelineTask %s can't run Pipeline %s, which would be nested more than %d PipelineRuns deep", pt.Name, pt.TaskRef.Name, MaxChildPipelineDepth)
+	}
+	if _, err := getPipeline(pt.TaskRef.Name); err != nil {
+		return &PipelineNotFoundError{
+			Name: pt.TaskRef.Name,
+			Msg:  err.Error(),
+		}
+	}
+	return nil
+}
+
+// GetAncestorPipelines returns the names of the Pipelines run by pr and the PipelineRuns above
+// it, from the outermost one. The Pipelines embedded in the spec of a PipelineRun have no name,
+// and can't be run by a PipelineTask, so they are omitted.
+func GetAncestorPipelines(pr *v1alpha1.PipelineRun) []string {
+	var ancestors []string
+	if a := pr.Annotations[pipeline.GroupName+pipeline.AncestorPipelinesAnnotationKey]; a != "" {
+		ancestors = strings.Split(a, ",")
+	}
+	if pr.Spec.PipelineRef.Name != "" {
+		ancestors = append(ancestors, pr.Spec.PipelineRef.Name)
+	}
+	return ancestors
+}
+
+// ResolveChildPipelineRuns will go through all tasks in state which run a Pipeline and check if
+// their child PipelineRuns exist by calling getPipelineRun.
+func ResolveChildPipelineRuns(getPipelineRun GetPipelineRun, state PipelineRunState) error {
+	for _, rprt := range state {
+		if !rprt.IsPipeline() {
+			continue
+		}
+		pipelineRun, err := getPipelineRun(rprt.TaskRunName)
+		if err != nil {
+			// If the PipelineRun isn't found, it just means it hasn't been run yet
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving PipelineRun %s: %s", rprt.TaskRunName, err)
+			}
+			continue
+		}
+		rprt.PipelineRun = pipelineRun
+	}
+	return nil
+}
+
+// GetChildPipelineRunResources returns the bindings of the PipelineResources which rprt, running
+// a Pipeline, passes to its child PipelineRun, sorted by name. The resources which are bound to a
+// spec in bindings, instead of a reference, are bound to that spec.
+func GetChildPipelineRunResources(rprt *ResolvedPipelineRunTask, bindings []v1alpha1.PipelineResourceBinding) []v1alpha1.PipelineResourceBinding {
+	inputSpecs, outputSpecs := getResourceSpecs(rprt.PipelineTask, bindings)
+	bound := map[string]*v1alpha1.PipelineResource{}
+	for name, r := range rprt.ResolvedTaskResources.Inputs {
+		bound[name] = r
+	}
+	for name, r := range rprt.ResolvedTaskResources.Outputs {
+		bound[name] = r
+	}
+	childBindings := []v1alpha1.PipelineResourceBinding{}
+	for name, r := range bound {
+		if spec, ok := outputSpecs[name]; ok {
+			childBindings = append(childBindings, v1alpha1.PipelineResourceBinding{Name: name, ResourceSpec: spec})
+		} else if spec, ok := inputSpecs[name]; ok {
+			childBindings = append(childBindings, v1alpha1.PipelineResourceBinding{Name: name, ResourceSpec: spec})
+		} else {
+			childBindings = append(childBindings, v1alpha1.PipelineResourceBinding{
+				Name:        name,
+				ResourceRef: v1alpha1.PipelineResourceRef{Name: r.Name},
+			})
+		}
+	}
+	sort.Slice(childBindings, func(i, j int) bool { return childBindings[i].Name < childBindings[j].Name })
+	return childBindings
+}
+
+// getChildPipelineRunName should return a unique name for the child `PipelineRun` of a PipelineTask
+// running a Pipeline if one has not already been defined, and the existing one otherwise.
+func getChildPipelineRunName(childStatus map[string]*v1alpha1.PipelineRunChildStatus, ptName, prName string) string {
+	for k, v := range childStatus {
+		if v.PipelineTaskName == ptName {
+			return k
+		}
+	}
+
+	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	"github.com/tektoncd/pipeline/test/names"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var childPipelineTask = v1alpha1.PipelineTask{
+	Name:    "mypipeline1",
+	TaskRef: v1alpha1.TaskRef{Name: "child-pipeline", Kind: v1alpha1.PipelineKind},
+}
+
+func TestResolvePipelineRun_ChildPipeline(t *testing.T) {
+	names.TestingSeed()
+
+	pts := []v1alpha1.PipelineTask{childPipelineTask}
+	pts[0].Resources = &v1alpha1.PipelineTaskResources{
+		Inputs: []v1alpha1.PipelineTaskInputResource{{Name: "source", Resource: "git-resource"}},
+	}
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{
+		"git-resource": {Name: "git-resource", ResourceRef: v1alpha1.PipelineResourceRef{Name: "someresource"}},
+	}
+	r := tb.PipelineResource("someresource", namespace, tb.PipelineResourceSpec(v1alpha1.PipelineResourceTypeGit))
+	pr := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+		Spec:       v1alpha1.PipelineRunSpec{PipelineRef: v1alpha1.PipelineRef{Name: "pipeline"}},
+	}
+	getTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getChildPipeline := func(name string) (*v1alpha1.Pipeline, error) {
+		return tb.Pipeline(name, namespace, tb.PipelineSpec()), nil
+	}
+	getResource := func(name string) (*v1alpha1.PipelineResource, error) { return r, nil }
+
+	state, err := ResolvePipelineRun(pr, getTask, getClusterTask, getChildPipeline, getResource, pts, providedResources)
+	if err != nil {
+		t.Fatalf("Didn't expect error resolving the PipelineRun but got %v", err)
+	}
+	if len(state) != 1 {
+		t.Fatalf("Expected one task to be resolved but got %d", len(state))
+	}
+	rprt := state[0]
+	if !rprt.IsPipeline() {
+		t.Errorf("Expected the task to run a Pipeline")
+	}
+	if rprt.TaskRunName != "pipelinerun-mypipeline1-9l9zj" {
+		t.Errorf("Expected the child PipelineRun to be named pipelinerun-mypipeline1-9l9zj but was %s", rprt.TaskRunName)
+	}
+	if rprt.ResolvedTaskResources.TaskSpec != nil {
+		t.Errorf("Expected no TaskSpec to be resolved but got %v", rprt.ResolvedTaskResources.TaskSpec)
+	}
+	if rprt.ResolvedTaskResources.TaskName != "child-pipeline" {
+		t.Errorf("Expected the name of the Pipeline to be resolved but got %s", rprt.ResolvedTaskResources.TaskName)
+	}
+	expectedBindings := []v1alpha1.PipelineResourceBinding{{
+		Name:        "source",
+		ResourceRef: v1alpha1.PipelineResourceRef{Name: "someresource"},
+	}}
+	if d := cmp.Diff(expectedBindings, GetChildPipelineRunResources(rprt, nil)); d != "" {
+		t.Errorf("Unexpected resources of the child PipelineRun -want, +got: %v", d)
+	}
+}
+
+func TestResolvePipelineRun_ChildPipelineExistingName(t *testing.T) {
+	pr := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+		Status: v1alpha1.PipelineRunStatus{
+			ChildPipelineRuns: map[string]*v1alpha1.PipelineRunChildStatus{
+				"pipelinerun-mypipeline1-abcde": {PipelineTaskName: "mypipeline1"},
+			},
+		},
+	}
+	getChildPipeline := func(name string) (*v1alpha1.Pipeline, error) {
+		return tb.Pipeline(name, namespace, tb.PipelineSpec()), nil
+	}
+	state, err := ResolvePipelineRun(pr, nil, nil, getChildPipeline, nil, []v1alpha1.PipelineTask{childPipelineTask}, nil)
+	if err != nil {
+		t.Fatalf("Didn't expect error resolving the PipelineRun but got %v", err)
+	}
+	if state[0].TaskRunName != "pipelinerun-mypipeline1-abcde" {
+		t.Errorf("Expected the existing child PipelineRun name to be used but got %s", state[0].TaskRunName)
+	}
+}
+
+func TestResolvePipelineRun_ChildPipelineDoesntExist(t *testing.T) {
+	pr := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+	}
+	getChildPipeline := func(name string) (*v1alpha1.Pipeline, error) {
+		return nil, errors.NewNotFound(v1alpha1.Resource("pipeline"), name)
+	}
+	_, err := ResolvePipelineRun(pr, nil, nil, getChildPipeline, nil, []v1alpha1.PipelineTask{childPipelineTask}, nil)
+	if _, ok := err.(*PipelineNotFoundError); !ok {
+		t.Fatalf("Expected a PipelineNotFoundError for a non-existent Pipeline but got %v", err)
+	}
+}
+
+func TestResolvePipelineRun_ChildPipelineRunsItself(t *testing.T) {
+	pr := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+		Spec:       v1alpha1.PipelineRunSpec{PipelineRef: v1alpha1.PipelineRef{Name: "child-pipeline"}},
+	}
+	getChildPipeline := func(name string) (*v1alpha1.Pipeline, error) {
+		return tb.Pipeline(name, namespace, tb.PipelineSpec()), nil
+	}
+	if _, err := ResolvePipelineRun(pr, nil, nil, getChildPipeline, nil, []v1alpha1.PipelineTask{childPipelineTask}, nil); err == nil {
+		t.Fatalf("Expected an error for a PipelineTask running the Pipeline it is part of")
+	}
+}
+
+func TestResolvePipelineRun_ChildPipelineIndirectCycle(t *testing.T) {
+	// pipelinerun runs other-pipeline as a child of child-pipeline, which can't be run again
+	pr := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pipelinerun-other",
+			Annotations: map[string]string{"tekton.dev/ancestorPipelines": "pipeline,child-pipeline"},
+		},
+		Spec: v1alpha1.PipelineRunSpec{PipelineRef: v1alpha1.PipelineRef{Name: "other-pipeline"}},
+	}
+	getChildPipeline := func(name string) (*v1alpha1.Pipeline, error) {
+		return tb.Pipeline(name, namespace, tb.PipelineSpec()), nil
+	}
+	if _, err := ResolvePipelineRun(pr, nil, nil, getChildPipeline, nil, []v1alpha1.PipelineTask{childPipelineTask}, nil); err == nil {
+		t.Fatalf("Expected an error for a PipelineTask running a Pipeline above it")
+	}
+}
+
+func TestResolvePipelineRun_ChildPipelineOfEmbeddedSpec(t *testing.T) {
+	// The Pipeline embedded in the outermost PipelineRun has no name to be run again
+	pr := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pipelinerun-other",
+			Annotations: map[string]string{"tekton.dev/ancestorPipelines": "child-pipeline"},
+		},
+		Spec: v1alpha1.PipelineRunSpec{PipelineRef: v1alpha1.PipelineRef{Name: "other-pipeline"}},
+	}
+	if d := cmp.Diff([]string{"child-pipeline", "other-pipeline"}, GetAncestorPipelines(&pr)); d != "" {
+		t.Errorf("Unexpected ancestor pipelines -want, +got: %v", d)
+	}
+	embedded := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+		Spec:       v1alpha1.PipelineRunSpec{PipelineSpec: &v1alpha1.PipelineSpec{}},
+	}
+	if ancestors := GetAncestorPipelines(&embedded); len(ancestors) != 0 {
+		t.Errorf("Expected no ancestor pipelines for an embedded spec but got %v", ancestors)
+	}
+
+	getChildPipeline := func(name string) (*v1alpha1.Pipeline, error) {
+		return tb.Pipeline(name, namespace, tb.PipelineSpec()), nil
+	}
+	if _, err := ResolvePipelineRun(pr, nil, nil, getChildPipeline, nil, []v1alpha1.PipelineTask{childPipelineTask}, nil); err == nil {
+		t.Fatalf("Expected an error for a PipelineTask running a Pipeline above it")
+	}
+}
+
+func TestResolvePipelineRun_ChildPipelineTooDeep(t *testing.T) {
+	ancestors := []string{}
+	for i := 0; i < MaxChildPipelineDepth-1; i++ {
+		ancestors = append(ancestors, fmt.Sprintf("pipeline-%d", i))
+	}
+	pr := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pipelinerun-deep",
+			Annotations: map[string]string{"tekton.dev/ancestorPipelines": strings.Join(ancestors, ",")},
+		},
+		Spec: v1alpha1.PipelineRunSpec{PipelineRef: v1alpha1.PipelineRef{Name: "other-pipeline"}},
+	}
+	getChildPipeline := func(name string) (*v1alpha1.Pipeline, error) {
+		return tb.Pipeline(name, namespace, tb.PipelineSpec()), nil
+	}
+	if _, err := ResolvePipelineRun(pr, nil, nil, getChildPipeline, nil, []v1alpha1.PipelineTask{childPipelineTask}, nil); err == nil {
+		t.Fatalf("Expected an error for a child PipelineRun nested more than %d deep", MaxChildPipelineDepth)
+	}
+}
+
+func TestResolveChildPipelineRuns(t *testing.T) {
+	child := tb.PipelineRun("pipelinerun-mypipeline1", namespace, tb.PipelineRunSpec("child-pipeline"))
+	state := PipelineRunState{{
+		PipelineTask: &childPipelineTask,
+		TaskRunName:  "pipelinerun-mypipeline1",
+	}, {
+		PipelineTask: &pts[0],
+		TaskRunName:  "pipelinerun-mytask1",
+	}}
+	getPipelineRun := func(name string) (*v1alpha1.PipelineRun, error) {
+		if name == child.Name {
+			return child, nil
+		}
+		return nil, fmt.Errorf("should not get called for %s", name)
+	}
+	if err := ResolveChildPipelineRuns(getPipelineRun, state); err != nil {
+		t.Fatalf("Didn't expect error resolving child PipelineRuns but got %v", err)
+	}
+	if state[0].PipelineRun != child {
+		t.Errorf("Expected the child PipelineRun to be resolved but was %v", state[0].PipelineRun)
+	}
+	if !state[0].IsStarted() {
+		t.Errorf("Expected the task running a Pipeline to be started")
+	}
+	if state[1].PipelineRun != nil {
+		t.Errorf("Expected no PipelineRun to be resolved for a task running a Task but got %v", state[1].PipelineRun)
+	}
+}
+
+func TestResolveChildPipelineRuns_NotStarted(t *testing.T) {
+	state := PipelineRunState{{
+		PipelineTask: &childPipelineTask,
+		TaskRunName:  "pipelinerun-mypipeline1",
+	}}
+	getPipelineRun := func(name string) (*v1alpha1.PipelineRun, error) {
+		return nil, errors.NewNotFound(v1alpha1.Resource("pipelinerun"), name)
+	}
+	if err := ResolveChildPipelineRuns(getPipelineRun, state); err != nil {
+		t.Fatalf("Didn't expect error resolving child PipelineRuns but got %v", err)
+	}
+	if state[0].IsStarted() {
+		t.Errorf("Expected the task running a Pipeline not to be started")
+	}
+
+	getPipelineRun = func(name string) (*v1alpha1.PipelineRun, error) {
+		return nil, fmt.Errorf("something has gone wrong")
+	}
+	if err := ResolveChildPipelineRuns(getPipelineRun, state); err == nil {
+		t.Fatalf("Expected to get an error when unable to resolve child PipelineRuns")
+	}
+}
+
+func TestGetPipelineConditionStatus_ChildPipeline(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		status          corev1.ConditionStatus
+		expectedStatus  corev1.ConditionStatus
+		expectedMessage string
+	}{{
+		name:           "running",
+		status:         corev1.ConditionUnknown,
+		expectedStatus: corev1.ConditionUnknown,
+	}, {
+		name:           "succeeded",
+		status:         corev1.ConditionTrue,
+		expectedStatus: corev1.ConditionTrue,
+	}, {
+		name:            "failed",
+		status:          corev1.ConditionFalse,
+		expectedStatus:  corev1.ConditionFalse,
+		expectedMessage: "PipelineRun pipelinerun-mypipeline1 has failed",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			child := tb.PipelineRun("pipelinerun-mypipeline1", namespace, tb.PipelineRunSpec("child-pipeline"),
+				tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
+					Type:   apis.ConditionSucceeded,
+					Status: tc.status,
+				})))
+			state := PipelineRunState{{
+				PipelineTask: &childPipelineTask,
+				TaskRunName:  child.Name,
+				PipelineRun:  child,
+			}}
+			c := GetPipelineConditionStatus("somepipelinerun", state, zap.NewNop().Sugar(), &metav1.Time{Time: time.Now()}, nil, v1alpha1.FailurePolicyFailFast)
+			if c.Status != tc.expectedStatus {
+				t.Fatalf("Expected to get status %s but got %s", tc.expectedStatus, c.Status)
+			}
+			if tc.expectedMessage != "" && c.Message != tc.expectedMessage {
+				t.Errorf("Expected message %q but got %q", tc.expectedMessage, c.Message)
+			}
+		})
+	}
+}
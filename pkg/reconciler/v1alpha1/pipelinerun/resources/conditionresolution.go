@@ -0,0 +1,206 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/names"
+)
+
+// ResolvedConditionCheck contains a PipelineTaskCondition and, for conditions
+// that run a check container, the TaskRun running that check if it exists.
+type ResolvedConditionCheck struct {
+	ConditionCheckName string
+	Condition          *v1alpha1.PipelineTaskCondition
+	TaskRun            *v1alpha1.TaskRun
+}
+
+// TaskConditionCheckState is a slice of ResolvedConditionChecks that represents
+// the current state of the conditions guarding a PipelineTask.
+type TaskConditionCheckState []*ResolvedConditionCheck
+
+// IsDone returns true if the condition has been evaluated.
+func (rcc *ResolvedConditionCheck) IsDone() bool {
+	if rcc.Condition.Expression != nil {
+		return true
+	}
+	return rcc.TaskRun != nil && rcc.TaskRun.IsDone()
+}
+
+// IsMet returns true if the condition has been evaluated and was met.
+func (rcc *ResolvedConditionCheck) IsMet() bool {
+	if rcc.Condition.Expression != nil {
+		return rcc.Condition.Expression.IsTrue()
+	}
+	return rcc.TaskRun != nil && rcc.TaskRun.Status.GetCondition(apis.ConditionSucceeded).IsTrue()
+}
+
+// IsSuccess returns true if all of the conditions have been evaluated and were met.
+func (state TaskConditionCheckState) IsSuccess() bool {
+	for _, rcc := range state {
+		if !rcc.IsDone() || !rcc.IsMet() {
+			return false
+		}
+	}
+	return true
+}
+
+// HasStarted returns true if a TaskRun has been created for any of the condition checks.
+func (state TaskConditionCheckState) HasStarted() bool {
+	for _, rcc := range state {
+		if rcc.TaskRun != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// failureReason returns a message describing the first condition which was evaluated
+// and wasn't met, or an empty string if there is no such condition.
+func (state TaskConditionCheckState) failureReason() string {
+	for _, rcc := range state {
+		if !rcc.IsDone() || rcc.IsMet() {
+			continue
+		}
+		if rcc.Condition.Expression != nil {
+			return fmt.Sprintf("condition %q was not met: %s", rcc.Condition.Name, rcc.Condition.Expression)
+		}
+		return fmt.Sprintf("condition %q was not met: check %s failed", rcc.Condition.Name, rcc.ConditionCheckName)
+	}
+	return ""
+}
+
+// IsSkipped returns true if the PipelineTask won't be run, either because one of its
+// conditions wasn't met or because a PipelineTask it depends on was skipped.
+func (t *ResolvedPipelineRunTask) IsSkipped() bool {
+	return t.SkipReason != ""
+}
+
+// MarkSkippedTasks sets the SkipReason of every PipelineTask in state which won't be run
+// because one of its conditions, or one of the conditions of its ancestors in d, wasn't met.
+func (state PipelineRunState) MarkSkippedTasks(d *v1alpha1.DAG) {
+	// The combinations of the matrix of a PipelineTask are all resolved under its name
+	tasks := make(map[string][]*ResolvedPipelineRunTask, len(state))
+	for _, t := range state {
+		tasks[t.PipelineTask.Name] = append(tasks[t.PipelineTask.Name], t)
+	}
+	visited := map[*ResolvedPipelineRunTask]struct{}{}
+	var mark func(t *ResolvedPipelineRunTask)
+	mark = func(t *ResolvedPipelineRunTask) {
+		if _, ok := visited[t]; ok {
+			return
+		}
+		visited[t] = struct{}{}
+		// A Task which has already been started can't be skipped anymore
+		if t.IsStarted() {
+			return
+		}
+		if reason := t.ResolvedConditionChecks.failureReason(); reason != "" {
+			t.SkipReason = reason
+			return
+		}
+		node, ok := d.Nodes[t.PipelineTask.Name]
+		if !ok {
+			return
+		}
+		for _, prev := range node.Prev {
+			for _, parent := range tasks[prev.Task.Name] {
+				mark(parent)
+				if parent.IsSkipped() {
+					t.SkipReason = fmt.Sprintf("parent task %q was skipped", parent.PipelineTask.Name)
+					return
+				}
+			}
+		}
+	}
+	for _, t := range state {
+		mark(t)
+	}
+}
+
+// MarkTasksAfterFailures sets the SkipReason of every PipelineTask in state which won't be
+// run because one of the tasks it runs after has failed. The tasks running after these ones
+// are then skipped in turn by MarkSkippedTasks.
+func (state PipelineRunState) MarkTasksAfterFailures(d *v1alpha1.DAG) {
+	failed := map[string]struct{}{}
+	for _, t := range state {
+		if t.isFailed() {
+			failed[t.PipelineTask.Name] = struct{}{}
+		}
+	}
+	for _, t := range state {
+		node, ok := d.Nodes[t.PipelineTask.Name]
+		if !ok || t.IsStarted() {
+			continue
+		}
+		for _, prev := range node.Prev {
+			if _, ok := failed[prev.Task.Name]; ok {
+				t.SkipReason = fmt.Sprintf("parent task %q failed", prev.Task.Name)
+				break
+			}
+		}
+	}
+}
+
+// GetSkippedTasks returns the list of PipelineTasks in state which were skipped, along
+// with the reason why.
+func (state PipelineRunState) GetSkippedTasks() []v1alpha1.SkippedTask {
+	var skipped []v1alpha1.SkippedTask
+	seen := map[string]struct{}{}
+	for _, t := range state {
+		if _, ok := seen[t.PipelineTask.Name]; ok {
+			continue
+		}
+		if t.IsSkipped() {
+			seen[t.PipelineTask.Name] = struct{}{}
+			skipped = append(skipped, v1alpha1.SkippedTask{
+				Name:   t.PipelineTask.Name,
+				Reason: t.SkipReason,
+			})
+		}
+	}
+	return skipped
+}
+
+func resolveConditionChecks(pt *v1alpha1.PipelineTask, checksStatus map[string]*v1alpha1.PipelineRunConditionCheckStatus, taskRunName string) TaskConditionCheckState {
+	var state TaskConditionCheckState
+	for i := range pt.Conditions {
+		c := &pt.Conditions[i]
+		rcc := &ResolvedConditionCheck{
+			Condition: c,
+		}
+		if c.Check != nil {
+			rcc.ConditionCheckName = getConditionCheckName(checksStatus, taskRunName, c.Name)
+		}
+		state = append(state, rcc)
+	}
+	return state
+}
+
+// getConditionCheckName should return a unique name for the TaskRun checking a condition if one
+// has not already been defined in checksStatus, and the existing one otherwise.
+func getConditionCheckName(checksStatus map[string]*v1alpha1.PipelineRunConditionCheckStatus, trName, conditionName string) string {
+	for k, v := range checksStatus {
+		if v.ConditionName == conditionName {
+			return k
+		}
+	}
+	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", trName, conditionName))
+}
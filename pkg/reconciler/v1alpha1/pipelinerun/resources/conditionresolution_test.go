@@ -0,0 +1,225 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/resources"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var conditionCheckTaskRun = v1alpha1.TaskRun{
+	ObjectMeta: metav1.ObjectMeta{
+		Namespace: "namespace",
+		Name:      "pipelinerun-mytask1-is-main",
+	},
+}
+
+func conditionState(checkTaskRun *v1alpha1.TaskRun, expression *v1alpha1.ConditionExpression) PipelineRunState {
+	guarded := tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+		tb.PipelineTask("mytask1", "task"),
+		tb.PipelineTask("mytask2", "task", tb.RunAfter("mytask1")),
+		tb.PipelineTask("mytask3", "task"),
+	)).Spec.Tasks
+	rcc := &ResolvedConditionCheck{
+		ConditionCheckName: "pipelinerun-mytask1-is-main",
+		Condition: &v1alpha1.PipelineTaskCondition{
+			Name:       "is-main",
+			Check:      &corev1.Container{Image: "busybox"},
+			Expression: expression,
+		},
+		TaskRun: checkTaskRun,
+	}
+	if expression != nil {
+		rcc.ConditionCheckName = ""
+		rcc.Condition.Check = nil
+	}
+	state := PipelineRunState{}
+	for i := range guarded {
+		state = append(state, &ResolvedPipelineRunTask{
+			PipelineTask: &guarded[i],
+			TaskRunName:  "pipelinerun-" + guarded[i].Name,
+			ResolvedTaskResources: &resources.ResolvedTaskResources{
+				TaskSpec: &task.Spec,
+			},
+		})
+	}
+	state[0].ResolvedConditionChecks = TaskConditionCheckState{rcc}
+	return state
+}
+
+func TestMarkSkippedTasks(t *testing.T) {
+	tcs := []struct {
+		name            string
+		state           PipelineRunState
+		expectedSkipped []v1alpha1.SkippedTask
+		expectedNext    []string
+	}{{
+		name:         "check-not-started",
+		state:        conditionState(nil, nil),
+		expectedNext: []string{"mytask1", "mytask3"},
+	}, {
+		name:         "check-running",
+		state:        conditionState(makeStarted(conditionCheckTaskRun), nil),
+		expectedNext: []string{"mytask1", "mytask3"},
+	}, {
+		name:         "check-succeeded",
+		state:        conditionState(makeSucceeded(conditionCheckTaskRun), nil),
+		expectedNext: []string{"mytask1", "mytask3"},
+	}, {
+		name:  "check-failed",
+		state: conditionState(makeFailed(conditionCheckTaskRun), nil),
+		expectedSkipped: []v1alpha1.SkippedTask{{
+			Name:   "mytask1",
+			Reason: `condition "is-main" was not met: check pipelinerun-mytask1-is-main failed`,
+		}, {
+			Name:   "mytask2",
+			Reason: `parent task "mytask1" was skipped`,
+		}},
+		expectedNext: []string{"mytask3"},
+	}, {
+		name: "expression-met",
+		state: conditionState(nil, &v1alpha1.ConditionExpression{
+			Input: "main", Operator: v1alpha1.ConditionOperatorIn, Values: []string{"main"},
+		}),
+		expectedNext: []string{"mytask1", "mytask3"},
+	}, {
+		name: "expression-not-met",
+		state: conditionState(nil, &v1alpha1.ConditionExpression{
+			Input: "feature", Operator: v1alpha1.ConditionOperatorIn, Values: []string{"main"},
+		}),
+		expectedSkipped: []v1alpha1.SkippedTask{{
+			Name:   "mytask1",
+			Reason: `condition "is-main" was not met: "feature" in [main]`,
+		}, {
+			Name:   "mytask2",
+			Reason: `parent task "mytask1" was skipped`,
+		}},
+		expectedNext: []string{"mytask3"},
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := v1alpha1.BuildDAG([]v1alpha1.PipelineTask{*tc.state[0].PipelineTask, *tc.state[1].PipelineTask, *tc.state[2].PipelineTask})
+			if err != nil {
+				t.Fatalf("Unexpected error building DAG: %v", err)
+			}
+			tc.state.MarkSkippedTasks(d)
+			if diff := cmp.Diff(tc.expectedSkipped, tc.state.GetSkippedTasks()); diff != "" {
+				t.Errorf("Unexpected skipped tasks: %s", diff)
+			}
+
+			candidates := map[string]v1alpha1.PipelineTask{
+				"mytask1": *tc.state[0].PipelineTask,
+				"mytask3": *tc.state[2].PipelineTask,
+			}
+			next := []string{}
+			for _, rprt := range tc.state.GetNextTasks(candidates) {
+				next = append(next, rprt.PipelineTask.Name)
+			}
+			if diff := cmp.Diff(tc.expectedNext, next); diff != "" {
+				t.Errorf("Unexpected next tasks: %s", diff)
+			}
+		})
+	}
+}
+
+func TestMarkSkippedTasks_Matrix(t *testing.T) {
+	state := conditionState(makeFailed(conditionCheckTaskRun), nil)
+	// mytask2 has a matrix with two combinations
+	combinations := PipelineRunState{state[1], {
+		PipelineTask: state[1].PipelineTask,
+		TaskRunName:  "pipelinerun-mytask2-1",
+		ResolvedTaskResources: &resources.ResolvedTaskResources{
+			TaskSpec: &task.Spec,
+		},
+	}}
+	state = append(state, combinations[1])
+
+	d, err := v1alpha1.BuildDAG([]v1alpha1.PipelineTask{*state[0].PipelineTask, *state[1].PipelineTask, *state[2].PipelineTask})
+	if err != nil {
+		t.Fatalf("Unexpected error building DAG: %v", err)
+	}
+	state.MarkSkippedTasks(d)
+	for _, rprt := range combinations {
+		if !rprt.IsSkipped() {
+			t.Errorf("Expected TaskRun %s to be skipped", rprt.TaskRunName)
+		}
+	}
+	expectedSkipped := []v1alpha1.SkippedTask{{
+		Name:   "mytask1",
+		Reason: `condition "is-main" was not met: check pipelinerun-mytask1-is-main failed`,
+	}, {
+		Name:   "mytask2",
+		Reason: `parent task "mytask1" was skipped`,
+	}}
+	if diff := cmp.Diff(expectedSkipped, state.GetSkippedTasks()); diff != "" {
+		t.Errorf("Unexpected skipped tasks: %s", diff)
+	}
+}
+
+func TestTaskConditionCheckState_IsSuccess(t *testing.T) {
+	tcs := []struct {
+		name     string
+		state    PipelineRunState
+		expected bool
+	}{{
+		name:     "check-not-started",
+		state:    conditionState(nil, nil),
+		expected: false,
+	}, {
+		name:     "check-running",
+		state:    conditionState(makeStarted(conditionCheckTaskRun), nil),
+		expected: false,
+	}, {
+		name:     "check-succeeded",
+		state:    conditionState(makeSucceeded(conditionCheckTaskRun), nil),
+		expected: true,
+	}, {
+		name:     "check-failed",
+		state:    conditionState(makeFailed(conditionCheckTaskRun), nil),
+		expected: false,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.state[0].ResolvedConditionChecks.IsSuccess(); got != tc.expected {
+				t.Errorf("Expected IsSuccess() to be %t but was %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetPipelineConditionStatus_SkippedTasks(t *testing.T) {
+	state := conditionState(makeFailed(conditionCheckTaskRun), nil)
+	state[2].TaskRun = makeSucceeded(trs[0])
+	d, err := v1alpha1.BuildDAG([]v1alpha1.PipelineTask{*state[0].PipelineTask, *state[1].PipelineTask, *state[2].PipelineTask})
+	if err != nil {
+		t.Fatalf("Unexpected error building DAG: %v", err)
+	}
+	state.MarkSkippedTasks(d)
+
+	c := GetPipelineConditionStatus("somepipelinerun", state, zap.NewNop().Sugar(), &metav1.Time{Time: time.Now()}, nil, v1alpha1.FailurePolicyFailFast)
+	if c.Status != corev1.ConditionTrue {
+		t.Fatalf("Expected skipped tasks not to block the PipelineRun from succeeding but status was %s", c.Status)
+	}
+}
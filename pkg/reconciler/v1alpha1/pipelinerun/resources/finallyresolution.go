@@ -0,0 +1,136 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SplitFinally splits state, resolved from the tasks of a Pipeline followed by its finally
+// tasks, into the state of the tasks of the DAG and the state of the finally tasks.
+func (state PipelineRunState) SplitFinally(finally []v1alpha1.PipelineTask) (PipelineRunState, PipelineRunState) {
+	names := map[string]struct{}{}
+	for _, pt := range finally {
+		names[pt.Name] = struct{}{}
+	}
+	for i, t := range state {
+		if _, ok := names[t.PipelineTask.Name]; ok {
+			return state[:i], state[i:]
+		}
+	}
+	return state, state[len(state):]
+}
+
+// IsDone returns true if every PipelineTask in state has either been skipped or has a
+// TaskRun, or a child PipelineRun, which has finished executing.
+func (state PipelineRunState) IsDone() bool {
+	for _, t := range state {
+		if t.IsSkipped() {
+			continue
+		}
+		if !t.isDone() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsRunning returns true if any of the TaskRuns or child PipelineRuns in state, including
+// the TaskRuns checking conditions, has been created but hasn't finished executing yet.
+func (state PipelineRunState) IsRunning() bool {
+	for _, t := range state {
+		if t.IsStarted() && !t.isDone() {
+			return true
+		}
+		for _, rcc := range t.ResolvedConditionChecks {
+			if rcc.TaskRun != nil && !rcc.TaskRun.IsDone() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isDAGDone returns true if no more Tasks will be started for dagState, described by
+// dagCondition, and all of the ones which were started have finished executing.
+func isDAGDone(dagCondition *apis.Condition, dagState PipelineRunState) bool {
+	return !dagCondition.IsUnknown() && !dagState.IsRunning()
+}
+
+// GetNextFinallyTasks returns the finally tasks in state which should be started: once the
+// Tasks in dagState are done, all of the finally tasks which haven't been started yet.
+func (state PipelineRunState) GetNextFinallyTasks(dagCondition *apis.Condition, dagState PipelineRunState) []*ResolvedPipelineRunTask {
+	tasks := []*ResolvedPipelineRunTask{}
+	if !isDAGDone(dagCondition, dagState) {
+		return tasks
+	}
+	for _, t := range state {
+		if !t.IsStarted() {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks
+}
+
+// GetFinallyConditionStatus will return the Condition that the PipelineRun prName should be
+// updated with, based on dagCondition, the Condition of the Tasks in dagState, and on the
+// status of the TaskRuns of the finally tasks in state.
+func (state PipelineRunState) GetFinallyConditionStatus(prName string, dagCondition *apis.Condition, dagState PipelineRunState, logger *zap.SugaredLogger) *apis.Condition {
+	if dagCondition.IsUnknown() {
+		return dagCondition
+	}
+	if !isDAGDone(dagCondition, dagState) {
+		logger.Infof("PipelineRun %s still has running TaskRuns so its finally Tasks can't be started yet", prName)
+		return &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  ReasonRunning,
+			Message: "Not all Tasks in the Pipeline have finished executing",
+		}
+	}
+	if !state.IsDone() {
+		logger.Infof("PipelineRun %s still has running finally TaskRuns so it isn't yet done", prName)
+		return &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionUnknown,
+			Reason:  ReasonRunning,
+			Message: "Not all finally Tasks in the Pipeline have finished executing",
+		}
+	}
+	// The outcome of the Tasks takes precedence over the outcome of the finally tasks
+	if dagCondition.IsFalse() {
+		return dagCondition
+	}
+	for _, rprt := range state {
+		if rprt.getSucceededCondition().IsFalse() {
+			logger.Infof("Finally %s has failed, so PipelineRun %s has failed", rprt.describeRun(), prName)
+			return &apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  ReasonFailed,
+				Message: fmt.Sprintf("%s has failed", rprt.describeRun()),
+			}
+		}
+	}
+	logger.Infof("All finally TaskRuns have finished for PipelineRun %s so it has finished", prName)
+	return dagCondition
+}
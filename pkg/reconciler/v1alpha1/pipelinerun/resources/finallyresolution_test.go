@@ -0,0 +1,184 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/resources"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var finallyTaskRun = v1alpha1.TaskRun{
+	ObjectMeta: metav1.ObjectMeta{
+		Namespace: "namespace",
+		Name:      "pipelinerun-cleanup",
+	},
+}
+
+func finallyState(tr *v1alpha1.TaskRun) PipelineRunState {
+	return PipelineRunState{{
+		PipelineTask: &v1alpha1.PipelineTask{
+			Name:    "cleanup",
+			TaskRef: v1alpha1.TaskRef{Name: "task"},
+		},
+		TaskRunName: "pipelinerun-cleanup",
+		TaskRun:     tr,
+		ResolvedTaskResources: &resources.ResolvedTaskResources{
+			TaskSpec: &task.Spec,
+		},
+	}}
+}
+
+var (
+	runningCondition = &apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: ReasonRunning,
+	}
+	succeededCondition = &apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionTrue,
+		Reason: ReasonSucceeded,
+	}
+	failedCondition = &apis.Condition{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionFalse,
+		Reason: ReasonFailed,
+	}
+)
+
+func TestGetNextFinallyTasks(t *testing.T) {
+	tcs := []struct {
+		name          string
+		dagCondition  *apis.Condition
+		dagState      PipelineRunState
+		finallyState  PipelineRunState
+		expectedTasks int
+	}{{
+		name:          "tasks-running",
+		dagCondition:  runningCondition,
+		dagState:      oneStartedState,
+		finallyState:  finallyState(nil),
+		expectedTasks: 0,
+	}, {
+		name:          "tasks-succeeded",
+		dagCondition:  succeededCondition,
+		dagState:      allFinishedState,
+		finallyState:  finallyState(nil),
+		expectedTasks: 1,
+	}, {
+		name:          "tasks-failed",
+		dagCondition:  failedCondition,
+		dagState:      oneFailedState,
+		finallyState:  finallyState(nil),
+		expectedTasks: 1,
+	}, {
+		name:         "task-failed-while-another-is-running",
+		dagCondition: failedCondition,
+		dagState: PipelineRunState{{
+			PipelineTask: &pts[0],
+			TaskRunName:  "pipelinerun-mytask1",
+			TaskRun:      makeFailed(trs[0]),
+		}, {
+			PipelineTask: &pts[1],
+			TaskRunName:  "pipelinerun-mytask2",
+			TaskRun:      makeStarted(trs[1]),
+		}},
+		finallyState:  finallyState(nil),
+		expectedTasks: 0,
+	}, {
+		name:          "finally-already-started",
+		dagCondition:  succeededCondition,
+		dagState:      allFinishedState,
+		finallyState:  finallyState(makeStarted(finallyTaskRun)),
+		expectedTasks: 0,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			next := tc.finallyState.GetNextFinallyTasks(tc.dagCondition, tc.dagState)
+			if len(next) != tc.expectedTasks {
+				t.Errorf("Expected %d finally tasks to be started but got %d", tc.expectedTasks, len(next))
+			}
+		})
+	}
+}
+
+func TestGetFinallyConditionStatus(t *testing.T) {
+	tcs := []struct {
+		name           string
+		dagCondition   *apis.Condition
+		dagState       PipelineRunState
+		finallyState   PipelineRunState
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{{
+		name:           "tasks-running",
+		dagCondition:   runningCondition,
+		dagState:       oneStartedState,
+		finallyState:   finallyState(nil),
+		expectedStatus: corev1.ConditionUnknown,
+		expectedReason: ReasonRunning,
+	}, {
+		name:           "finally-running",
+		dagCondition:   succeededCondition,
+		dagState:       allFinishedState,
+		finallyState:   finallyState(makeStarted(finallyTaskRun)),
+		expectedStatus: corev1.ConditionUnknown,
+		expectedReason: ReasonRunning,
+	}, {
+		name:           "all-succeeded",
+		dagCondition:   succeededCondition,
+		dagState:       allFinishedState,
+		finallyState:   finallyState(makeSucceeded(finallyTaskRun)),
+		expectedStatus: corev1.ConditionTrue,
+		expectedReason: ReasonSucceeded,
+	}, {
+		name:           "finally-failed",
+		dagCondition:   succeededCondition,
+		dagState:       allFinishedState,
+		finallyState:   finallyState(makeFailed(finallyTaskRun)),
+		expectedStatus: corev1.ConditionFalse,
+		expectedReason: ReasonFailed,
+	}, {
+		name: "tasks-timed-out-finally-succeeded",
+		dagCondition: &apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionFalse,
+			Reason: ReasonTimedOut,
+		},
+		dagState:       oneFailedState,
+		finallyState:   finallyState(makeSucceeded(finallyTaskRun)),
+		expectedStatus: corev1.ConditionFalse,
+		expectedReason: ReasonTimedOut,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			c := tc.finallyState.GetFinallyConditionStatus("somepipelinerun", tc.dagCondition, tc.dagState, zap.NewNop().Sugar())
+			if c.Status != tc.expectedStatus {
+				t.Errorf("Expected status to be %s but was %s", tc.expectedStatus, c.Status)
+			}
+			if c.Reason != tc.expectedReason {
+				t.Errorf("Expected reason to be %s but was %s", tc.expectedReason, c.Reason)
+			}
+		})
+	}
+}
@@ -71,13 +71,53 @@ func GetInputSteps(inputs map[string]*v1alpha1.PipelineResource, pt *v1alpha1.Pi
 	return taskInputResources
 }
 
-// WrapSteps will add the correct `paths` to all of the inputs and outputs for pt
-func WrapSteps(tr *v1alpha1.TaskRunSpec, pt *v1alpha1.PipelineTask, inputs, outputs map[string]*v1alpha1.PipelineResource, storageBasePath string) {
+// WrapSteps will add the correct `paths` to all of the inputs and outputs for pt. The resources
+// which are bound to a spec in bindings, instead of a reference, are bound to that spec.
+func WrapSteps(tr *v1alpha1.TaskRunSpec, pt *v1alpha1.PipelineTask, inputs, outputs map[string]*v1alpha1.PipelineResource, bindings []v1alpha1.PipelineResourceBinding, storageBasePath string) {
 	if pt == nil {
 		return
 	}
+	inputSpecs, outputSpecs := getResourceSpecs(pt, bindings)
 	// Add presteps to setup updated input
-	tr.Inputs.Resources = append(tr.Inputs.Resources, GetInputSteps(inputs, pt, storageBasePath)...)
+	tr.Inputs.Resources = append(tr.Inputs.Resources, embedResourceSpecs(GetInputSteps(inputs, pt, storageBasePath), inputSpecs)...)
 	// Add poststeps to setup outputs
-	tr.Outputs.Resources = append(tr.Outputs.Resources, GetOutputSteps(outputs, pt.Name, storageBasePath)...)
+	tr.Outputs.Resources = append(tr.Outputs.Resources, embedResourceSpecs(GetOutputSteps(outputs, pt.Name, storageBasePath), outputSpecs)...)
+}
+
+// getResourceSpecs returns the specs of the input and output resources of pt, keyed by their
+// name in the Task, which are bound to a spec in bindings instead of a reference.
+func getResourceSpecs(pt *v1alpha1.PipelineTask, bindings []v1alpha1.PipelineResourceBinding) (map[string]*v1alpha1.PipelineResourceSpec, map[string]*v1alpha1.PipelineResourceSpec) {
+	specs := map[string]*v1alpha1.PipelineResourceSpec{}
+	for _, b := range bindings {
+		if b.ResourceSpec != nil {
+			specs[b.Name] = b.ResourceSpec
+		}
+	}
+	inputs, outputs := map[string]*v1alpha1.PipelineResourceSpec{}, map[string]*v1alpha1.PipelineResourceSpec{}
+	if pt.Resources == nil {
+		return inputs, outputs
+	}
+	for _, r := range pt.Resources.Inputs {
+		if spec, ok := specs[r.Resource]; ok {
+			inputs[r.Name] = spec
+		}
+	}
+	for _, r := range pt.Resources.Outputs {
+		if spec, ok := specs[r.Resource]; ok {
+			outputs[r.Name] = spec
+		}
+	}
+	return inputs, outputs
+}
+
+// embedResourceSpecs replaces the references of the resources of trbs which have a spec in specs
+// with that spec, since there is no PipelineResource to reference.
+func embedResourceSpecs(trbs []v1alpha1.TaskResourceBinding, specs map[string]*v1alpha1.PipelineResourceSpec) []v1alpha1.TaskResourceBinding {
+	for i := range trbs {
+		if spec, ok := specs[trbs[i].Name]; ok {
+			trbs[i].ResourceRef = v1alpha1.PipelineResourceRef{}
+			trbs[i].ResourceSpec = spec
+		}
+	}
+	return trbs
 }
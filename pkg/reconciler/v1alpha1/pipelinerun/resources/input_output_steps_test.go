@@ -174,7 +174,7 @@ func TestWrapSteps(t *testing.T) {
 	}
 
 	taskRunSpec := &v1alpha1.TaskRunSpec{}
-	resources.WrapSteps(taskRunSpec, pt, inputs, outputs, pvcDir)
+	resources.WrapSteps(taskRunSpec, pt, inputs, outputs, nil, pvcDir)
 
 	expectedtaskInputResources := []v1alpha1.TaskResourceBinding{{
 		ResourceRef: v1alpha1.PipelineResourceRef{Name: "resource1"},
@@ -200,3 +200,47 @@ func TestWrapSteps(t *testing.T) {
 		t.Errorf("error comparing output resources: %s", d)
 	}
 }
+
+func TestWrapSteps_ResourceSpec(t *testing.T) {
+	spec := &v1alpha1.PipelineResourceSpec{
+		Type:   v1alpha1.PipelineResourceTypeGit,
+		Params: []v1alpha1.ResourceParam{{Name: "url", Value: "https://foo.git"}},
+	}
+	inputs := map[string]*v1alpha1.PipelineResource{
+		"test-input": {ObjectMeta: metav1.ObjectMeta{Name: "test-input"}, Spec: *spec},
+	}
+	outputs := map[string]*v1alpha1.PipelineResource{
+		"test-output": {ObjectMeta: metav1.ObjectMeta{Name: "resource1"}},
+	}
+	pt := &v1alpha1.PipelineTask{
+		Name: "test-task",
+		Resources: &v1alpha1.PipelineTaskResources{
+			Inputs:  []v1alpha1.PipelineTaskInputResource{{Name: "test-input", Resource: "git-resource"}},
+			Outputs: []v1alpha1.PipelineTaskOutputResource{{Name: "test-output", Resource: "image-resource"}},
+		},
+	}
+	bindings := []v1alpha1.PipelineResourceBinding{
+		{Name: "git-resource", ResourceSpec: spec},
+		{Name: "image-resource", ResourceRef: v1alpha1.PipelineResourceRef{Name: "resource1"}},
+	}
+
+	taskRunSpec := &v1alpha1.TaskRunSpec{}
+	resources.WrapSteps(taskRunSpec, pt, inputs, outputs, bindings, pvcDir)
+
+	// Only the resource bound to a spec is bound to it instead of a reference
+	expectedtaskInputResources := []v1alpha1.TaskResourceBinding{{
+		ResourceSpec: spec,
+		Name:         "test-input",
+	}}
+	expectedtaskOuputResources := []v1alpha1.TaskResourceBinding{{
+		ResourceRef: v1alpha1.PipelineResourceRef{Name: "resource1"},
+		Name:        "test-output",
+		Paths:       []string{"/pvc/test-task/test-output"},
+	}}
+	if d := cmp.Diff(expectedtaskInputResources, taskRunSpec.Inputs.Resources); d != "" {
+		t.Errorf("error comparing input resources -want, +got: %s", d)
+	}
+	if d := cmp.Diff(expectedtaskOuputResources, taskRunSpec.Outputs.Resources); d != "" {
+		t.Errorf("error comparing output resources -want, +got: %s", d)
+	}
+}
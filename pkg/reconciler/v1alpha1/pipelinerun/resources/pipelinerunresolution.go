@@ -17,7 +17,9 @@ limitations under the License.
 package resources
 
 import (
+	"crypto/sha256"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/knative/pkg/apis"
@@ -26,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/list"
@@ -50,12 +53,24 @@ const (
 )
 
 // ResolvedPipelineRunTask contains a Task and its associated TaskRun, if it
-// exists. TaskRun can be nil to represent there being no TaskRun.
+// exists. TaskRun can be nil to represent there being no TaskRun. A PipelineTask
+// with a matrix is resolved into a ResolvedPipelineRunTask for each combination of
+// its matrix, whose PipelineTask has the params of this combination.
 type ResolvedPipelineRunTask struct {
-	TaskRunName           string
-	TaskRun               *v1alpha1.TaskRun
+	TaskRunName string
+	TaskRun     *v1alpha1.TaskRun
+	// PipelineRun is the child PipelineRun, named TaskRunName, of a PipelineTask
+	// which runs another Pipeline, if it exists: these never have a TaskRun
+	PipelineRun *v1alpha1.PipelineRun
+	// Run is the Run, named TaskRunName, of a PipelineTask which runs a custom
+	// task, if it exists: these never have a TaskRun either
+	Run                   *v1alpha1.Run
 	PipelineTask          *v1alpha1.PipelineTask
 	ResolvedTaskResources *resources.ResolvedTaskResources
+	// ResolvedConditionChecks holds the state of the conditions guarding the PipelineTask
+	ResolvedConditionChecks TaskConditionCheckState
+	// SkipReason is set when the PipelineTask won't be run, see MarkSkippedTasks
+	SkipReason string
 }
 
 // PipelineRunState is a slice of ResolvedPipelineRunTasks the represents the current execution
@@ -67,7 +82,7 @@ type PipelineRunState []*ResolvedPipelineRunTask
 func (state PipelineRunState) GetNextTasks(candidateTasks map[string]v1alpha1.PipelineTask) []*ResolvedPipelineRunTask {
 	tasks := []*ResolvedPipelineRunTask{}
 	for _, t := range state {
-		if _, ok := candidateTasks[t.PipelineTask.Name]; ok && t.TaskRun == nil {
+		if _, ok := candidateTasks[t.PipelineTask.Name]; ok && !t.IsStarted() && !t.IsSkipped() {
 			tasks = append(tasks, t)
 		}
 	}
@@ -75,15 +90,25 @@ func (state PipelineRunState) GetNextTasks(candidateTasks map[string]v1alpha1.Pi
 }
 
 // SuccessfulPipelineTaskNames returns a list of the names of all of the PipelineTasks in state
-// which have successfully completed.
+// which have successfully completed. A PipelineTask with a matrix has only completed once the
+// TaskRuns of all of its combinations have.
 func (state PipelineRunState) SuccessfulPipelineTaskNames() []string {
-	done := []string{}
+	names := []string{}
+	successful := map[string]bool{}
 	for _, t := range state {
-		if t.TaskRun != nil {
-			c := t.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
-			if c.IsTrue() {
-				done = append(done, t.PipelineTask.Name)
-			}
+		name := t.PipelineTask.Name
+		if _, ok := successful[name]; !ok {
+			names = append(names, name)
+			successful[name] = true
+		}
+		if !t.getSucceededCondition().IsTrue() {
+			successful[name] = false
+		}
+	}
+	done := []string{}
+	for _, name := range names {
+		if successful[name] {
+			done = append(done, name)
 		}
 	}
 	return done
@@ -94,9 +119,9 @@ type GetTaskRun func(name string) (*v1alpha1.TaskRun, error)
 
 // GetResourcesFromBindings will validate that all PipelineResources declared in Pipeline p are bound in PipelineRun pr
 // and if so, will return a map from the declared name of the PipelineResource (which is how the PipelineResource will
-// be referred to in the PipelineRun) to the ResourceRef.
-func GetResourcesFromBindings(p *v1alpha1.Pipeline, pr *v1alpha1.PipelineRun) (map[string]v1alpha1.PipelineResourceRef, error) {
-	resources := map[string]v1alpha1.PipelineResourceRef{}
+// be referred to in the PipelineRun) to its binding, which either references the PipelineResource or embeds its spec.
+func GetResourcesFromBindings(p *v1alpha1.Pipeline, pr *v1alpha1.PipelineRun) (map[string]v1alpha1.PipelineResourceBinding, error) {
+	resources := map[string]v1alpha1.PipelineResourceBinding{}
 
 	required := make([]string, 0, len(p.Spec.Resources))
 	for _, resource := range p.Spec.Resources {
@@ -112,12 +137,12 @@ func GetResourcesFromBindings(p *v1alpha1.Pipeline, pr *v1alpha1.PipelineRun) (m
 	}
 
 	for _, resource := range pr.Spec.Resources {
-		resources[resource.Name] = resource.ResourceRef
+		resources[resource.Name] = resource
 	}
 	return resources, nil
 }
 
-func getPipelineRunTaskResources(pt v1alpha1.PipelineTask, providedResources map[string]v1alpha1.PipelineResourceRef) ([]v1alpha1.TaskResourceBinding, []v1alpha1.TaskResourceBinding, error) {
+func getPipelineRunTaskResources(pt v1alpha1.PipelineTask, providedResources map[string]v1alpha1.PipelineResourceBinding) ([]v1alpha1.TaskResourceBinding, []v1alpha1.TaskResourceBinding, error) {
 	inputs, outputs := []v1alpha1.TaskResourceBinding{}, []v1alpha1.TaskResourceBinding{}
 	if pt.Resources != nil {
 		for _, taskInput := range pt.Resources.Inputs {
@@ -126,8 +151,9 @@ func getPipelineRunTaskResources(pt v1alpha1.PipelineTask, providedResources map
 				return inputs, outputs, fmt.Errorf("pipelineTask tried to use input resource %s not present in declared resources", taskInput.Resource)
 			}
 			inputs = append(inputs, v1alpha1.TaskResourceBinding{
-				Name:        taskInput.Name,
-				ResourceRef: resource,
+				Name:         taskInput.Name,
+				ResourceRef:  resource.ResourceRef,
+				ResourceSpec: resource.ResourceSpec,
 			})
 		}
 		for _, taskOutput := range pt.Resources.Outputs {
@@ -136,8 +162,9 @@ func getPipelineRunTaskResources(pt v1alpha1.PipelineTask, providedResources map
 				return outputs, outputs, fmt.Errorf("pipelineTask tried to use output resource %s not present in declared resources", taskOutput.Resource)
 			}
 			outputs = append(outputs, v1alpha1.TaskResourceBinding{
-				Name:        taskOutput.Name,
-				ResourceRef: resource,
+				Name:         taskOutput.Name,
+				ResourceRef:  resource.ResourceRef,
+				ResourceSpec: resource.ResourceSpec,
 			})
 		}
 	}
@@ -165,40 +192,56 @@ func (e *ResourceNotFoundError) Error() string {
 
 // ResolvePipelineRun retrieves all Tasks instances which are reference by tasks, getting
 // instances from getTask. If it is unable to retrieve an instance of a referenced Task, it
-// will return an error, otherwise it returns a list of all of the Tasks retrieved.
+// will return an error, otherwise it returns a list of all of the Tasks retrieved. The Pipelines
+// run by tasks are retrieved from getPipeline.
 // It will retrieve the Resources needed for the TaskRun as well using getResource and the mapping
-// of providedResources.
+// of providedResources, unless their spec is embedded in the binding.
 func ResolvePipelineRun(
 	pipelineRun v1alpha1.PipelineRun,
 	getTask resources.GetTask,
 	getClusterTask resources.GetClusterTask,
+	getPipeline GetPipeline,
 	getResource resources.GetResource,
 	tasks []v1alpha1.PipelineTask,
-	providedResources map[string]v1alpha1.PipelineResourceRef,
+	providedResources map[string]v1alpha1.PipelineResourceBinding,
 ) (PipelineRunState, error) {
 
 	state := []*ResolvedPipelineRunTask{}
 	for i := range tasks {
 		pt := tasks[i]
 
-		rprt := ResolvedPipelineRunTask{
-			PipelineTask: &pt,
-			TaskRunName:  getTaskRunName(pipelineRun.Status.TaskRuns, pt.Name, pipelineRun.Name),
-		}
-
-		// Find the Task that this task in the Pipeline this PipelineTask is using
-		var t v1alpha1.TaskInterface
-		var err error
-		if pt.TaskRef.Kind == v1alpha1.ClusterTaskKind {
-			t, err = getClusterTask(pt.TaskRef.Name)
+		// Find the Task that this task in the Pipeline this PipelineTask is using, unless it embeds its spec
+		// or runs a Pipeline or a custom task, which have no TaskSpec
+		var spec *v1alpha1.TaskSpec
+		var taskName string
+		if pt.TaskSpec != nil {
+			ts := *pt.TaskSpec
+			spec = &ts
+		} else if pt.TaskRef.IsCustomTask() {
+			// The custom task is resolved by the controller implementing its kind
+			taskName = pt.TaskRef.Name
+		} else if pt.TaskRef.Kind == v1alpha1.PipelineKind {
+			if err := resolveChildPipeline(pipelineRun, getPipeline, pt); err != nil {
+				return nil, err
+			}
+			taskName = pt.TaskRef.Name
 		} else {
-			t, err = getTask(pt.TaskRef.Name)
-		}
-		if err != nil {
-			return nil, &TaskNotFoundError{
-				Name: pt.TaskRef.Name,
-				Msg:  err.Error(),
+			var t v1alpha1.TaskInterface
+			var err error
+			if pt.TaskRef.Kind == v1alpha1.ClusterTaskKind {
+				t, err = getClusterTask(pt.TaskRef.Name)
+			} else {
+				t, err = getTask(pt.TaskRef.Name)
+			}
+			if err != nil {
+				return nil, &TaskNotFoundError{
+					Name: pt.TaskRef.Name,
+					Msg:  err.Error(),
+				}
 			}
+			ts := t.TaskSpec()
+			spec = &ts
+			taskName = t.TaskMetadata().Name
 		}
 
 		// Get all the resources that this task will be using, if any
@@ -207,37 +250,95 @@ func ResolvePipelineRun(
 			return nil, fmt.Errorf("unexpected error which should have been caught by Pipeline webhook: %v", err)
 		}
 
-		spec := t.TaskSpec()
-		rtr, err := resources.ResolveTaskResources(&spec, t.TaskMetadata().Name, inputs, outputs, getResource)
+		rtr, err := resources.ResolveTaskResources(spec, taskName, inputs, outputs, getResource)
 		if err != nil {
 			return nil, &ResourceNotFoundError{Msg: err.Error()}
 		}
-		rprt.ResolvedTaskResources = rtr
 
-		// Add this task to the state of the PipelineRun
-		state = append(state, &rprt)
+		combinations := pt.MatrixCombinations()
+		if len(combinations) == 0 {
+			rprt := ResolvedPipelineRunTask{
+				PipelineTask:          &pt,
+				ResolvedTaskResources: rtr,
+			}
+			var checksStatus map[string]*v1alpha1.PipelineRunConditionCheckStatus
+			if rprt.IsCustomTask() {
+				rprt.TaskRunName = getRunName(pipelineRun.Status.Runs, pt.Name, pipelineRun.Name)
+				if s, ok := pipelineRun.Status.Runs[rprt.TaskRunName]; ok {
+					checksStatus = s.ConditionChecks
+				}
+			} else if rprt.IsPipeline() {
+				rprt.TaskRunName = getChildPipelineRunName(pipelineRun.Status.ChildPipelineRuns, pt.Name, pipelineRun.Name)
+				if s, ok := pipelineRun.Status.ChildPipelineRuns[rprt.TaskRunName]; ok {
+					checksStatus = s.ConditionChecks
+				}
+			} else {
+				rprt.TaskRunName = getTaskRunName(pipelineRun.Status.TaskRuns, pt.Name, pipelineRun.Name)
+				if s, ok := pipelineRun.Status.TaskRuns[rprt.TaskRunName]; ok {
+					checksStatus = s.ConditionChecks
+				}
+			}
+			rprt.ResolvedConditionChecks = resolveConditionChecks(&pt, checksStatus, rprt.TaskRunName)
+
+			// Add this task to the state of the PipelineRun
+			state = append(state, &rprt)
+			continue
+		}
+
+		// Each combination of the matrix is run by its own TaskRun, with the params of the combination
+		for j, combination := range combinations {
+			ptc := pt
+			ptc.Params = append(append([]v1alpha1.Param{}, pt.Params...), combination...)
+			state = append(state, &ResolvedPipelineRunTask{
+				PipelineTask:          &ptc,
+				TaskRunName:           getMatrixTaskRunName(pipelineRun.Status.TaskRuns, pt.Name, pipelineRun.Name, j),
+				ResolvedTaskResources: rtr,
+			})
+		}
 	}
 	return state, nil
 }
 
 // ResolveTaskRuns will go through all tasks in state and check if there are existing TaskRuns
-// for each of them by calling getTaskRun.
+// for each of them, unless they run a Pipeline or a custom task, and for each of their condition
+// checks, by calling getTaskRun.
 func ResolveTaskRuns(getTaskRun GetTaskRun, state PipelineRunState) error {
 	for _, rprt := range state {
 		// Check if we have already started a TaskRun for this task
-		taskRun, err := getTaskRun(rprt.TaskRunName)
-		if err != nil {
-			// If the TaskRun isn't found, it just means it hasn't been run yet
-			if !errors.IsNotFound(err) {
-				return fmt.Errorf("error retrieving TaskRun %s: %s", rprt.TaskRunName, err)
+		if !rprt.IsPipeline() && !rprt.IsCustomTask() {
+			taskRun, err := getExistingTaskRun(getTaskRun, rprt.TaskRunName)
+			if err != nil {
+				return err
 			}
-		} else {
 			rprt.TaskRun = taskRun
 		}
+
+		for _, rcc := range rprt.ResolvedConditionChecks {
+			if rcc.ConditionCheckName == "" {
+				continue
+			}
+			taskRun, err := getExistingTaskRun(getTaskRun, rcc.ConditionCheckName)
+			if err != nil {
+				return err
+			}
+			rcc.TaskRun = taskRun
+		}
 	}
 	return nil
 }
 
+func getExistingTaskRun(getTaskRun GetTaskRun, name string) (*v1alpha1.TaskRun, error) {
+	taskRun, err := getTaskRun(name)
+	if err != nil {
+		// If the TaskRun isn't found, it just means it hasn't been run yet
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("error retrieving TaskRun %s: %s", name, err)
+		}
+		return nil, nil
+	}
+	return taskRun, nil
+}
+
 // getTaskRunName should return a unique name for a `TaskRun` if one has not already been defined, and the existing one otherwise.
 func getTaskRunName(taskRunsStatus map[string]*v1alpha1.PipelineRunTaskRunStatus, ptName, prName string) string {
 	for k, v := range taskRunsStatus {
@@ -249,11 +350,40 @@ func getTaskRunName(taskRunsStatus map[string]*v1alpha1.PipelineRunTaskRunStatus
 	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
 }
 
+// matrixHashLength is the length of the hash keeping the names of the TaskRuns of a matrix unique.
+const matrixHashLength = 5
+
+// getMatrixTaskRunName returns the name of the `TaskRun` running the combination with index i of
+// the matrix of a PipelineTask. Since all of the TaskRuns of a matrix run the same PipelineTask,
+// their names end with the index of their combination, so that the TaskRun of each combination
+// is found again, including the TaskRuns reused from the PipelineRun a rerun was started from.
+// The names which are too long are cut, after a hash of the PipelineTask name and the index which
+// keeps them unique.
+func getMatrixTaskRunName(taskRunsStatus map[string]*v1alpha1.PipelineRunTaskRunStatus, ptName, prName string, i int) string {
+	suffix := fmt.Sprintf("-%d", i)
+	for k, v := range taskRunsStatus {
+		if v.PipelineTaskName == ptName && strings.HasSuffix(k, suffix) {
+			return k
+		}
+	}
+
+	base := fmt.Sprintf("%s-%s", prName, ptName)
+	if len(base)+len(suffix) > validation.DNS1123LabelMaxLength {
+		hash := fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%s-%d", ptName, i))))
+		suffix = fmt.Sprintf("-%s%s", hash[:matrixHashLength], suffix)
+		base = base[:validation.DNS1123LabelMaxLength-len(suffix)]
+	}
+	return base + suffix
+}
+
 // GetPipelineConditionStatus will return the Condition that the PipelineRun prName should be
-// updated with, based on the status of the TaskRuns in state.
+// updated with, based on the status of the TaskRuns in state. With the run all independent
+// failurePolicy, the PipelineRun only fails once the tasks which can still be run, which must
+// have been marked with MarkTasksAfterFailures, have finished.
 func GetPipelineConditionStatus(prName string, state PipelineRunState, logger *zap.SugaredLogger, startTime *metav1.Time,
-	pipelineTimeout *metav1.Duration) *apis.Condition {
+	pipelineTimeout *metav1.Duration, failurePolicy v1alpha1.FailurePolicy) *apis.Condition {
 	allFinished := true
+	failed := []string{}
 	if !startTime.IsZero() && pipelineTimeout != nil {
 		timeout := pipelineTimeout.Duration
 		runtime := time.Since(startTime.Time)
@@ -270,30 +400,40 @@ func GetPipelineConditionStatus(prName string, state PipelineRunState, logger *z
 		}
 	}
 	for _, rprt := range state {
-		if rprt.TaskRun == nil {
-			logger.Infof("TaskRun %s doesn't have a Status, so PipelineRun %s isn't finished", rprt.TaskRunName, prName)
+		if rprt.IsSkipped() {
+			logger.Infof("PipelineTask %s was skipped: %s", rprt.PipelineTask.Name, rprt.SkipReason)
+			continue
+		}
+		if !rprt.IsStarted() {
+			logger.Infof("%s doesn't have a Status, so PipelineRun %s isn't finished", rprt.describeRun(), prName)
 			allFinished = false
 			continue
 		}
-		c := rprt.TaskRun.Status.GetCondition(apis.ConditionSucceeded)
+		c := rprt.getSucceededCondition()
 		if c == nil {
-			logger.Infof("TaskRun %s doesn't have a condition, so PipelineRun %s isn't finished", rprt.TaskRunName, prName)
+			logger.Infof("%s doesn't have a condition, so PipelineRun %s isn't finished", rprt.describeRun(), prName)
 			allFinished = false
 			continue
 		}
-		logger.Infof("TaskRun %s status : %v", rprt.TaskRunName, c.Status)
-		// If any TaskRuns have failed, we should halt execution and consider the run failed
+		logger.Infof("%s status : %v", rprt.describeRun(), c.Status)
+		// With the run all independent policy, the tasks which don't run after a failed task are still run
+		if c.Status == corev1.ConditionFalse && failurePolicy == v1alpha1.FailurePolicyRunAllIndependent {
+			logger.Infof("%s has failed, PipelineRun %s keeps running its independent tasks", rprt.describeRun(), prName)
+			failed = append(failed, rprt.describeRun())
+			continue
+		}
+		// If any TaskRuns, or child PipelineRuns, have failed, we should halt execution and consider the run failed
 		if c.Status == corev1.ConditionFalse {
-			logger.Infof("TaskRun %s has failed, so PipelineRun %s has failed", rprt.TaskRunName, prName)
+			logger.Infof("%s has failed, so PipelineRun %s has failed", rprt.describeRun(), prName)
 			return &apis.Condition{
 				Type:    apis.ConditionSucceeded,
 				Status:  corev1.ConditionFalse,
 				Reason:  ReasonFailed,
-				Message: fmt.Sprintf("TaskRun %s has failed", rprt.TaskRun.Name),
+				Message: fmt.Sprintf("%s has failed", rprt.describeRun()),
 			}
 		}
 		if c.Status != corev1.ConditionTrue {
-			logger.Infof("TaskRun %s is still running so PipelineRun %s is still running", rprt.TaskRunName, prName)
+			logger.Infof("%s is still running so PipelineRun %s is still running", rprt.describeRun(), prName)
 			allFinished = false
 		}
 	}
@@ -306,6 +446,15 @@ func GetPipelineConditionStatus(prName string, state PipelineRunState, logger *z
 			Message: "Not all Tasks in the Pipeline have finished executing",
 		}
 	}
+	if len(failed) > 0 {
+		logger.Infof("No more tasks can be run and some have failed, so PipelineRun %s has failed", prName)
+		return &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  ReasonFailed,
+			Message: fmt.Sprintf("%s failed", strings.Join(failed, ", ")),
+		}
+	}
 	logger.Infof("All TaskRuns have finished for PipelineRun %s so it has finished", prName)
 	return &apis.Condition{
 		Type:    apis.ConditionSucceeded,
@@ -27,6 +27,7 @@ import (
 	"github.com/knative/pkg/apis"
 	duckv1beta1 "github.com/knative/pkg/apis/duck/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/pipeline/dag"
 	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/resources"
 	tb "github.com/tektoncd/pipeline/test/builder"
 	"github.com/tektoncd/pipeline/test/names"
@@ -66,9 +67,9 @@ var task = &v1alpha1.Task{
 		Name: "task",
 	},
 	Spec: v1alpha1.TaskSpec{
-		Steps: []corev1.Container{{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
 			Name: "step1",
-		}},
+		}}},
 	},
 }
 
@@ -77,9 +78,9 @@ var clustertask = &v1alpha1.ClusterTask{
 		Name: "clustertask",
 	},
 	Spec: v1alpha1.TaskSpec{
-		Steps: []corev1.Container{{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
 			Name: "step1",
-		}},
+		}}},
 	},
 }
 
@@ -97,6 +98,11 @@ var trs = []v1alpha1.TaskRun{{
 	Spec: v1alpha1.TaskRunSpec{},
 }}
 
+// getPipeline is used to resolve the PipelineTasks of tests which don't run any Pipeline
+var getPipeline = func(name string) (*v1alpha1.Pipeline, error) {
+	return nil, fmt.Errorf("should not get called")
+}
+
 func makeStarted(tr v1alpha1.TaskRun) *v1alpha1.TaskRun {
 	newTr := newTaskRun(tr)
 	newTr.Status.Conditions[0].Status = corev1.ConditionUnknown
@@ -410,6 +416,32 @@ func TestSuccessfulPipelineTaskNames(t *testing.T) {
 			state:         allFinishedState,
 			expectedNames: []string{"mytask1", "mytask2"},
 		},
+		{
+			name: "matrix-one-combination-finished",
+			state: PipelineRunState{{
+				PipelineTask: &pts[0],
+				TaskRunName:  "pipelinerun-mytask1-0",
+				TaskRun:      makeSucceeded(trs[0]),
+			}, {
+				PipelineTask: &pts[0],
+				TaskRunName:  "pipelinerun-mytask1-1",
+				TaskRun:      makeStarted(trs[0]),
+			}},
+			expectedNames: []string{},
+		},
+		{
+			name: "matrix-all-combinations-finished",
+			state: PipelineRunState{{
+				PipelineTask: &pts[0],
+				TaskRunName:  "pipelinerun-mytask1-0",
+				TaskRun:      makeSucceeded(trs[0]),
+			}, {
+				PipelineTask: &pts[0],
+				TaskRunName:  "pipelinerun-mytask1-1",
+				TaskRun:      makeSucceeded(trs[0]),
+			}},
+			expectedNames: []string{"mytask1"},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -456,7 +488,7 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
 			c := GetPipelineConditionStatus("somepipelinerun", tc.state, zap.NewNop().Sugar(), &metav1.Time{time.Now()},
-				nil)
+				nil, v1alpha1.FailurePolicyFailFast)
 			if c.Status != tc.expectedStatus {
 				t.Fatalf("Expected to get status %s but got %s for state %v", tc.expectedStatus, c.Status, tc.state)
 			}
@@ -464,6 +496,64 @@ func TestGetPipelineConditionStatus(t *testing.T) {
 	}
 }
 
+func TestGetPipelineConditionStatus_RunAllIndependent(t *testing.T) {
+	// build -> test -> deploy -> publish, and lint
+	tasks := tb.Pipeline("pipeline", "namespace", tb.PipelineSpec(
+		tb.PipelineTask("build", "task"),
+		tb.PipelineTask("test", "task", tb.RunAfter("build")),
+		tb.PipelineTask("deploy", "task", tb.RunAfter("test")),
+		tb.PipelineTask("publish", "task", tb.RunAfter("deploy")),
+		tb.PipelineTask("lint", "task"),
+	)).Spec.Tasks
+	d, err := v1alpha1.BuildDAG(tasks)
+	if err != nil {
+		t.Fatalf("Didn't expect error building the DAG but got %v", err)
+	}
+	state := PipelineRunState{}
+	for i := range tasks {
+		state = append(state, &ResolvedPipelineRunTask{
+			PipelineTask: &tasks[i],
+			TaskRunName:  "pipelinerun-" + tasks[i].Name,
+		})
+	}
+	state[0].TaskRun = makeSucceeded(trs[0])
+	state[1].TaskRun = makeFailed(trs[0])
+
+	state.MarkTasksAfterFailures(d)
+	state.MarkSkippedTasks(d)
+	expectedSkipped := []v1alpha1.SkippedTask{{
+		Name:   "deploy",
+		Reason: `parent task "test" failed`,
+	}, {
+		Name:   "publish",
+		Reason: `parent task "deploy" was skipped`,
+	}}
+	if d := cmp.Diff(expectedSkipped, state.GetSkippedTasks()); d != "" {
+		t.Errorf("Unexpected skipped tasks -want, +got: %v", d)
+	}
+
+	// The independent lint task is still run after test has failed
+	candidateTasks, err := dag.GetSchedulable(d, state.SuccessfulPipelineTaskNames()...)
+	if err != nil {
+		t.Fatalf("Didn't expect error getting the schedulable tasks but got %v", err)
+	}
+	next := state.GetNextTasks(candidateTasks)
+	if len(next) != 1 || next[0].PipelineTask.Name != "lint" {
+		t.Errorf("Expected lint to be the next task but got %v", next)
+	}
+	c := GetPipelineConditionStatus("somepipelinerun", state, zap.NewNop().Sugar(), &metav1.Time{Time: time.Now()}, nil, v1alpha1.FailurePolicyRunAllIndependent)
+	if !c.IsUnknown() {
+		t.Errorf("Expected the PipelineRun to still be running but condition was %v", c)
+	}
+
+	// Once no more tasks can be run the PipelineRun fails
+	state[4].TaskRun = makeSucceeded(trs[0])
+	c = GetPipelineConditionStatus("somepipelinerun", state, zap.NewNop().Sugar(), &metav1.Time{Time: time.Now()}, nil, v1alpha1.FailurePolicyRunAllIndependent)
+	if !c.IsFalse() || c.Reason != ReasonFailed || c.Message != "TaskRun pipelinerun-test failed" {
+		t.Errorf("Expected the PipelineRun to have failed because of the test task but condition was %v", c)
+	}
+}
+
 func TestGetResourcesFromBindings(t *testing.T) {
 	p := tb.Pipeline("pipelines", "namespace", tb.PipelineSpec(
 		tb.PipelineDeclaredResource("git-resource", "git"),
@@ -475,9 +565,37 @@ func TestGetResourcesFromBindings(t *testing.T) {
 	if err != nil {
 		t.Fatalf("didn't expect error getting resources from bindings but got: %v", err)
 	}
-	expectedResources := map[string]v1alpha1.PipelineResourceRef{
+	expectedResources := map[string]v1alpha1.PipelineResourceBinding{
 		"git-resource": {
-			Name: "sweet-resource",
+			Name:        "git-resource",
+			ResourceRef: v1alpha1.PipelineResourceRef{Name: "sweet-resource"},
+		},
+	}
+	if d := cmp.Diff(expectedResources, m); d != "" {
+		t.Fatalf("Expected resources didn't match actual -want, +got: %v", d)
+	}
+}
+
+func TestGetResourcesFromBindings_ResourceSpec(t *testing.T) {
+	p := tb.Pipeline("pipelines", "namespace", tb.PipelineSpec(
+		tb.PipelineDeclaredResource("git-resource", "git"),
+	))
+	pr := tb.PipelineRun("pipelinerun", "namespace", tb.PipelineRunSpec("pipeline",
+		tb.PipelineRunResourceBinding("git-resource", tb.PipelineResourceBindingResourceSpec(
+			v1alpha1.PipelineResourceTypeGit, tb.PipelineResourceSpecParam("url", "https://foo.git"),
+		)),
+	))
+	m, err := GetResourcesFromBindings(p, pr)
+	if err != nil {
+		t.Fatalf("didn't expect error getting resources from bindings but got: %v", err)
+	}
+	expectedResources := map[string]v1alpha1.PipelineResourceBinding{
+		"git-resource": {
+			Name: "git-resource",
+			ResourceSpec: &v1alpha1.PipelineResourceSpec{
+				Type:   v1alpha1.PipelineResourceTypeGit,
+				Params: []v1alpha1.ResourceParam{{Name: "url", Value: "https://foo.git"}},
+			},
 		},
 	}
 	if d := cmp.Diff(expectedResources, m); d != "" {
@@ -527,9 +645,10 @@ func TestResolvePipelineRun(t *testing.T) {
 			tb.PipelineTaskOutputResource("output1", "git-resource"),
 		),
 	))
-	providedResources := map[string]v1alpha1.PipelineResourceRef{
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{
 		"git-resource": {
-			Name: "someresource",
+			Name:        "git-resource",
+			ResourceRef: v1alpha1.PipelineResourceRef{Name: "someresource"},
 		},
 	}
 
@@ -552,7 +671,7 @@ func TestResolvePipelineRun(t *testing.T) {
 	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, nil }
 	getResource := func(name string) (*v1alpha1.PipelineResource, error) { return r, nil }
 
-	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, p.Spec.Tasks, providedResources)
+	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getPipeline, getResource, p.Spec.Tasks, providedResources)
 	if err != nil {
 		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 	}
@@ -599,6 +718,47 @@ func TestResolvePipelineRun(t *testing.T) {
 	}
 }
 
+func TestResolvePipelineRun_ResourceSpec(t *testing.T) {
+	names.TestingSeed()
+
+	p := tb.Pipeline("pipelines", "namespace", tb.PipelineSpec(
+		tb.PipelineDeclaredResource("git-resource", "git"),
+		tb.PipelineTask("mytask1", "task",
+			tb.PipelineTaskInputResource("input1", "git-resource"),
+		),
+	))
+	spec := &v1alpha1.PipelineResourceSpec{
+		Type:   v1alpha1.PipelineResourceTypeGit,
+		Params: []v1alpha1.ResourceParam{{Name: "url", Value: "https://foo.git"}},
+	}
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{
+		"git-resource": {Name: "git-resource", ResourceSpec: spec},
+	}
+	pr := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipelinerun",
+		},
+	}
+	getTask := func(name string) (v1alpha1.TaskInterface, error) { return task, nil }
+	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, nil }
+	getResource := func(name string) (*v1alpha1.PipelineResource, error) { return nil, fmt.Errorf("should not get called") }
+
+	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getPipeline, getResource, p.Spec.Tasks, providedResources)
+	if err != nil {
+		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
+	}
+	// The resource is built from the spec of the binding instead of being retrieved
+	expectedInputs := map[string]*v1alpha1.PipelineResource{
+		"input1": {
+			ObjectMeta: metav1.ObjectMeta{Name: "input1"},
+			Spec:       *spec,
+		},
+	}
+	if d := cmp.Diff(expectedInputs, pipelineState[0].ResolvedTaskResources.Inputs); d != "" {
+		t.Errorf("Unexpected resolved inputs -want, +got: %v", d)
+	}
+}
+
 func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 	pts := []v1alpha1.PipelineTask{{
 		Name:    "mytask1",
@@ -610,7 +770,7 @@ func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 		Name:    "mytask3",
 		TaskRef: v1alpha1.TaskRef{Name: "task"},
 	}}
-	providedResources := map[string]v1alpha1.PipelineResourceRef{}
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{}
 
 	getTask := func(name string) (v1alpha1.TaskInterface, error) { return task, nil }
 	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return clustertask, nil }
@@ -620,7 +780,7 @@ func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 			Name: "pipelinerun",
 		},
 	}
-	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, pts, providedResources)
+	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getPipeline, getResource, pts, providedResources)
 	if err != nil {
 		t.Fatalf("Did not expect error when resolving PipelineRun without Resources: %v", err)
 	}
@@ -641,12 +801,127 @@ func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 	}
 }
 
+func TestResolvePipelineRun_EmbeddedTaskSpec(t *testing.T) {
+	pts := []v1alpha1.PipelineTask{{
+		Name:     "mytask1",
+		TaskSpec: &task.Spec,
+	}}
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{}
+
+	getTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getResource := func(name string) (*v1alpha1.PipelineResource, error) { return nil, fmt.Errorf("should not get called") }
+	pr := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipelinerun",
+		},
+	}
+	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getPipeline, getResource, pts, providedResources)
+	if err != nil {
+		t.Fatalf("Did not expect error when resolving PipelineRun with embedded TaskSpec: %v", err)
+	}
+	if len(pipelineState) != 1 {
+		t.Fatalf("Expected 1 resolved PipelineTask but got %d", len(pipelineState))
+	}
+	expectedTaskResources := &resources.ResolvedTaskResources{
+		TaskSpec: &task.Spec,
+		Inputs:   map[string]*v1alpha1.PipelineResource{},
+		Outputs:  map[string]*v1alpha1.PipelineResource{},
+	}
+	if d := cmp.Diff(pipelineState[0].ResolvedTaskResources, expectedTaskResources, cmpopts.IgnoreUnexported(v1alpha1.TaskRunSpec{})); d != "" {
+		t.Fatalf("Expected resources resolved from the embedded TaskSpec but actual differed: %s", d)
+	}
+}
+
+func TestResolvePipelineRun_Matrix(t *testing.T) {
+	pts := []v1alpha1.PipelineTask{{
+		Name:    "mytask1",
+		TaskRef: v1alpha1.TaskRef{Name: "task"},
+		Params: []v1alpha1.Param{{
+			Name: "flags", Value: *v1alpha1.NewArrayOrString("-v"),
+		}},
+		Matrix: []v1alpha1.Param{{
+			Name: "version", Value: *v1alpha1.NewArrayOrString("1.12", "1.13"),
+		}},
+	}}
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{}
+
+	getTask := func(name string) (v1alpha1.TaskInterface, error) { return task, nil }
+	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getResource := func(name string) (*v1alpha1.PipelineResource, error) { return nil, fmt.Errorf("should not get called") }
+	pr := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipelinerun",
+		},
+	}
+	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getPipeline, getResource, pts, providedResources)
+	if err != nil {
+		t.Fatalf("Did not expect error when resolving PipelineRun with a matrix: %v", err)
+	}
+	if len(pipelineState) != 2 {
+		t.Fatalf("Expected a resolved PipelineTask for each of the 2 combinations but got %d", len(pipelineState))
+	}
+	for i, version := range []string{"1.12", "1.13"} {
+		rprt := pipelineState[i]
+		expectedName := fmt.Sprintf("pipelinerun-mytask1-%d", i)
+		if rprt.TaskRunName != expectedName {
+			t.Errorf("Expected the TaskRun of combination %d to be named %s but was %s", i, expectedName, rprt.TaskRunName)
+		}
+		if rprt.PipelineTask.Name != "mytask1" {
+			t.Errorf("Expected combination %d to be resolved for PipelineTask mytask1 but was %s", i, rprt.PipelineTask.Name)
+		}
+		expectedParams := []v1alpha1.Param{{
+			Name: "flags", Value: *v1alpha1.NewArrayOrString("-v"),
+		}, {
+			Name: "version", Value: *v1alpha1.NewArrayOrString(version),
+		}}
+		if d := cmp.Diff(expectedParams, rprt.PipelineTask.Params); d != "" {
+			t.Errorf("Unexpected params for combination %d: %s", i, d)
+		}
+	}
+	if len(pts[0].Params) != 1 {
+		t.Errorf("Expected the params of the PipelineTask not to be modified but got %v", pts[0].Params)
+	}
+}
+
+func TestGetMatrixTaskRunName(t *testing.T) {
+	prName := "pipeline-run-with-a-very-long-name-which-leaves-little-room"
+	name := getMatrixTaskRunName(nil, "build", prName, 12)
+	if len(name) > 63 {
+		t.Errorf("Expected the name to be at most 63 characters long but got %d: %s", len(name), name)
+	}
+	if !strings.HasSuffix(name, "-12") {
+		t.Errorf("Expected the name to keep the index of the combination but got %s", name)
+	}
+	if name != getMatrixTaskRunName(nil, "build", prName, 12) {
+		t.Errorf("Expected the names of the TaskRuns of a matrix to be deterministic")
+	}
+
+	// The PipelineTasks whose names are cut to the same prefix still get different TaskRuns
+	if getMatrixTaskRunName(nil, "build-linux-amd64", prName, 1) == getMatrixTaskRunName(nil, "build-linux-arm64", prName, 1) {
+		t.Errorf("Expected the TaskRuns of PipelineTasks with the same prefix to have different names")
+	}
+
+	// The TaskRuns reused by a rerun keep the name they were given by the previous PipelineRun
+	taskRunsStatus := map[string]*v1alpha1.PipelineRunTaskRunStatus{
+		"previous-build-2":  {PipelineTaskName: "build"},
+		"previous-build-12": {PipelineTaskName: "build"},
+		"previous-test-12":  {PipelineTaskName: "test"},
+	}
+	if name := getMatrixTaskRunName(taskRunsStatus, "build", "rerun", 12); name != "previous-build-12" {
+		t.Errorf("Expected the name of the reused TaskRun previous-build-12 but got %s", name)
+	}
+	if name := getMatrixTaskRunName(taskRunsStatus, "build", "rerun", 1); name != "rerun-build-1" {
+		t.Errorf("Expected a new name for the combination which isn't reused but got %s", name)
+	}
+}
+
 func TestResolvePipelineRun_TaskDoesntExist(t *testing.T) {
 	pts := []v1alpha1.PipelineTask{{
 		Name:    "mytask1",
 		TaskRef: v1alpha1.TaskRef{Name: "task"},
 	}}
-	providedResources := map[string]v1alpha1.PipelineResourceRef{}
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{}
 
 	// Return an error when the Task is retrieved, as if it didn't exist
 	getTask := func(name string) (v1alpha1.TaskInterface, error) {
@@ -661,7 +936,7 @@ func TestResolvePipelineRun_TaskDoesntExist(t *testing.T) {
 			Name: "pipelinerun",
 		},
 	}
-	_, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, pts, providedResources)
+	_, err := ResolvePipelineRun(pr, getTask, getClusterTask, getPipeline, getResource, pts, providedResources)
 	switch err := err.(type) {
 	case nil:
 		t.Fatalf("Expected error getting non-existent Tasks for Pipeline %s but got none", p.Name)
@@ -694,7 +969,7 @@ func TestResolvePipelineRun_ResourceBindingsDontExist(t *testing.T) {
 			)),
 		},
 	}
-	providedResources := map[string]v1alpha1.PipelineResourceRef{}
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{}
 
 	getTask := func(name string) (v1alpha1.TaskInterface, error) { return task, nil }
 	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return clustertask, nil }
@@ -707,7 +982,7 @@ func TestResolvePipelineRun_ResourceBindingsDontExist(t *testing.T) {
 					Name: "pipelinerun",
 				},
 			}
-			_, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, tt.p.Spec.Tasks, providedResources)
+			_, err := ResolvePipelineRun(pr, getTask, getClusterTask, getPipeline, getResource, tt.p.Spec.Tasks, providedResources)
 			if err == nil {
 				t.Fatalf("Expected error when bindings are in incorrect state for Pipeline %s but got none", p.Name)
 			}
@@ -737,9 +1012,10 @@ func TestResolvePipelineRun_ResourcesDontExist(t *testing.T) {
 			)),
 		},
 	}
-	providedResources := map[string]v1alpha1.PipelineResourceRef{
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{
 		"git-resource": {
-			Name: "doesnt-exist",
+			Name:        "git-resource",
+			ResourceRef: v1alpha1.PipelineResourceRef{Name: "doesnt-exist"},
 		},
 	}
 
@@ -756,7 +1032,7 @@ func TestResolvePipelineRun_ResourcesDontExist(t *testing.T) {
 					Name: "pipelinerun",
 				},
 			}
-			_, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, tt.p.Spec.Tasks, providedResources)
+			_, err := ResolvePipelineRun(pr, getTask, getClusterTask, getPipeline, getResource, tt.p.Spec.Tasks, providedResources)
 			switch err := err.(type) {
 			case nil:
 				t.Fatalf("Expected error getting non-existent Resources for Pipeline %s but got none", p.Name)
@@ -1039,9 +1315,10 @@ func TestResolvePipelineRun_withExistingTaskRuns(t *testing.T) {
 			tb.PipelineTaskInputResource("input1", "git-resource"),
 		),
 	))
-	providedResources := map[string]v1alpha1.PipelineResourceRef{
+	providedResources := map[string]v1alpha1.PipelineResourceBinding{
 		"git-resource": {
-			Name: "someresource",
+			Name:        "git-resource",
+			ResourceRef: v1alpha1.PipelineResourceRef{Name: "someresource"},
 		},
 	}
 
@@ -1074,7 +1351,7 @@ func TestResolvePipelineRun_withExistingTaskRuns(t *testing.T) {
 	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, nil }
 	getResource := func(name string) (*v1alpha1.PipelineResource, error) { return r, nil }
 
-	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getResource, p.Spec.Tasks, providedResources)
+	pipelineState, err := ResolvePipelineRun(pr, getTask, getClusterTask, getPipeline, getResource, p.Spec.Tasks, providedResources)
 	if err != nil {
 		t.Fatalf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 	}
@@ -0,0 +1,124 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/knative/pkg/apis"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// ValidateRerun validates that pr can rerun previous, which must have finished executing
+// and run the same Pipeline with the same params and resources, since the TaskRuns it
+// reuses were run with those of previous.
+func ValidateRerun(previous, pr *v1alpha1.PipelineRun) error {
+	if !previous.IsDone() {
+		return fmt.Errorf("PipelineRun %s hasn't finished executing", previous.Name)
+	}
+	if previous.Spec.PipelineRef.Name != pr.Spec.PipelineRef.Name || !equality.Semantic.DeepEqual(previous.Spec.PipelineSpec, pr.Spec.PipelineSpec) {
+		return fmt.Errorf("PipelineRun %s doesn't run the same Pipeline", previous.Name)
+	}
+	if !equality.Semantic.DeepEqual(previous.Spec.Params, pr.Spec.Params) {
+		return fmt.Errorf("PipelineRun %s doesn't use the same params", previous.Name)
+	}
+	if !equality.Semantic.DeepEqual(previous.Spec.Resources, pr.Spec.Resources) {
+		return fmt.Errorf("PipelineRun %s doesn't use the same resources", previous.Name)
+	}
+	return nil
+}
+
+// ReusedRuns are the TaskRuns, child PipelineRuns and Runs of a previous PipelineRun which are
+// reused by a rerun, keyed by their names like in the status of a PipelineRun.
+type ReusedRuns struct {
+	TaskRuns          map[string]*v1alpha1.PipelineRunTaskRunStatus
+	ChildPipelineRuns map[string]*v1alpha1.PipelineRunChildStatus
+	Runs              map[string]*v1alpha1.PipelineRunRunStatus
+}
+
+// GetReusedRuns returns the status of the TaskRuns, child PipelineRuns and Runs of previous which
+// can be reused by a rerun of the Pipeline whose tasks are in d. These are the ones of the tasks
+// which succeeded, unless one of the tasks they depend on is run again.
+func GetReusedRuns(previous *v1alpha1.PipelineRun, d *v1alpha1.DAG) ReusedRuns {
+	// A task succeeded if all of its runs, e.g. one per combination of its matrix, succeeded
+	succeeded := map[string]bool{}
+	record := func(pipelineTask string, c *apis.Condition) {
+		ok, seen := succeeded[pipelineTask]
+		succeeded[pipelineTask] = (ok || !seen) && c.IsTrue()
+	}
+	for _, s := range previous.Status.TaskRuns {
+		if s.Status != nil {
+			record(s.PipelineTaskName, s.Status.GetCondition(apis.ConditionSucceeded))
+		} else {
+			record(s.PipelineTaskName, nil)
+		}
+	}
+	for _, s := range previous.Status.ChildPipelineRuns {
+		if s.Status != nil {
+			record(s.PipelineTaskName, s.Status.GetCondition(apis.ConditionSucceeded))
+		} else {
+			record(s.PipelineTaskName, nil)
+		}
+	}
+	for _, s := range previous.Status.Runs {
+		if s.Status != nil {
+			record(s.PipelineTaskName, s.Status.GetCondition(apis.ConditionSucceeded))
+		} else {
+			record(s.PipelineTaskName, nil)
+		}
+	}
+
+	reused := map[string]bool{}
+	var isReused func(n *v1alpha1.Node) bool
+	isReused = func(n *v1alpha1.Node) bool {
+		if r, ok := reused[n.Task.Name]; ok {
+			return r
+		}
+		r := succeeded[n.Task.Name]
+		for _, prev := range n.Prev {
+			r = isReused(prev) && r
+		}
+		reused[n.Task.Name] = r
+		return r
+	}
+	for _, n := range d.Nodes {
+		isReused(n)
+	}
+
+	runs := ReusedRuns{
+		TaskRuns:          map[string]*v1alpha1.PipelineRunTaskRunStatus{},
+		ChildPipelineRuns: map[string]*v1alpha1.PipelineRunChildStatus{},
+		Runs:              map[string]*v1alpha1.PipelineRunRunStatus{},
+	}
+	for name, s := range previous.Status.TaskRuns {
+		if reused[s.PipelineTaskName] {
+			runs.TaskRuns[name] = s.DeepCopy()
+		}
+	}
+	for name, s := range previous.Status.ChildPipelineRuns {
+		if reused[s.PipelineTaskName] {
+			runs.ChildPipelineRuns[name] = s.DeepCopy()
+		}
+	}
+	for name, s := range previous.Status.Runs {
+		if reused[s.PipelineTaskName] {
+			runs.Runs[name] = s.DeepCopy()
+		}
+	}
+	return runs
+}
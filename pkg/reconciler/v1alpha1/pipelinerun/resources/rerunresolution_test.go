@@ -0,0 +1,217 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/knative/pkg/apis"
+	duckv1beta1 "github.com/knative/pkg/apis/duck/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func taskRunStatus(pipelineTask string, status corev1.ConditionStatus) *v1alpha1.PipelineRunTaskRunStatus {
+	return &v1alpha1.PipelineRunTaskRunStatus{
+		PipelineTaskName: pipelineTask,
+		Status: &v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: status}},
+			},
+		},
+	}
+}
+
+func childStatus(pipelineTask string, status corev1.ConditionStatus) *v1alpha1.PipelineRunChildStatus {
+	return &v1alpha1.PipelineRunChildStatus{
+		PipelineTaskName: pipelineTask,
+		Status: &v1alpha1.PipelineRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: status}},
+			},
+		},
+	}
+}
+
+func runStatus(pipelineTask string, status corev1.ConditionStatus) *v1alpha1.PipelineRunRunStatus {
+	return &v1alpha1.PipelineRunRunStatus{
+		PipelineTaskName: pipelineTask,
+		Status: &v1alpha1.RunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: status}},
+			},
+		},
+	}
+}
+
+func TestGetReusedRuns(t *testing.T) {
+	// build -> test -> deploy, lint and docs -> publish, with a matrix for lint, and
+	// scan -> notify and approve -> release, where scan runs a child pipeline and approve and
+	// notify are custom tasks
+	d, err := v1alpha1.BuildDAG([]v1alpha1.PipelineTask{
+		{Name: "build"},
+		{Name: "test", RunAfter: []string{"build"}},
+		{Name: "deploy", RunAfter: []string{"test"}},
+		{Name: "lint"},
+		{Name: "docs"},
+		{Name: "publish", RunAfter: []string{"docs"}},
+		{Name: "scan"},
+		{Name: "approve"},
+		{Name: "notify", RunAfter: []string{"scan"}},
+		{Name: "release", RunAfter: []string{"approve"}},
+	})
+	if err != nil {
+		t.Fatalf("Didn't expect error building the DAG but got %v", err)
+	}
+	previous := &v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "previous"},
+		Status: v1alpha1.PipelineRunStatus{
+			TaskRuns: map[string]*v1alpha1.PipelineRunTaskRunStatus{
+				"previous-build-abcde":   taskRunStatus("build", corev1.ConditionTrue),
+				"previous-test-abcde":    taskRunStatus("test", corev1.ConditionFalse),
+				"previous-lint-0-abcde":  taskRunStatus("lint", corev1.ConditionTrue),
+				"previous-lint-1-abcde":  taskRunStatus("lint", corev1.ConditionTrue),
+				"previous-docs-abcde":    taskRunStatus("docs", corev1.ConditionTrue),
+				"previous-publish-abcde": taskRunStatus("publish", corev1.ConditionFalse),
+				"previous-release-abcde": taskRunStatus("release", corev1.ConditionTrue),
+			},
+			ChildPipelineRuns: map[string]*v1alpha1.PipelineRunChildStatus{
+				"previous-scan-abcde": childStatus("scan", corev1.ConditionTrue),
+			},
+			Runs: map[string]*v1alpha1.PipelineRunRunStatus{
+				"previous-approve-abcde": runStatus("approve", corev1.ConditionFalse),
+				"previous-notify-abcde":  runStatus("notify", corev1.ConditionTrue),
+			},
+		},
+	}
+
+	// Only the tasks which failed, didn't run, or depend on one of these are run again
+	expected := ReusedRuns{
+		TaskRuns: map[string]*v1alpha1.PipelineRunTaskRunStatus{
+			"previous-build-abcde":  taskRunStatus("build", corev1.ConditionTrue),
+			"previous-lint-0-abcde": taskRunStatus("lint", corev1.ConditionTrue),
+			"previous-lint-1-abcde": taskRunStatus("lint", corev1.ConditionTrue),
+			"previous-docs-abcde":   taskRunStatus("docs", corev1.ConditionTrue),
+		},
+		ChildPipelineRuns: map[string]*v1alpha1.PipelineRunChildStatus{
+			"previous-scan-abcde": childStatus("scan", corev1.ConditionTrue),
+		},
+		Runs: map[string]*v1alpha1.PipelineRunRunStatus{
+			"previous-notify-abcde": runStatus("notify", corev1.ConditionTrue),
+		},
+	}
+	if d := cmp.Diff(expected, GetReusedRuns(previous, d)); d != "" {
+		t.Errorf("Unexpected reused runs -want, +got: %v", d)
+	}
+}
+
+func TestGetReusedRuns_FailedDependency(t *testing.T) {
+	d, err := v1alpha1.BuildDAG([]v1alpha1.PipelineTask{
+		{Name: "build"},
+		{Name: "test", RunAfter: []string{"build"}},
+	})
+	if err != nil {
+		t.Fatalf("Didn't expect error building the DAG but got %v", err)
+	}
+	// A task which succeeded is run again when a task it depends on is
+	previous := &v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "previous"},
+		Status: v1alpha1.PipelineRunStatus{
+			TaskRuns: map[string]*v1alpha1.PipelineRunTaskRunStatus{
+				"previous-build-abcde": taskRunStatus("build", corev1.ConditionFalse),
+				"previous-test-abcde":  taskRunStatus("test", corev1.ConditionTrue),
+			},
+		},
+	}
+	if reused := GetReusedRuns(previous, d); len(reused.TaskRuns) != 0 {
+		t.Errorf("Expected no TaskRuns to be reused but got %v", reused.TaskRuns)
+	}
+}
+
+func TestValidateRerun(t *testing.T) {
+	done := duckv1beta1.Status{
+		Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse}},
+	}
+	pr := &v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "rerun"},
+		Spec: v1alpha1.PipelineRunSpec{
+			PipelineRef: v1alpha1.PipelineRef{Name: "pipeline"},
+			RerunOf:     &v1alpha1.PipelineRunRef{Name: "previous"},
+		},
+	}
+	for _, tc := range []struct {
+		name     string
+		previous *v1alpha1.PipelineRun
+		wantErr  bool
+	}{{
+		name: "failed run of the same pipeline",
+		previous: &v1alpha1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "previous"},
+			Spec:       v1alpha1.PipelineRunSpec{PipelineRef: v1alpha1.PipelineRef{Name: "pipeline"}},
+			Status:     v1alpha1.PipelineRunStatus{Status: done},
+		},
+	}, {
+		name: "still running",
+		previous: &v1alpha1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "previous"},
+			Spec:       v1alpha1.PipelineRunSpec{PipelineRef: v1alpha1.PipelineRef{Name: "pipeline"}},
+		},
+		wantErr: true,
+	}, {
+		name: "run of another pipeline",
+		previous: &v1alpha1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "previous"},
+			Spec:       v1alpha1.PipelineRunSpec{PipelineRef: v1alpha1.PipelineRef{Name: "other-pipeline"}},
+			Status:     v1alpha1.PipelineRunStatus{Status: done},
+		},
+		wantErr: true,
+	}, {
+		name: "run with other params",
+		previous: &v1alpha1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "previous"},
+			Spec: v1alpha1.PipelineRunSpec{
+				PipelineRef: v1alpha1.PipelineRef{Name: "pipeline"},
+				Params:      []v1alpha1.Param{{Name: "version", Value: *v1alpha1.NewArrayOrString("1.12")}},
+			},
+			Status: v1alpha1.PipelineRunStatus{Status: done},
+		},
+		wantErr: true,
+	}, {
+		name: "run with other resources",
+		previous: &v1alpha1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "previous"},
+			Spec: v1alpha1.PipelineRunSpec{
+				PipelineRef: v1alpha1.PipelineRef{Name: "pipeline"},
+				Resources: []v1alpha1.PipelineResourceBinding{{
+					Name:        "source",
+					ResourceRef: v1alpha1.PipelineResourceRef{Name: "other-repo"},
+				}},
+			},
+			Status: v1alpha1.PipelineRunStatus{Status: done},
+		},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateRerun(tc.previous, pr)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Expected error to be %t but got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/templating"
+)
+
+// ApplyTaskResults replaces the references to the results of other PipelineTasks in the params
+// of rprt with the values reported by the TaskRuns of those PipelineTasks in state. It returns an
+// error if one of the referenced results hasn't been reported.
+func ApplyTaskResults(rprt *ResolvedPipelineRunTask, state PipelineRunState) error {
+	refs := rprt.PipelineTask.ResultRefs()
+	if len(refs) == 0 {
+		return nil
+	}
+	replacements, err := state.getResultReplacements(refs)
+	if err != nil {
+		return fmt.Errorf("%v, used by PipelineTask %q", err, rprt.PipelineTask.Name)
+	}
+
+	pt := rprt.PipelineTask.DeepCopy()
+	for i := range pt.Params {
+		pt.Params[i].Value.ApplyReplacements(replacements, nil)
+	}
+	rprt.PipelineTask = pt
+	return nil
+}
+
+// ApplyFinallyTaskResults replaces the references to the results of other PipelineTasks in the
+// params of the finally task rprt with the values reported by the TaskRuns in state. Since the
+// finally tasks run even once the tasks they use the results of failed or were skipped, the
+// params using results which weren't reported are omitted, and take the default of their Task.
+func ApplyFinallyTaskResults(rprt *ResolvedPipelineRunTask, state PipelineRunState) {
+	if len(rprt.PipelineTask.ResultRefs()) == 0 {
+		return
+	}
+
+	pt := rprt.PipelineTask.DeepCopy()
+	pt.Params = nil
+	for _, p := range rprt.PipelineTask.Params {
+		replacements, err := state.getResultReplacements(p.ResultRefs())
+		if err != nil {
+			continue
+		}
+		p = *p.DeepCopy()
+		p.Value.ApplyReplacements(replacements, nil)
+		pt.Params = append(pt.Params, p)
+	}
+	rprt.PipelineTask = pt
+}
+
+// GetPipelineResults returns the values of results, computed from the results reported by the
+// TaskRuns in state. The results which use a task result that wasn't reported, for example
+// because the task was skipped or failed, are omitted.
+func GetPipelineResults(results []v1alpha1.PipelineResult, state PipelineRunState) []v1alpha1.PipelineRunResult {
+	var values []v1alpha1.PipelineRunResult
+	for _, r := range results {
+		replacements, err := state.getResultReplacements(r.ResultRefs())
+		if err != nil {
+			continue
+		}
+		values = append(values, v1alpha1.PipelineRunResult{
+			Name:  r.Name,
+			Value: templating.ApplyReplacements(r.Value, replacements),
+		})
+	}
+	return values
+}
+
+// getResultReplacements returns the values reported for refs by the TaskRuns in state, keyed
+// by the variables which reference them.
+func (state PipelineRunState) getResultReplacements(refs []v1alpha1.ResultRef) (map[string]string, error) {
+	replacements := map[string]string{}
+	for _, ref := range refs {
+		value, ok := state.getTaskResult(ref)
+		if !ok {
+			return nil, fmt.Errorf("result %q of PipelineTask %q wasn't reported", ref.Result, ref.PipelineTask)
+		}
+		replacements[ref.Variable()] = value
+	}
+	return replacements, nil
+}
+
+// getTaskResult returns the value reported for ref by the TaskRun of its PipelineTask in state, by
+// the child PipelineRun as one of its pipeline results if the PipelineTask runs a Pipeline, or by
+// the Run if it runs a custom task.
+func (state PipelineRunState) getTaskResult(ref v1alpha1.ResultRef) (string, bool) {
+	for _, t := range state {
+		if t.PipelineTask.Name != ref.PipelineTask {
+			continue
+		}
+		if t.TaskRun != nil {
+			for _, r := range t.TaskRun.Status.TaskResults {
+				if r.Name == ref.Result {
+					return r.Value, true
+				}
+			}
+		}
+		if t.PipelineRun != nil {
+			for _, r := range t.PipelineRun.Status.PipelineResults {
+				if r.Name == ref.Result {
+					return r.Value, true
+				}
+			}
+		}
+		if t.Run != nil {
+			for _, r := range t.Run.Status.Results {
+				if r.Name == ref.Result {
+					return r.Value, true
+				}
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func resultsState(results ...v1alpha1.TaskRunResult) PipelineRunState {
+	tr := makeSucceeded(trs[0])
+	tr.Status.TaskResults = results
+	return PipelineRunState{{
+		PipelineTask: &pts[0],
+		TaskRunName:  "pipelinerun-mytask1",
+		TaskRun:      tr,
+	}, {
+		PipelineTask: &v1alpha1.PipelineTask{
+			Name:    "mytask2",
+			TaskRef: v1alpha1.TaskRef{Name: "task"},
+			Params: []v1alpha1.Param{{
+				Name:  "image",
+				Value: *v1alpha1.NewArrayOrString("gcr.io/foo/bar@${tasks.mytask1.results.digest}"),
+			}, {
+				Name:  "version",
+				Value: *v1alpha1.NewArrayOrString("${tasks.mytask1.results.version}"),
+			}, {
+				Name:  "static",
+				Value: *v1alpha1.NewArrayOrString("value"),
+			}},
+		},
+		TaskRunName: "pipelinerun-mytask2",
+	}}
+}
+
+func TestApplyTaskResults(t *testing.T) {
+	state := resultsState(v1alpha1.TaskRunResult{
+		Name: "digest", Value: "sha256:abcd",
+	}, v1alpha1.TaskRunResult{
+		Name: "version", Value: "v0.1",
+	})
+	original := state[1].PipelineTask
+
+	if err := ApplyTaskResults(state[1], state); err != nil {
+		t.Fatalf("Didn't expect error applying task results but got %v", err)
+	}
+	expectedParams := []v1alpha1.Param{{
+		Name:  "image",
+		Value: *v1alpha1.NewArrayOrString("gcr.io/foo/bar@sha256:abcd"),
+	}, {
+		Name:  "version",
+		Value: *v1alpha1.NewArrayOrString("v0.1"),
+	}, {
+		Name:  "static",
+		Value: *v1alpha1.NewArrayOrString("value"),
+	}}
+	if d := cmp.Diff(expectedParams, state[1].PipelineTask.Params); d != "" {
+		t.Errorf("Unexpected params -want, +got: %v", d)
+	}
+	if original.Params[0].Value.StringVal != "gcr.io/foo/bar@${tasks.mytask1.results.digest}" {
+		t.Errorf("Expected the PipelineTask of the Pipeline not to be modified but params were %v", original.Params)
+	}
+}
+
+func TestApplyTaskResults_NoRefs(t *testing.T) {
+	state := resultsState()
+	if err := ApplyTaskResults(state[0], state); err != nil {
+		t.Fatalf("Didn't expect error applying task results but got %v", err)
+	}
+	if state[0].PipelineTask != &pts[0] {
+		t.Errorf("Expected the PipelineTask not to be replaced when it doesn't use any results")
+	}
+}
+
+func TestApplyTaskResults_Missing(t *testing.T) {
+	state := resultsState(v1alpha1.TaskRunResult{
+		Name: "digest", Value: "sha256:abcd",
+	})
+	if err := ApplyTaskResults(state[1], state); err == nil {
+		t.Fatalf("Expected error when a result which wasn't reported is used")
+	}
+}
+
+func TestApplyFinallyTaskResults(t *testing.T) {
+	state := resultsState(v1alpha1.TaskRunResult{
+		Name: "digest", Value: "sha256:abcd",
+	})
+
+	ApplyFinallyTaskResults(state[1], state)
+	expectedParams := []v1alpha1.Param{{
+		Name:  "image",
+		Value: *v1alpha1.NewArrayOrString("gcr.io/foo/bar@sha256:abcd"),
+	}, {
+		Name:  "static",
+		Value: *v1alpha1.NewArrayOrString("value"),
+	}}
+	if d := cmp.Diff(expectedParams, state[1].PipelineTask.Params); d != "" {
+		t.Errorf("Unexpected params -want, +got: %v", d)
+	}
+}
+
+func TestGetPipelineResults(t *testing.T) {
+	state := resultsState(v1alpha1.TaskRunResult{
+		Name: "digest", Value: "sha256:abcd",
+	}, v1alpha1.TaskRunResult{
+		Name: "version", Value: "v0.1",
+	})
+	results := []v1alpha1.PipelineResult{{
+		Name:  "image",
+		Value: "gcr.io/foo/bar:${tasks.mytask1.results.version}@${tasks.mytask1.results.digest}",
+	}, {
+		Name:  "not-reported",
+		Value: "${tasks.mytask1.results.missing}",
+	}, {
+		Name:  "not-run",
+		Value: "${tasks.mytask2.results.digest}",
+	}}
+
+	expected := []v1alpha1.PipelineRunResult{{
+		Name:  "image",
+		Value: "gcr.io/foo/bar:v0.1@sha256:abcd",
+	}}
+	if d := cmp.Diff(expected, GetPipelineResults(results, state)); d != "" {
+		t.Errorf("Unexpected pipeline results -want, +got: %v", d)
+	}
+}
+
+func TestApplyTaskResults_ChildPipeline(t *testing.T) {
+	child := tb.PipelineRun("pipelinerun-mytask1", namespace, tb.PipelineRunSpec("child-pipeline"))
+	child.Status.PipelineResults = []v1alpha1.PipelineRunResult{{
+		Name: "digest", Value: "sha256:abcd",
+	}, {
+		Name: "version", Value: "v0.1",
+	}}
+	state := resultsState()
+	state[0].PipelineTask = &v1alpha1.PipelineTask{
+		Name:    "mytask1",
+		TaskRef: v1alpha1.TaskRef{Name: "child-pipeline", Kind: v1alpha1.PipelineKind},
+	}
+	state[0].TaskRun = nil
+	state[0].PipelineRun = child
+
+	if err := ApplyTaskResults(state[1], state); err != nil {
+		t.Fatalf("Didn't expect error applying the results of the child PipelineRun but got %v", err)
+	}
+	if v := state[1].PipelineTask.Params[0].Value.StringVal; v != "gcr.io/foo/bar@sha256:abcd" {
+		t.Errorf("Expected the result of the child PipelineRun to be applied but got %s", v)
+	}
+}
+
+func TestApplyTaskResults_CustomTask(t *testing.T) {
+	run := makeRun("pipelinerun-mytask1", corev1.ConditionTrue)
+	run.Status.Results = []v1alpha1.RunResult{{
+		Name: "digest", Value: "sha256:abcd",
+	}, {
+		Name: "version", Value: "v0.1",
+	}}
+	state := resultsState()
+	state[0].PipelineTask = &v1alpha1.PipelineTask{
+		Name:    "mytask1",
+		TaskRef: customTask.TaskRef,
+	}
+	state[0].TaskRun = nil
+	state[0].Run = run
+
+	if err := ApplyTaskResults(state[1], state); err != nil {
+		t.Fatalf("Didn't expect error applying the results of the Run but got %v", err)
+	}
+	if v := state[1].PipelineTask.Params[1].Value.StringVal; v != "v0.1" {
+		t.Errorf("Expected the result of the Run to be applied but got %s", v)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/names"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// GetRun is a function that will retrieve the Run name.
+type GetRun func(name string) (*v1alpha1.Run, error)
+
+// IsCustomTask returns true if the PipelineTask of t runs a custom task, in a Run named
+// TaskRunName which is executed by the controller implementing its kind, instead of a Task.
+func (t *ResolvedPipelineRunTask) IsCustomTask() bool {
+	return t.PipelineTask != nil && t.PipelineTask.TaskRef.IsCustomTask() && t.PipelineTask.TaskSpec == nil
+}
+
+// ResolveRuns will go through all tasks in state which run a custom task and check if their
+// Runs exist by calling getRun.
+func ResolveRuns(getRun GetRun, state PipelineRunState) error {
+	for _, rprt := range state {
+		if !rprt.IsCustomTask() {
+			continue
+		}
+		run, err := getRun(rprt.TaskRunName)
+		if err != nil {
+			// If the Run isn't found, it just means it hasn't been run yet
+			if !errors.IsNotFound(err) {
+				return fmt.Errorf("error retrieving Run %s: %s", rprt.TaskRunName, err)
+			}
+			continue
+		}
+		rprt.Run = run
+	}
+	return nil
+}
+
+// getRunName should return a unique name for the `Run` of a PipelineTask running a custom task
+// if one has not already been defined, and the existing one otherwise.
+func getRunName(runsStatus map[string]*v1alpha1.PipelineRunRunStatus, ptName, prName string) string {
+	for k, v := range runsStatus {
+		if v.PipelineTaskName == ptName {
+			return k
+		}
+	}
+
+	return names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("%s-%s", prName, ptName))
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/knative/pkg/apis"
+	duckv1beta1 "github.com/knative/pkg/apis/duck/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/names"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var customTask = v1alpha1.PipelineTask{
+	Name: "approve",
+	TaskRef: v1alpha1.TaskRef{
+		APIVersion: "example.dev/v1",
+		Kind:       "Approval",
+		Name:       "release-approval",
+	},
+}
+
+func makeRun(name string, status corev1.ConditionStatus) *v1alpha1.Run {
+	return &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       v1alpha1.RunSpec{Ref: &customTask.TaskRef},
+		Status: v1alpha1.RunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: status}},
+			},
+		},
+	}
+}
+
+func TestResolvePipelineRun_CustomTask(t *testing.T) {
+	names.TestingSeed()
+
+	pr := v1alpha1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+	}
+	getTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getClusterTask := func(name string) (v1alpha1.TaskInterface, error) { return nil, fmt.Errorf("should not get called") }
+	getResource := func(name string) (*v1alpha1.PipelineResource, error) { return nil, fmt.Errorf("should not get called") }
+
+	state, err := ResolvePipelineRun(pr, getTask, getClusterTask, getPipeline, getResource, []v1alpha1.PipelineTask{customTask}, nil)
+	if err != nil {
+		t.Fatalf("Didn't expect error resolving the PipelineRun but got %v", err)
+	}
+	rprt := state[0]
+	if !rprt.IsCustomTask() || rprt.IsPipeline() {
+		t.Errorf("Expected the task to run a custom task")
+	}
+	if rprt.TaskRunName != "pipelinerun-approve-9l9zj" {
+		t.Errorf("Expected the Run to be named pipelinerun-approve-9l9zj but was %s", rprt.TaskRunName)
+	}
+	if rprt.ResolvedTaskResources.TaskSpec != nil {
+		t.Errorf("Expected no TaskSpec to be resolved but got %v", rprt.ResolvedTaskResources.TaskSpec)
+	}
+
+	// Once the Run has been created, its name is kept
+	pr.Status.Runs = map[string]*v1alpha1.PipelineRunRunStatus{
+		"pipelinerun-approve-abcde": {PipelineTaskName: "approve"},
+	}
+	state, err = ResolvePipelineRun(pr, getTask, getClusterTask, getPipeline, getResource, []v1alpha1.PipelineTask{customTask}, nil)
+	if err != nil {
+		t.Fatalf("Didn't expect error resolving the PipelineRun but got %v", err)
+	}
+	if state[0].TaskRunName != "pipelinerun-approve-abcde" {
+		t.Errorf("Expected the existing Run name to be used but got %s", state[0].TaskRunName)
+	}
+}
+
+func TestResolveRuns(t *testing.T) {
+	run := makeRun("pipelinerun-approve", corev1.ConditionUnknown)
+	state := PipelineRunState{{
+		PipelineTask: &customTask,
+		TaskRunName:  "pipelinerun-approve",
+	}, {
+		PipelineTask: &pts[0],
+		TaskRunName:  "pipelinerun-mytask1",
+	}}
+	getRun := func(name string) (*v1alpha1.Run, error) {
+		if name == run.Name {
+			return run, nil
+		}
+		return nil, fmt.Errorf("should not get called for %s", name)
+	}
+	if err := ResolveRuns(getRun, state); err != nil {
+		t.Fatalf("Didn't expect error resolving Runs but got %v", err)
+	}
+	if state[0].Run != run {
+		t.Errorf("Expected the Run to be resolved but was %v", state[0].Run)
+	}
+	if !state[0].IsStarted() || state[0].isDone() {
+		t.Errorf("Expected the custom task to be started and running")
+	}
+	if state[1].Run != nil {
+		t.Errorf("Expected no Run to be resolved for a task running a Task but got %v", state[1].Run)
+	}
+}
+
+func TestResolveRuns_NotStarted(t *testing.T) {
+	state := PipelineRunState{{
+		PipelineTask: &customTask,
+		TaskRunName:  "pipelinerun-approve",
+	}}
+	getRun := func(name string) (*v1alpha1.Run, error) {
+		return nil, errors.NewNotFound(v1alpha1.Resource("run"), name)
+	}
+	if err := ResolveRuns(getRun, state); err != nil {
+		t.Fatalf("Didn't expect error resolving Runs but got %v", err)
+	}
+	if state[0].IsStarted() {
+		t.Errorf("Expected the custom task not to be started")
+	}
+
+	getRun = func(name string) (*v1alpha1.Run, error) {
+		return nil, fmt.Errorf("something has gone wrong")
+	}
+	if err := ResolveRuns(getRun, state); err == nil {
+		t.Fatalf("Expected to get an error when unable to resolve Runs")
+	}
+}
+
+func TestGetPipelineConditionStatus_CustomTask(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		status          corev1.ConditionStatus
+		expectedStatus  corev1.ConditionStatus
+		expectedMessage string
+	}{{
+		name:           "running",
+		status:         corev1.ConditionUnknown,
+		expectedStatus: corev1.ConditionUnknown,
+	}, {
+		name:           "succeeded",
+		status:         corev1.ConditionTrue,
+		expectedStatus: corev1.ConditionTrue,
+	}, {
+		name:            "failed",
+		status:          corev1.ConditionFalse,
+		expectedStatus:  corev1.ConditionFalse,
+		expectedMessage: "Run pipelinerun-approve has failed",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			state := PipelineRunState{{
+				PipelineTask: &customTask,
+				TaskRunName:  "pipelinerun-approve",
+				Run:          makeRun("pipelinerun-approve", tc.status),
+			}}
+			c := GetPipelineConditionStatus("somepipelinerun", state, zap.NewNop().Sugar(), &metav1.Time{Time: time.Now()}, nil, v1alpha1.FailurePolicyFailFast)
+			if c.Status != tc.expectedStatus {
+				t.Fatalf("Expected to get status %s but got %s", tc.expectedStatus, c.Status)
+			}
+			if tc.expectedMessage != "" && c.Message != tc.expectedMessage {
+				t.Errorf("Expected message %q but got %q", tc.expectedMessage, c.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/list"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/volumeclaim"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ValidateWorkspaceBindings validates that all the workspaces declared by Pipeline p are bound
+// in PipelineRun pr, and that pr doesn't bind any other workspace.
+func ValidateWorkspaceBindings(p *v1alpha1.Pipeline, pr *v1alpha1.PipelineRun) error {
+	declared := make([]string, 0, len(p.Spec.Workspaces))
+	for _, w := range p.Spec.Workspaces {
+		declared = append(declared, w.Name)
+	}
+	bound := make([]string, 0, len(pr.Spec.Workspaces))
+	for _, wb := range pr.Spec.Workspaces {
+		bound = append(bound, wb.Name)
+	}
+	if err := list.IsSame(declared, bound); err != nil {
+		return fmt.Errorf("PipelineRun bound workspaces didn't match Pipeline: %s", err)
+	}
+	return nil
+}
+
+// GetTaskRunWorkspaces returns the bindings of the workspaces of the Task of pt, made from the
+// bindings in pr of the workspaces of the Pipeline they are mapped to. The workspaces bound to
+// a volumeClaimTemplate are bound to the claim created for pr, so that all of the TaskRuns
+// share the same volume.
+func GetTaskRunWorkspaces(pr *v1alpha1.PipelineRun, pt *v1alpha1.PipelineTask) []v1alpha1.WorkspaceBinding {
+	bindings := map[string]v1alpha1.WorkspaceBinding{}
+	for _, wb := range pr.Spec.Workspaces {
+		bindings[wb.Name] = wb
+	}
+
+	var workspaces []v1alpha1.WorkspaceBinding
+	for _, w := range pt.Workspaces {
+		wb, ok := bindings[w.Workspace]
+		if !ok {
+			continue
+		}
+		b := *wb.DeepCopy()
+		b.Name = w.Name
+		if wb.VolumeClaimTemplate != nil {
+			b.VolumeClaimTemplate = nil
+			b.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: volumeclaim.GetPersistentVolumeClaimName(wb, pr.GetOwnerReference()[0]),
+			}
+		}
+		workspaces = append(workspaces, b)
+	}
+	return workspaces
+}
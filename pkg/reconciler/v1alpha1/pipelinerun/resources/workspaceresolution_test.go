@@ -0,0 +1,101 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/volumeclaim"
+	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateWorkspaceBindings(t *testing.T) {
+	p := tb.Pipeline("pipelines", "namespace", tb.PipelineSpec(
+		tb.PipelineWorkspace("source", ""),
+	))
+	tcs := []struct {
+		name    string
+		pr      *v1alpha1.PipelineRun
+		wantErr bool
+	}{{
+		name: "bound",
+		pr: tb.PipelineRun("pipelinerun", "namespace", tb.PipelineRunSpec("pipeline",
+			tb.PipelineRunWorkspace("source", tb.WorkspaceBindingEmptyDir))),
+	}, {
+		name:    "not-bound",
+		pr:      tb.PipelineRun("pipelinerun", "namespace", tb.PipelineRunSpec("pipeline")),
+		wantErr: true,
+	}, {
+		name: "extra-binding",
+		pr: tb.PipelineRun("pipelinerun", "namespace", tb.PipelineRunSpec("pipeline",
+			tb.PipelineRunWorkspace("source", tb.WorkspaceBindingEmptyDir),
+			tb.PipelineRunWorkspace("other", tb.WorkspaceBindingEmptyDir))),
+		wantErr: true,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateWorkspaceBindings(p, tc.pr)
+			if tc.wantErr && err == nil {
+				t.Errorf("Expected error validating the workspace bindings but got none")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("Didn't expect error validating the workspace bindings but got %v", err)
+			}
+		})
+	}
+}
+
+func TestGetTaskRunWorkspaces(t *testing.T) {
+	template := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "mypvc"},
+	}
+	pr := tb.PipelineRun("pipelinerun", "namespace", tb.PipelineRunSpec("pipeline",
+		tb.PipelineRunWorkspace("shared", tb.WorkspaceBindingSubPath("src"),
+			tb.WorkspaceBindingVolumeClaimTemplate(template)),
+		tb.PipelineRunWorkspace("secret", tb.WorkspaceBindingSecret("mysecret")),
+	))
+	pt := &v1alpha1.PipelineTask{
+		Name: "build",
+		Workspaces: []v1alpha1.WorkspacePipelineTaskBinding{{
+			Name:      "source",
+			Workspace: "shared",
+		}, {
+			Name:      "credentials",
+			Workspace: "secret",
+		}},
+	}
+
+	expected := []v1alpha1.WorkspaceBinding{{
+		Name:    "source",
+		SubPath: "src",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: volumeclaim.GetPersistentVolumeClaimName(pr.Spec.Workspaces[0], pr.GetOwnerReference()[0]),
+		},
+	}, {
+		Name:   "credentials",
+		Secret: &corev1.SecretVolumeSource{SecretName: "mysecret"},
+	}}
+	if d := cmp.Diff(expected, GetTaskRunWorkspaces(pr, pt)); d != "" {
+		t.Errorf("Unexpected workspace bindings -want, +got: %v", d)
+	}
+	if pr.Spec.Workspaces[0].Name != "shared" || pr.Spec.Workspaces[0].VolumeClaimTemplate == nil {
+		t.Errorf("Expected the bindings of the PipelineRun not to be modified but were %v", pr.Spec.Workspaces)
+	}
+}
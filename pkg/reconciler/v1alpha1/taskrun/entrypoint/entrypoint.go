@@ -20,6 +20,7 @@ import (
 	"flag"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
@@ -28,6 +29,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/names"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -43,12 +45,28 @@ const (
 	InitContainerName = "place-tools"
 	digestSeparator   = "@"
 	cacheSize         = 1024
+	// ResultsMountName is the name of the volume the results
+	// of the Task are written to
+	ResultsMountName = "results"
+	ResultsDir       = "/builder/results"
+	// ScriptsInitContainerName is the name of the step which writes
+	// the scripts of the steps to the files they run
+	ScriptsInitContainerName = "place-scripts"
+	ScriptsDir               = MountPoint + "/scripts"
+	// defaultScriptShebang is prepended to the scripts which don't start
+	// with a shebang line, so that they are run by the shell and stop at
+	// the first failing command
+	defaultScriptShebang = "#!/bin/sh\nset -xe\n"
 )
 
 var toolsMount = corev1.VolumeMount{
 	Name:      MountName,
 	MountPath: MountPoint,
 }
+var resultsMount = corev1.VolumeMount{
+	Name:      ResultsMountName,
+	MountPath: ResultsDir,
+}
 var (
 	entrypointImage = flag.String("entrypoint-image", "override-with-entrypoint:latest",
 		"The container image containing our entrypoint binary.")
@@ -98,20 +116,86 @@ func AddCopyStep(spec *v1alpha1.TaskSpec) {
 		Args:         []string{"-c", fmt.Sprintf("cp /ko-app/entrypoint %s", BinaryLocation)},
 		VolumeMounts: []corev1.VolumeMount{toolsMount},
 	}
-	spec.Steps = append([]corev1.Container{cp}, spec.Steps...)
+	spec.Steps = append([]v1alpha1.Step{{Container: cp}}, spec.Steps...)
+
+}
 
+// AddScripts will prepend a Step (Container) that will write the scripts of the
+// steps of spec to the files they run, in the volume mounted at MountPoint. The
+// steps must already have been redirected, so that they run these files, and no
+// step must have been added since.
+func AddScripts(spec *v1alpha1.TaskSpec) {
+	var script strings.Builder
+	for i := range spec.Steps {
+		step := &spec.Steps[i]
+		if step.Script == "" {
+			continue
+		}
+		content := step.Script
+		if !strings.HasPrefix(content, "#!") {
+			content = defaultScriptShebang + content
+		}
+		// The heredoc delimiter is quoted so that the script isn't expanded.
+		delimiter := names.SimpleNameGenerator.RestrictLengthWithRandomSuffix("script-heredoc")
+		path := scriptPath(i)
+		fmt.Fprintf(&script, "cat > %s << '%s'\n%s\n%s\nchmod +x %s\n", path, delimiter, content, delimiter, path)
+		step.Script = ""
+	}
+	if script.Len() == 0 {
+		return
+	}
+	place := corev1.Container{
+		Name:         ScriptsInitContainerName,
+		Image:        *entrypointImage,
+		Command:      []string{"/bin/sh"},
+		Args:         []string{"-c", fmt.Sprintf("mkdir -p %s\n%s", ScriptsDir, script.String())},
+		VolumeMounts: []corev1.VolumeMount{toolsMount},
+	}
+	spec.Steps = append([]v1alpha1.Step{{Container: place}}, spec.Steps...)
+}
+
+// AddResultsDir will modify each of the steps of spec, which must already have
+// been redirected, so that the entrypoint reports the results the Task declares,
+// and will add the volume the results are written to.
+func AddResultsDir(spec *v1alpha1.TaskSpec) {
+	if len(spec.Results) == 0 {
+		return
+	}
+	for i := range spec.Steps {
+		step := &spec.Steps[i]
+		step.Args = append([]string{"-results_dir", ResultsDir}, step.Args...)
+		step.VolumeMounts = append(step.VolumeMounts, resultsMount)
+	}
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: ResultsMountName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
 }
 
 // RedirectSteps will modify each of the steps/containers such that
 // the binary being run is no longer the one specified by the Command
 // and the Args, but is instead the entrypoint binary, which will
 // itself invoke the Command and Args, but also capture logs.
-func RedirectSteps(cache *Cache, steps []corev1.Container, kubeclient kubernetes.Interface, taskRun *v1alpha1.TaskRun, logger *zap.SugaredLogger) error {
+// The steps which have a script run the file the script is written to by AddScripts,
+// the steps which have a timeout are killed by the entrypoint once it is exceeded, and
+// the steps which continue after an error don't make the entrypoint skip the next steps.
+func RedirectSteps(cache *Cache, steps []v1alpha1.Step, kubeclient kubernetes.Interface, taskRun *v1alpha1.TaskRun, logger *zap.SugaredLogger) error {
 	for i := range steps {
 		step := &steps[i]
-		if err := RedirectStep(cache, i, step, kubeclient, taskRun, logger); err != nil {
+		if step.Script != "" {
+			step.Command = []string{scriptPath(i)}
+		}
+		if err := RedirectStep(cache, i, &step.Container, kubeclient, taskRun, logger); err != nil {
 			return err
 		}
+		if step.Timeout != nil {
+			step.Args = append([]string{"-timeout", step.Timeout.Duration.String()}, step.Args...)
+		}
+		if step.OnError != "" {
+			step.Args = append([]string{"-on_error", string(step.OnError)}, step.Args...)
+		}
 	}
 
 	return nil
@@ -138,6 +222,10 @@ func RedirectStep(cache *Cache, stepNum int, step *corev1.Container, kubeclient
 	return nil
 }
 
+func scriptPath(stepNum int) string {
+	return fmt.Sprintf("%s/script-%d", ScriptsDir, stepNum)
+}
+
 // GetArgs returns the arguments that should be specified for the step which has been wrapped
 // such that it will execute our custom entrypoint instead of the user provided Command and Args.
 func GetArgs(stepNum int, commands, args []string) []string {
@@ -8,12 +8,14 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/partial"
 	"github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/names"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 	corev1 "k8s.io/api/core/v1"
@@ -26,16 +28,16 @@ const (
 )
 
 func TestRewriteSteps(t *testing.T) {
-	inputs := []corev1.Container{
-		{
+	inputs := []v1alpha1.Step{
+		{Container: corev1.Container{
 			Image:   "image",
 			Command: []string{"abcd"},
-		},
-		{
+		}},
+		{Container: corev1.Container{
 			Image:   "my.registry.svc/image:tag",
 			Command: []string{"abcd"},
 			Args:    []string{"efgh"},
-		},
+		}},
 	}
 	taskRun := &v1alpha1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
@@ -44,11 +46,11 @@ func TestRewriteSteps(t *testing.T) {
 		},
 		Spec: v1alpha1.TaskRunSpec{
 			TaskSpec: &v1alpha1.TaskSpec{
-				Steps: []corev1.Container{{
+				Steps: []v1alpha1.Step{{Container: corev1.Container{
 					Image:   "ubuntu",
 					Command: []string{"echo"},
 					Args:    []string{"hello"},
-				}},
+				}}},
 			},
 		},
 	}
@@ -76,6 +78,42 @@ func TestRewriteSteps(t *testing.T) {
 	}
 }
 
+func TestRedirectStepsWithTimeout(t *testing.T) {
+	steps := []v1alpha1.Step{{
+		Container: corev1.Container{Name: "test", Image: "ubuntu", Command: []string{"echo"}},
+		Timeout:   &metav1.Duration{Duration: 90 * time.Second},
+	}}
+	observer, _ := observer.New(zap.InfoLevel)
+	entrypointCache, _ := NewCache()
+	c := fakekubeclientset.NewSimpleClientset()
+	if err := RedirectSteps(entrypointCache, steps, c, &v1alpha1.TaskRun{}, zap.New(observer).Sugar()); err != nil {
+		t.Fatalf("failed to redirect steps: %v", err)
+	}
+
+	expectedArgs := []string{"-timeout", "1m30s", "-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "echo", "--"}
+	if d := cmp.Diff(expectedArgs, steps[0].Args); d != "" {
+		t.Errorf("step args diff -want, +got: %v", d)
+	}
+}
+
+func TestRedirectStepsWithOnError(t *testing.T) {
+	steps := []v1alpha1.Step{{
+		Container: corev1.Container{Name: "test", Image: "ubuntu", Command: []string{"echo"}},
+		OnError:   v1alpha1.StepOnErrorContinue,
+	}}
+	observer, _ := observer.New(zap.InfoLevel)
+	entrypointCache, _ := NewCache()
+	c := fakekubeclientset.NewSimpleClientset()
+	if err := RedirectSteps(entrypointCache, steps, c, &v1alpha1.TaskRun{}, zap.New(observer).Sugar()); err != nil {
+		t.Fatalf("failed to redirect steps: %v", err)
+	}
+
+	expectedArgs := []string{"-on_error", "continue", "-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "echo", "--"}
+	if d := cmp.Diff(expectedArgs, steps[0].Args); d != "" {
+		t.Errorf("step args diff -want, +got: %v", d)
+	}
+}
+
 func TestGetArgs(t *testing.T) {
 	// first step
 	// multiple commands
@@ -241,11 +279,11 @@ func TestGetRemoteEntrypoint(t *testing.T) {
 		Spec: v1alpha1.TaskRunSpec{
 			ServiceAccount: "default",
 			TaskSpec: &v1alpha1.TaskSpec{
-				Steps: []corev1.Container{{
+				Steps: []v1alpha1.Step{{Container: corev1.Container{
 					Image:   "ubuntu",
 					Command: []string{"echo"},
 					Args:    []string{"hello"},
-				}},
+				}}},
 			},
 		},
 	}
@@ -310,11 +348,11 @@ func TestGetRemoteEntrypointWithNonDefaultSA(t *testing.T) {
 		Spec: v1alpha1.TaskRunSpec{
 			ServiceAccount: "some-other-sa",
 			TaskSpec: &v1alpha1.TaskSpec{
-				Steps: []corev1.Container{{
+				Steps: []v1alpha1.Step{{Container: corev1.Container{
 					Image:   "ubuntu",
 					Command: []string{"echo"},
 					Args:    []string{"hello"},
-				}},
+				}}},
 			},
 		},
 	}
@@ -360,11 +398,11 @@ func TestEntrypointCacheLRU(t *testing.T) {
 
 func TestAddCopyStep(t *testing.T) {
 	ts := &v1alpha1.TaskSpec{
-		Steps: []corev1.Container{{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
 			Name: "test",
-		}, {
+		}}, {Container: corev1.Container{
 			Name: "test",
-		}},
+		}}},
 	}
 
 	expectedSteps := len(ts.Steps) + 1
@@ -376,3 +414,112 @@ func TestAddCopyStep(t *testing.T) {
 		t.Errorf("entrypoint is incorrect: %s should be %s", ts.Steps[0].Name, InitContainerName)
 	}
 }
+
+func TestAddResultsDir(t *testing.T) {
+	ts := &v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
+			Name:    "test",
+			Command: []string{BinaryLocation},
+			Args:    GetArgs(0, []string{"echo"}, []string{"hello"}),
+		}}},
+		Results: []v1alpha1.TaskResult{{Name: "sha"}},
+	}
+
+	AddResultsDir(ts)
+	expectedArgs := []string{"-results_dir", ResultsDir, "-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "echo", "--", "hello"}
+	if d := cmp.Diff(expectedArgs, ts.Steps[0].Args); d != "" {
+		t.Errorf("step args diff -want, +got: %v", d)
+	}
+	if d := cmp.Diff([]corev1.VolumeMount{resultsMount}, ts.Steps[0].VolumeMounts); d != "" {
+		t.Errorf("step volume mounts diff -want, +got: %v", d)
+	}
+	if len(ts.Volumes) != 1 || ts.Volumes[0].Name != ResultsMountName {
+		t.Errorf("expected the results volume to be added but volumes were %v", ts.Volumes)
+	}
+}
+
+func TestAddResultsDirWithoutResults(t *testing.T) {
+	ts := &v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
+			Name: "test",
+		}}},
+	}
+
+	AddResultsDir(ts)
+	if len(ts.Steps[0].Args) != 0 || len(ts.Steps[0].VolumeMounts) != 0 || len(ts.Volumes) != 0 {
+		t.Errorf("expected the TaskSpec not to be modified when no results are declared but was %v", ts)
+	}
+}
+
+func TestAddScripts(t *testing.T) {
+	names.TestingSeed()
+	ts := &v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{
+			Container: corev1.Container{Name: "no-shebang", Image: "ubuntu"},
+			Script:    "echo hello",
+		}, {
+			Container: corev1.Container{Name: "command", Image: "ubuntu", Command: []string{"ls"}},
+		}, {
+			Container: corev1.Container{Name: "shebang", Image: "python"},
+			Script:    "#!/usr/bin/env python\nprint('hello')",
+		}},
+	}
+	observer, _ := observer.New(zap.InfoLevel)
+	entrypointCache, _ := NewCache()
+	c := fakekubeclientset.NewSimpleClientset()
+	if err := RedirectSteps(entrypointCache, ts.Steps, c, &v1alpha1.TaskRun{}, zap.New(observer).Sugar()); err != nil {
+		t.Fatalf("failed to redirect steps: %v", err)
+	}
+
+	AddScripts(ts)
+	if len(ts.Steps) != 4 {
+		t.Fatalf("expected the step placing the scripts to be added but steps were %v", ts.Steps)
+	}
+	place := ts.Steps[0]
+	if place.Name != ScriptsInitContainerName {
+		t.Errorf("expected the first step to be %s but was %s", ScriptsInitContainerName, place.Name)
+	}
+	expectedArgs := []string{"-c", `mkdir -p /builder/tools/scripts
+cat > /builder/tools/scripts/script-0 << 'script-heredoc-9l9zj'
+#!/bin/sh
+set -xe
+echo hello
+script-heredoc-9l9zj
+chmod +x /builder/tools/scripts/script-0
+cat > /builder/tools/scripts/script-2 << 'script-heredoc-mz4c7'
+#!/usr/bin/env python
+print('hello')
+script-heredoc-mz4c7
+chmod +x /builder/tools/scripts/script-2
+`}
+	if d := cmp.Diff(expectedArgs, place.Args); d != "" {
+		t.Errorf("place scripts args diff -want, +got: %v", d)
+	}
+
+	expectedStepArgs := [][]string{
+		{"-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "/builder/tools/scripts/script-0", "--"},
+		{"-wait_file", "/builder/tools/0", "-post_file", "/builder/tools/1", "-entrypoint", "ls", "--"},
+		{"-wait_file", "/builder/tools/1", "-post_file", "/builder/tools/2", "-entrypoint", "/builder/tools/scripts/script-2", "--"},
+	}
+	for i, step := range ts.Steps[1:] {
+		if step.Script != "" {
+			t.Errorf("expected the script of step %s to be cleared but was %q", step.Name, step.Script)
+		}
+		if d := cmp.Diff(expectedStepArgs[i], step.Args); d != "" {
+			t.Errorf("step %s args diff -want, +got: %v", step.Name, d)
+		}
+	}
+}
+
+func TestAddScriptsWithoutScripts(t *testing.T) {
+	ts := &v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
+			Name: "test",
+		}}},
+	}
+
+	AddScripts(ts)
+	if len(ts.Steps) != 1 {
+		t.Errorf("expected no step to be added when no step has a script but steps were %v", ts.Steps)
+	}
+}
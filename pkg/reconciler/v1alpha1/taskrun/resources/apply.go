@@ -18,27 +18,55 @@ package resources
 
 import (
 	"fmt"
+	"strconv"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/templating"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // ApplyParameters applies the params from a TaskRun.Input.Parameters to a TaskSpec
 func ApplyParameters(spec *v1alpha1.TaskSpec, tr *v1alpha1.TaskRun, defaults ...v1alpha1.TaskParam) *v1alpha1.TaskSpec {
 	// This assumes that the TaskRun inputs have been validated against what the Task requests.
-	replacements := map[string]string{}
+	stringReplacements := map[string]string{}
+	arrayReplacements := map[string][]string{}
+	setReplacement := func(name string, value v1alpha1.ArrayOrString) {
+		key := fmt.Sprintf("inputs.params.%s", name)
+		if value.Type == v1alpha1.ParamTypeArray {
+			arrayReplacements[key] = value.ArrayVal
+			delete(stringReplacements, key)
+		} else {
+			stringReplacements[key] = value.StringVal
+			delete(arrayReplacements, key)
+		}
+	}
 	// Set all the default replacements
 	for _, p := range defaults {
-		if p.Default != "" {
-			replacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Default
+		if p.Default != nil {
+			setReplacement(p.Name, *p.Default)
 		}
 	}
 	// Set and overwrite params with the ones from the TaskRun
 	for _, p := range tr.Spec.Inputs.Params {
-		replacements[fmt.Sprintf("inputs.params.%s", p.Name)] = p.Value
+		setReplacement(p.Name, p.Value)
 	}
 
-	return ApplyReplacements(spec, replacements)
+	return ApplyReplacements(spec, stringReplacements, arrayReplacements)
+}
+
+// ApplyContexts replaces the ${context.*} variables in spec with the values describing the
+// context tr is run in. The variables about the PipelineRun are empty if tr isn't run by one.
+func ApplyContexts(spec *v1alpha1.TaskSpec, tr *v1alpha1.TaskRun) *v1alpha1.TaskSpec {
+	replacements := map[string]string{
+		"context.taskRun.name":      tr.Name,
+		"context.taskRun.namespace": tr.Namespace,
+		"context.taskRun.uid":       string(tr.UID),
+		"context.pipelineRun.name":  tr.Labels[pipeline.GroupName+pipeline.PipelineRunLabelKey],
+		"context.pipelineTask.name": tr.Labels[pipeline.GroupName+pipeline.PipelineTaskLabelKey],
+		"context.task.retry-count":  strconv.Itoa(len(tr.Status.RetriesStatus)),
+	}
+	return ApplyReplacements(spec, replacements, nil)
 }
 
 // ApplyResources applies the templating from values in resources which are referenced in spec as subitems
@@ -60,48 +88,64 @@ func ApplyResources(spec *v1alpha1.TaskSpec, resources []v1alpha1.TaskResourceBi
 			replacements[fmt.Sprintf("%s.resources.%s.%s", replacementStr, r.Name, k)] = v
 		}
 	}
-	return ApplyReplacements(spec, replacements), nil
+	return ApplyReplacements(spec, replacements, nil), nil
 }
 
 // ApplyReplacements replaces placeholders for declared parameters with the specified replacements.
-func ApplyReplacements(spec *v1alpha1.TaskSpec, replacements map[string]string) *v1alpha1.TaskSpec {
+// The elements of the command and of the args of the containers which are exactly a placeholder
+// of arrayReplacements are expanded into all of the elements of the array.
+func ApplyReplacements(spec *v1alpha1.TaskSpec, stringReplacements map[string]string, arrayReplacements map[string][]string) *v1alpha1.TaskSpec {
 	spec = spec.DeepCopy()
 
-	// Apply variable expansion to steps fields.
-	steps := spec.Steps
-	for i := range steps {
-		steps[i].Name = templating.ApplyReplacements(steps[i].Name, replacements)
-		steps[i].Image = templating.ApplyReplacements(steps[i].Image, replacements)
-		for ia, a := range steps[i].Args {
-			steps[i].Args[ia] = templating.ApplyReplacements(a, replacements)
-		}
-		for ie, e := range steps[i].Env {
-			steps[i].Env[ie].Value = templating.ApplyReplacements(e.Value, replacements)
-		}
-		steps[i].WorkingDir = templating.ApplyReplacements(steps[i].WorkingDir, replacements)
-		for ic, c := range steps[i].Command {
-			steps[i].Command[ic] = templating.ApplyReplacements(c, replacements)
-		}
-		for iv, v := range steps[i].VolumeMounts {
-			steps[i].VolumeMounts[iv].Name = templating.ApplyReplacements(v.Name, replacements)
-			steps[i].VolumeMounts[iv].MountPath = templating.ApplyReplacements(v.MountPath, replacements)
-			steps[i].VolumeMounts[iv].SubPath = templating.ApplyReplacements(v.SubPath, replacements)
-		}
+	// Apply variable expansion to steps and sidecars fields.
+	for i := range spec.Steps {
+		applyContainerReplacements(&spec.Steps[i].Container, stringReplacements, arrayReplacements)
+		spec.Steps[i].Script = templating.ApplyReplacements(spec.Steps[i].Script, stringReplacements)
+	}
+	for i := range spec.Sidecars {
+		applyContainerReplacements(&spec.Sidecars[i], stringReplacements, arrayReplacements)
 	}
 
 	// Apply variable expansion to the build's volumes
 	for i, v := range spec.Volumes {
-		spec.Volumes[i].Name = templating.ApplyReplacements(v.Name, replacements)
+		spec.Volumes[i].Name = templating.ApplyReplacements(v.Name, stringReplacements)
 		if v.VolumeSource.ConfigMap != nil {
-			spec.Volumes[i].ConfigMap.Name = templating.ApplyReplacements(v.ConfigMap.Name, replacements)
+			spec.Volumes[i].ConfigMap.Name = templating.ApplyReplacements(v.ConfigMap.Name, stringReplacements)
 		}
 		if v.VolumeSource.Secret != nil {
-			spec.Volumes[i].Secret.SecretName = templating.ApplyReplacements(v.Secret.SecretName, replacements)
+			spec.Volumes[i].Secret.SecretName = templating.ApplyReplacements(v.Secret.SecretName, stringReplacements)
 		}
 		if v.PersistentVolumeClaim != nil {
-			spec.Volumes[i].PersistentVolumeClaim.ClaimName = templating.ApplyReplacements(v.PersistentVolumeClaim.ClaimName, replacements)
+			spec.Volumes[i].PersistentVolumeClaim.ClaimName = templating.ApplyReplacements(v.PersistentVolumeClaim.ClaimName, stringReplacements)
 		}
 	}
 
 	return spec
 }
+
+func applyContainerReplacements(c *corev1.Container, stringReplacements map[string]string, arrayReplacements map[string][]string) {
+	c.Name = templating.ApplyReplacements(c.Name, stringReplacements)
+	c.Image = templating.ApplyReplacements(c.Image, stringReplacements)
+	c.Args = applyArrayReplacements(c.Args, stringReplacements, arrayReplacements)
+	for ie, e := range c.Env {
+		c.Env[ie].Value = templating.ApplyReplacements(e.Value, stringReplacements)
+	}
+	c.WorkingDir = templating.ApplyReplacements(c.WorkingDir, stringReplacements)
+	c.Command = applyArrayReplacements(c.Command, stringReplacements, arrayReplacements)
+	for iv, v := range c.VolumeMounts {
+		c.VolumeMounts[iv].Name = templating.ApplyReplacements(v.Name, stringReplacements)
+		c.VolumeMounts[iv].MountPath = templating.ApplyReplacements(v.MountPath, stringReplacements)
+		c.VolumeMounts[iv].SubPath = templating.ApplyReplacements(v.SubPath, stringReplacements)
+	}
+}
+
+func applyArrayReplacements(in []string, stringReplacements map[string]string, arrayReplacements map[string][]string) []string {
+	if in == nil {
+		return nil
+	}
+	out := []string{}
+	for _, s := range in {
+		out = append(out, templating.ApplyArrayReplacements(s, stringReplacements, arrayReplacements)...)
+	}
+	return out
+}
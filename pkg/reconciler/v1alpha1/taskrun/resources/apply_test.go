@@ -27,22 +27,22 @@ import (
 )
 
 var simpleTaskSpec = &v1alpha1.TaskSpec{
-	Steps: []corev1.Container{{
+	Steps: []v1alpha1.Step{{Container: corev1.Container{
 		Name:  "foo",
 		Image: "${inputs.params.myimage}",
-	}, {
+	}}, {Container: corev1.Container{
 		Name:  "baz",
 		Image: "bat",
 		Args:  []string{"${inputs.resources.workspace.url}"},
-	}, {
+	}}, {Container: corev1.Container{
 		Name:  "qux",
 		Image: "quux",
 		Args:  []string{"${outputs.resources.imageToUse.url}"},
-	}},
+	}}},
 }
 
 var volumeMountTaskSpec = &v1alpha1.TaskSpec{
-	Steps: []corev1.Container{{
+	Steps: []v1alpha1.Step{{Container: corev1.Container{
 		Name:  "foo",
 		Image: "busybox:${inputs.params.FOO}",
 		VolumeMounts: []corev1.VolumeMount{{
@@ -50,16 +50,50 @@ var volumeMountTaskSpec = &v1alpha1.TaskSpec{
 			MountPath: "path/to/${inputs.params.FOO}",
 			SubPath:   "sub/${inputs.params.FOO}/path",
 		}},
+	}}},
+}
+
+var sidecarTaskSpec = &v1alpha1.TaskSpec{
+	Steps: []v1alpha1.Step{{Container: corev1.Container{
+		Name:  "foo",
+		Image: "busybox",
+	}}},
+	Sidecars: []corev1.Container{{
+		Name:  "bar",
+		Image: "${inputs.params.myimage}",
+		Env: []corev1.EnvVar{{
+			Name:  "IMAGE",
+			Value: "${inputs.params.myimage}",
+		}},
 	}},
 }
 
+var scriptTaskSpec = &v1alpha1.TaskSpec{
+	Steps: []v1alpha1.Step{{
+		Container: corev1.Container{
+			Name:  "foo",
+			Image: "busybox",
+		},
+		Script: "echo ${inputs.params.myimage}",
+	}},
+}
+
+var arrayParamTaskSpec = &v1alpha1.TaskSpec{
+	Steps: []v1alpha1.Step{{Container: corev1.Container{
+		Name:    "foo",
+		Image:   "busybox",
+		Command: []string{"${inputs.params.mycommand}"},
+		Args:    []string{"first", "${inputs.params.myargs}", "--flag=${inputs.params.myargs}", "last"},
+	}}},
+}
+
 var paramTaskRun = &v1alpha1.TaskRun{
 	Spec: v1alpha1.TaskRunSpec{
 		Inputs: v1alpha1.TaskRunInputs{
 			Params: []v1alpha1.Param{
 				{
 					Name:  "myimage",
-					Value: "bar",
+					Value: *v1alpha1.NewArrayOrString("bar"),
 				},
 			},
 		},
@@ -86,7 +120,7 @@ var gitResource = &v1alpha1.PipelineResource{
 	},
 	Spec: v1alpha1.PipelineResourceSpec{
 		Type: v1alpha1.PipelineResourceTypeGit,
-		Params: []v1alpha1.Param{
+		Params: []v1alpha1.ResourceParam{
 			{
 				Name:  "URL",
 				Value: "https://git-repo",
@@ -101,7 +135,7 @@ var imageResource = &v1alpha1.PipelineResource{
 	},
 	Spec: v1alpha1.PipelineResourceSpec{
 		Type: v1alpha1.PipelineResourceTypeImage,
-		Params: []v1alpha1.Param{
+		Params: []v1alpha1.ResourceParam{
 			{
 				Name:  "URL",
 				Value: "gcr.io/hans/sandwiches",
@@ -144,7 +178,7 @@ func TestApplyParameters(t *testing.T) {
 					Inputs: v1alpha1.TaskRunInputs{
 						Params: []v1alpha1.Param{{
 							Name:  "FOO",
-							Value: "world",
+							Value: *v1alpha1.NewArrayOrString("world"),
 						}},
 					},
 				},
@@ -164,13 +198,57 @@ func TestApplyParameters(t *testing.T) {
 			dp: []v1alpha1.TaskParam{
 				{
 					Name:    "myimage",
-					Default: "mydefault",
+					Default: v1alpha1.NewArrayOrString("mydefault"),
 				},
 			},
 		},
 		want: applyMutation(simpleTaskSpec, func(spec *v1alpha1.TaskSpec) {
 			spec.Steps[0].Image = "mydefault"
 		}),
+	}, {
+		name: "sidecar parameter",
+		args: args{
+			ts: sidecarTaskSpec,
+			tr: paramTaskRun,
+		},
+		want: applyMutation(sidecarTaskSpec, func(spec *v1alpha1.TaskSpec) {
+			spec.Sidecars[0].Image = "bar"
+			spec.Sidecars[0].Env[0].Value = "bar"
+		}),
+	}, {
+		name: "script parameter",
+		args: args{
+			ts: scriptTaskSpec,
+			tr: paramTaskRun,
+		},
+		want: applyMutation(scriptTaskSpec, func(spec *v1alpha1.TaskSpec) {
+			spec.Steps[0].Script = "echo bar"
+		}),
+	}, {
+		name: "array parameter",
+		args: args{
+			ts: arrayParamTaskSpec,
+			tr: &v1alpha1.TaskRun{
+				Spec: v1alpha1.TaskRunSpec{
+					Inputs: v1alpha1.TaskRunInputs{
+						Params: []v1alpha1.Param{{
+							Name:  "myargs",
+							Value: *v1alpha1.NewArrayOrString("second", "third"),
+						}},
+					},
+				},
+			},
+			dp: []v1alpha1.TaskParam{{
+				Name:    "mycommand",
+				Type:    v1alpha1.ParamTypeArray,
+				Default: v1alpha1.NewArrayOrString("go", "test"),
+			}},
+		},
+		want: applyMutation(arrayParamTaskSpec, func(spec *v1alpha1.TaskSpec) {
+			spec.Steps[0].Command = []string{"go", "test"}
+			// Only the args which are exactly the variable of an array are expanded.
+			spec.Steps[0].Args = []string{"first", "second", "third", "--flag=${inputs.params.myargs}", "last"}
+		}),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -381,10 +459,69 @@ func TestVolumeReplacement(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := ApplyReplacements(tt.ts, tt.repl)
+			got := ApplyReplacements(tt.ts, tt.repl, nil)
 			if d := cmp.Diff(got, tt.want); d != "" {
 				t.Errorf("ApplyResources() diff %s", d)
 			}
 		})
 	}
 }
+
+func TestApplyWorkspaces(t *testing.T) {
+	ts := &v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
+			Name:       "foo",
+			Image:      "busybox",
+			Args:       []string{"ls", "${workspaces.source.path}", "${workspaces.config.path}"},
+			WorkingDir: "${workspaces.source.path}/src",
+		}}},
+		Workspaces: []v1alpha1.WorkspaceDeclaration{{
+			Name: "source",
+		}, {
+			Name:      "config",
+			MountPath: "/config",
+		}},
+	}
+	want := ts.DeepCopy()
+	want.Steps[0].Args = []string{"ls", "/workspace/source", "/config"}
+	want.Steps[0].WorkingDir = "/workspace/source/src"
+
+	got := ApplyWorkspaces(ts)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyWorkspaces() diff %s", d)
+	}
+}
+
+func TestApplyContexts(t *testing.T) {
+	ts := &v1alpha1.TaskSpec{
+		Steps: []v1alpha1.Step{{Container: corev1.Container{
+			Name:  "foo",
+			Image: "busybox",
+			Args: []string{
+				"${context.taskRun.name}", "${context.taskRun.namespace}", "${context.taskRun.uid}",
+				"${context.pipelineRun.name}", "${context.pipelineTask.name}", "${context.task.retry-count}",
+			},
+		}}},
+	}
+	tr := &v1alpha1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "build-1234",
+			Namespace: "ci",
+			UID:       "abcd-efgh",
+			Labels: map[string]string{
+				"tekton.dev/pipelineRun":  "release",
+				"tekton.dev/pipelineTask": "build",
+			},
+		},
+		Status: v1alpha1.TaskRunStatus{
+			RetriesStatus: []v1alpha1.TaskRunStatus{{}, {}},
+		},
+	}
+	want := ts.DeepCopy()
+	want.Steps[0].Args = []string{"build-1234", "ci", "abcd-efgh", "release", "build", "2"}
+
+	got := ApplyContexts(ts, tr)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyContexts() diff %s", d)
+	}
+}
@@ -108,7 +108,7 @@ func AddInputResource(
 		}
 		// source is copied from previous task so skip fetching download container definition
 		if len(copyStepsFromPrevTasks) > 0 {
-			taskSpec.Steps = append(copyStepsFromPrevTasks, taskSpec.Steps...)
+			taskSpec.Steps = append(containersToSteps(copyStepsFromPrevTasks), taskSpec.Steps...)
 			taskSpec.Volumes = append(taskSpec.Volumes, as.GetSecretsVolumes()...)
 		} else {
 			switch resource.Spec.Type {
@@ -137,7 +137,7 @@ func AddInputResource(
 				}
 			}
 
-			taskSpec.Steps = append(resourceContainers, taskSpec.Steps...)
+			taskSpec.Steps = append(containersToSteps(resourceContainers), taskSpec.Steps...)
 			taskSpec.Volumes = append(taskSpec.Volumes, resourceVolumes...)
 		}
 	}
@@ -209,3 +209,13 @@ func destinationPath(name, path string) string {
 	}
 	return filepath.Join(workspaceDir, path)
 }
+
+// containersToSteps returns the steps which run containers, e.g. the containers
+// which fetch or upload a resource.
+func containersToSteps(containers []corev1.Container) []v1alpha1.Step {
+	steps := make([]v1alpha1.Step, 0, len(containers))
+	for _, c := range containers {
+		steps = append(steps, v1alpha1.Step{Container: c})
+	}
+	return steps
+}
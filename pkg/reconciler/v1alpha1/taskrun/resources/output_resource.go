@@ -139,7 +139,7 @@ func AddOutputResources(
 			resourceVolumes = append(resourceVolumes, as.GetSecretsVolumes()...)
 		}
 
-		taskSpec.Steps = append(taskSpec.Steps, resourceContainers...)
+		taskSpec.Steps = append(taskSpec.Steps, containersToSteps(resourceContainers)...)
 		taskSpec.Volumes = append(taskSpec.Volumes, resourceVolumes...)
 
 		if as.GetType() == v1alpha1.ArtifactStoragePVCType {
@@ -88,6 +88,8 @@ const (
 	// will break log collection for init containers.
 	containerPrefix            = "build-step-"
 	unnamedInitContainerPrefix = "build-step-unnamed-"
+	// Prefix to add to the name of the sidecar containers.
+	sidecarPrefix = "sidecar-"
 	// Name of the credential initialization container.
 	credsInit = "credential-initializer"
 )
@@ -178,23 +180,20 @@ func MakePod(taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, kubeclient k
 	initContainers := []corev1.Container{*cred}
 	podContainers := []corev1.Container{}
 
-	maxIndicesByResource := findMaxResourceRequest(taskSpec.Steps, corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage)
+	stepContainers := make([]corev1.Container, 0, len(taskSpec.Steps))
+	for _, s := range taskSpec.Steps {
+		stepContainers = append(stepContainers, s.Container)
+	}
+	maxIndicesByResource := findMaxResourceRequest(stepContainers, corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage)
+
+	// The workspaces are mounted into each step, like the implicit volumes.
+	workspaceVolumes, workspaceVolumeMounts := getWorkspaceVolumes(taskRun, taskSpec)
 
-	for i, step := range taskSpec.Steps {
+	for i, step := range stepContainers {
 		step.Env = append(implicitEnvVars, step.Env...)
 		// TODO(mattmoor): Check that volumeMounts match volumes.
 
-		// Add implicit volume mounts, unless the user has requested
-		// their own volume mount at that path.
-		requestedVolumeMounts := map[string]bool{}
-		for _, vm := range step.VolumeMounts {
-			requestedVolumeMounts[filepath.Clean(vm.MountPath)] = true
-		}
-		for _, imp := range implicitVolumeMounts {
-			if !requestedVolumeMounts[filepath.Clean(imp.MountPath)] {
-				step.VolumeMounts = append(step.VolumeMounts, imp)
-			}
-		}
+		addImplicitVolumeMounts(&step, workspaceVolumeMounts)
 
 		if step.WorkingDir == "" {
 			step.WorkingDir = workspaceDir
@@ -204,18 +203,23 @@ func MakePod(taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, kubeclient k
 		} else {
 			step.Name = names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", containerPrefix, step.Name))
 		}
-		// use the step name to add the entrypoint biary as an init container
-		if step.Name == names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", containerPrefix, entrypoint.InitContainerName)) {
+		// use the step name to add the entrypoint biary and the scripts as init containers
+		if step.Name == names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", containerPrefix, entrypoint.InitContainerName)) ||
+			step.Name == names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", containerPrefix, entrypoint.ScriptsInitContainerName)) {
 			initContainers = append(initContainers, step)
 		} else {
 			zeroNonMaxResourceRequests(&step, i, maxIndicesByResource)
 			podContainers = append(podContainers, step)
 		}
 	}
-	// Add our implicit volumes and any volumes needed for secrets to the explicitly
-	// declared user volumes.
+	// Add our implicit volumes, the volumes bound to the workspaces and any volumes
+	// needed for secrets to the explicitly declared user volumes.
 	volumes := append(taskSpec.Volumes, implicitVolumes...)
+	volumes = append(volumes, workspaceVolumes...)
 	volumes = append(volumes, secrets...)
+	if taskRun.Spec.PodTemplate != nil {
+		volumes = append(volumes, taskRun.Spec.PodTemplate.Volumes...)
+	}
 	if err := v1alpha1.ValidateVolumes(volumes); err != nil {
 		return nil, err
 	}
@@ -231,7 +235,16 @@ func MakePod(taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, kubeclient k
 	entrypoint.RedirectStep(cache, len(podContainers), nopContainer, kubeclient, taskRun, logger)
 	podContainers = append(podContainers, *nopContainer)
 
-	return &corev1.Pod{
+	// The sidecars run alongside the steps, so their entrypoints aren't redirected
+	// and their resource requests are kept.
+	for _, sidecar := range taskSpec.Sidecars {
+		sidecar.Env = append(implicitEnvVars, sidecar.Env...)
+		addImplicitVolumeMounts(&sidecar, workspaceVolumeMounts)
+		sidecar.Name = names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("%v%v", sidecarPrefix, sidecar.Name))
+		podContainers = append(podContainers, sidecar)
+	}
+
+	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			// We execute the build's pod in the same namespace as where the build was
 			// created so that it can access colocated resources.
@@ -259,7 +272,39 @@ func MakePod(taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec, kubeclient k
 			Tolerations:        taskRun.Spec.Tolerations,
 			Affinity:           taskRun.Spec.Affinity,
 		},
-	}, nil
+	}
+	applyPodTemplate(&pod.Spec, taskRun.Spec.PodTemplate)
+	return pod, nil
+}
+
+// applyPodTemplate sets the fields of template, other than its volumes which are added
+// with the other volumes of the pod, to spec.
+func applyPodTemplate(spec *corev1.PodSpec, template *v1alpha1.PodTemplate) {
+	if template == nil {
+		return
+	}
+	spec.SecurityContext = template.SecurityContext
+	spec.RuntimeClassName = template.RuntimeClassName
+	spec.PriorityClassName = template.PriorityClassName
+	spec.SchedulerName = template.SchedulerName
+	spec.HostAliases = template.HostAliases
+	spec.DNSConfig = template.DNSConfig
+	spec.ImagePullSecrets = template.ImagePullSecrets
+}
+
+// addImplicitVolumeMounts adds the implicit volume mounts and the mounts of the
+// workspaces to container, unless the user has requested their own volume mount
+// at that path.
+func addImplicitVolumeMounts(container *corev1.Container, workspaceVolumeMounts []corev1.VolumeMount) {
+	requestedVolumeMounts := map[string]bool{}
+	for _, vm := range container.VolumeMounts {
+		requestedVolumeMounts[filepath.Clean(vm.MountPath)] = true
+	}
+	for _, imp := range append(implicitVolumeMounts, workspaceVolumeMounts...) {
+		if !requestedVolumeMounts[filepath.Clean(imp.MountPath)] {
+			container.VolumeMounts = append(container.VolumeMounts, imp)
+		}
+	}
 }
 
 // makeLabels constructs the labels we will propagate from TaskRuns to Pods.
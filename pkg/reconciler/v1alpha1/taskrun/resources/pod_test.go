@@ -73,6 +73,9 @@ func TestMakePod(t *testing.T) {
 		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "multi-creds"}},
 	})
 
+	runAsNonRoot := true
+	runtimeClassName := "gvisor"
+
 	randReader = strings.NewReader(strings.Repeat("a", 10000))
 	defer func() { randReader = rand.Reader }()
 
@@ -86,10 +89,10 @@ func TestMakePod(t *testing.T) {
 	}{{
 		desc: "simple",
 		ts: v1alpha1.TaskSpec{
-			Steps: []corev1.Container{{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
 				Name:  "name",
 				Image: "image",
-			}},
+			}}},
 		},
 		bAnnotations: map[string]string{
 			"simple-annotation-key": "simple-annotation-val",
@@ -124,12 +127,74 @@ func TestMakePod(t *testing.T) {
 			Volumes: implicitVolumes,
 		},
 	}, {
-		desc: "with-service-account",
+		desc: "pod-template",
 		ts: v1alpha1.TaskSpec{
-			Steps: []corev1.Container{{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
 				Name:  "name",
 				Image: "image",
+			}}},
+		},
+		trs: v1alpha1.TaskRunSpec{
+			PodTemplate: &v1alpha1.PodTemplate{
+				SecurityContext:   &corev1.PodSecurityContext{RunAsNonRoot: &runAsNonRoot},
+				RuntimeClassName:  &runtimeClassName,
+				PriorityClassName: "high-priority",
+				SchedulerName:     "custom-scheduler",
+				HostAliases:       []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry.local"}}},
+				DNSConfig:         &corev1.PodDNSConfig{Nameservers: []string{"10.0.0.53"}},
+				ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "registry-creds"}},
+				Volumes: []corev1.Volume{{
+					Name:         "cache",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				}},
+			},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{{
+				Name:         containerPrefix + credsInit + "-9l9zj",
+				Image:        *credsImage,
+				Command:      []string{"/ko-app/creds-init"},
+				Args:         []string{},
+				Env:          implicitEnvVars,
+				VolumeMounts: implicitVolumeMounts,
+				WorkingDir:   workspaceDir,
 			}},
+			Containers: []corev1.Container{{
+				Name:         "build-step-name",
+				Image:        "image",
+				Env:          implicitEnvVars,
+				VolumeMounts: implicitVolumeMounts,
+				WorkingDir:   workspaceDir,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:              resource.MustParse("0"),
+						corev1.ResourceMemory:           resource.MustParse("0"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("0"),
+					},
+				},
+			},
+				nopContainer,
+			},
+			Volumes: append(implicitVolumes, corev1.Volume{
+				Name:         "cache",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}),
+			SecurityContext:   &corev1.PodSecurityContext{RunAsNonRoot: &runAsNonRoot},
+			RuntimeClassName:  &runtimeClassName,
+			PriorityClassName: "high-priority",
+			SchedulerName:     "custom-scheduler",
+			HostAliases:       []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry.local"}}},
+			DNSConfig:         &corev1.PodDNSConfig{Nameservers: []string{"10.0.0.53"}},
+			ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "registry-creds"}},
+		},
+	}, {
+		desc: "with-service-account",
+		ts: v1alpha1.TaskSpec{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:  "name",
+				Image: "image",
+			}}},
 		},
 		trs: v1alpha1.TaskRunSpec{
 			ServiceAccount: "service-account",
@@ -172,10 +237,10 @@ func TestMakePod(t *testing.T) {
 	}, {
 		desc: "very-long-step-name",
 		ts: v1alpha1.TaskSpec{
-			Steps: []corev1.Container{{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
 				Name:  "a-very-long-character-step-name-to-trigger-max-len----and-invalid-characters",
 				Image: "image",
-			}},
+			}}},
 		},
 		bAnnotations: map[string]string{
 			"simple-annotation-key": "simple-annotation-val",
@@ -212,10 +277,10 @@ func TestMakePod(t *testing.T) {
 	}, {
 		desc: "step-name-ends-with-non-alphanumeric",
 		ts: v1alpha1.TaskSpec{
-			Steps: []corev1.Container{{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
 				Name:  "ends-with-invalid-%%__$$",
 				Image: "image",
-			}},
+			}}},
 		},
 		bAnnotations: map[string]string{
 			"simple-annotation-key": "simple-annotation-val",
@@ -249,6 +314,148 @@ func TestMakePod(t *testing.T) {
 			},
 			Volumes: implicitVolumes,
 		},
+	}, {
+		desc: "workspaces",
+		ts: v1alpha1.TaskSpec{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:  "name",
+				Image: "image",
+			}}},
+			Workspaces: []v1alpha1.WorkspaceDeclaration{{
+				Name: "source",
+			}, {
+				Name:      "config",
+				MountPath: "/config",
+				ReadOnly:  true,
+			}},
+		},
+		trs: v1alpha1.TaskRunSpec{
+			Workspaces: []v1alpha1.WorkspaceBinding{{
+				Name:    "source",
+				SubPath: "src",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "mypvc",
+				},
+			}, {
+				Name: "config",
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "myconfig"},
+				},
+			}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{{
+				Name:         containerPrefix + credsInit + "-9l9zj",
+				Image:        *credsImage,
+				Command:      []string{"/ko-app/creds-init"},
+				Args:         []string{},
+				Env:          implicitEnvVars,
+				VolumeMounts: implicitVolumeMounts,
+				WorkingDir:   workspaceDir,
+			}},
+			Containers: []corev1.Container{{
+				Name:  "build-step-name",
+				Image: "image",
+				Env:   implicitEnvVars,
+				VolumeMounts: append(implicitVolumeMounts, corev1.VolumeMount{
+					Name:      "ws-source",
+					MountPath: "/workspace/source",
+					SubPath:   "src",
+				}, corev1.VolumeMount{
+					Name:      "ws-config",
+					MountPath: "/config",
+					ReadOnly:  true,
+				}),
+				WorkingDir: workspaceDir,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:              resource.MustParse("0"),
+						corev1.ResourceMemory:           resource.MustParse("0"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("0"),
+					},
+				},
+			},
+				nopContainer,
+			},
+			Volumes: append(implicitVolumes, corev1.Volume{
+				Name: "ws-source",
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "mypvc"},
+				},
+			}, corev1.Volume{
+				Name: "ws-config",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "myconfig"},
+					},
+				},
+			}),
+		},
+	}, {
+		desc: "sidecar container",
+		ts: v1alpha1.TaskSpec{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
+				Name:  "name",
+				Image: "image",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("1"),
+					},
+				},
+			}}},
+			Sidecars: []corev1.Container{{
+				Name:    "database",
+				Image:   "postgres",
+				Command: []string{"postgres"},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("2"),
+					},
+				},
+			}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{{
+				Name:         containerPrefix + credsInit + "-9l9zj",
+				Image:        *credsImage,
+				Command:      []string{"/ko-app/creds-init"},
+				Args:         []string{},
+				Env:          implicitEnvVars,
+				VolumeMounts: implicitVolumeMounts,
+				WorkingDir:   workspaceDir,
+			}},
+			Containers: []corev1.Container{{
+				Name:         "build-step-name",
+				Image:        "image",
+				Env:          implicitEnvVars,
+				VolumeMounts: implicitVolumeMounts,
+				WorkingDir:   workspaceDir,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:              resource.MustParse("1"),
+						corev1.ResourceMemory:           resource.MustParse("0"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("0"),
+					},
+				},
+			},
+				nopContainer,
+				{
+					Name:         "sidecar-database",
+					Image:        "postgres",
+					Command:      []string{"postgres"},
+					Env:          implicitEnvVars,
+					VolumeMounts: implicitVolumeMounts,
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("2"),
+						},
+					},
+				},
+			},
+			Volumes: implicitVolumes,
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			names.TestingSeed()
@@ -0,0 +1,65 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// UpdatePod updates a Pod, e.g. using the Pods client of a namespace.
+type UpdatePod func(*corev1.Pod) (*corev1.Pod, error)
+
+// IsContainerSidecar returns true if the container of the pod named name runs a
+// sidecar of the Task rather than one of its steps.
+func IsContainerSidecar(name string) bool {
+	return strings.HasPrefix(name, sidecarPrefix)
+}
+
+// StopSidecars stops the sidecars of pod which are still running, so that the pod
+// can complete once all of its steps are done. Since only the image of a running
+// container can be changed, the sidecars are stopped by replacing their image with
+// the nop image: the kubelet then restarts them without running the sidecar. Their
+// command and args are cleared as well, since the nop image can't run them.
+func StopSidecars(pod *corev1.Pod, updatePod UpdatePod) error {
+	running := map[string]bool{}
+	for _, s := range pod.Status.ContainerStatuses {
+		if IsContainerSidecar(s.Name) && s.State.Running != nil {
+			running[s.Name] = true
+		}
+	}
+	if len(running) == 0 {
+		return nil
+	}
+
+	newPod := pod.DeepCopy()
+	updated := false
+	for i, c := range newPod.Spec.Containers {
+		if running[c.Name] && c.Image != *nopImage {
+			newPod.Spec.Containers[i].Image = *nopImage
+			newPod.Spec.Containers[i].Command = nil
+			newPod.Spec.Containers[i].Args = nil
+			updated = true
+		}
+	}
+	if !updated {
+		return nil
+	}
+	_, err := updatePod(newPod)
+	return err
+}
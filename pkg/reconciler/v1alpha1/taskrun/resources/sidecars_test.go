@@ -0,0 +1,81 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestStopSidecars(t *testing.T) {
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	terminated := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "build-step-name", Image: "image"},
+				{Name: "sidecar-database", Image: "postgres"},
+				{Name: "sidecar-docker", Image: "docker", Command: []string{"dockerd-entrypoint.sh"}, Args: []string{"--tls=false"}},
+				{Name: "sidecar-crashed", Image: "redis"},
+			},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "build-step-name", State: terminated},
+				{Name: "sidecar-database", State: running},
+				{Name: "sidecar-docker", State: running},
+				{Name: "sidecar-crashed", State: terminated},
+			},
+		},
+	}
+
+	var updated *corev1.Pod
+	updatePod := func(p *corev1.Pod) (*corev1.Pod, error) {
+		updated = p
+		return p, nil
+	}
+	if err := StopSidecars(pod, updatePod); err != nil {
+		t.Fatalf("Didn't expect error stopping the sidecars but got %v", err)
+	}
+	if updated == nil {
+		t.Fatalf("Expected the pod to be updated")
+	}
+	expected := []corev1.Container{
+		{Name: "build-step-name", Image: "image"},
+		{Name: "sidecar-database", Image: *nopImage},
+		{Name: "sidecar-docker", Image: *nopImage},
+		{Name: "sidecar-crashed", Image: "redis"},
+	}
+	if d := cmp.Diff(expected, updated.Spec.Containers); d != "" {
+		t.Errorf("Unexpected containers -want, +got: %v", d)
+	}
+	if pod.Spec.Containers[1].Image != "postgres" {
+		t.Errorf("Expected the pod not to be modified but the image of the sidecar was %q", pod.Spec.Containers[1].Image)
+	}
+
+	// Once the sidecars have been stopped, the pod isn't updated anymore.
+	stopped := updated
+	updated = nil
+	if err := StopSidecars(stopped, updatePod); err != nil {
+		t.Fatalf("Didn't expect error stopping the sidecars but got %v", err)
+	}
+	if updated != nil {
+		t.Errorf("Expected the pod not to be updated again")
+	}
+}
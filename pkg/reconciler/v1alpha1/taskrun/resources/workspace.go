@@ -0,0 +1,87 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/names"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/volumeclaim"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ApplyWorkspaces replaces the ${workspaces.<name>.path} variables in spec with the
+// directories its workspaces are mounted at.
+func ApplyWorkspaces(spec *v1alpha1.TaskSpec) *v1alpha1.TaskSpec {
+	replacements := map[string]string{}
+	for _, w := range spec.Workspaces {
+		replacements[fmt.Sprintf("workspaces.%s.path", w.Name)] = w.GetMountPath()
+	}
+	return ApplyReplacements(spec, replacements, nil)
+}
+
+// getWorkspaceVolumes returns the volumes bound to the workspaces declared by taskSpec by
+// the bindings of taskRun, and the mounts which mount them into each step. The workspaces
+// which aren't bound are ignored, since the bindings are validated before the pod is made.
+func getWorkspaceVolumes(taskRun *v1alpha1.TaskRun, taskSpec v1alpha1.TaskSpec) ([]corev1.Volume, []corev1.VolumeMount) {
+	bindings := map[string]v1alpha1.WorkspaceBinding{}
+	for _, wb := range taskRun.Spec.Workspaces {
+		bindings[wb.Name] = wb
+	}
+
+	volumes := []corev1.Volume{}
+	mounts := []corev1.VolumeMount{}
+	for _, w := range taskSpec.Workspaces {
+		wb, ok := bindings[w.Name]
+		if !ok {
+			continue
+		}
+		name := names.SimpleNameGenerator.RestrictLength(fmt.Sprintf("ws-%s", w.Name))
+		volumes = append(volumes, corev1.Volume{
+			Name:         name,
+			VolumeSource: getWorkspaceVolumeSource(taskRun, wb),
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: w.GetMountPath(),
+			SubPath:   wb.SubPath,
+			ReadOnly:  w.ReadOnly,
+		})
+	}
+	return volumes, mounts
+}
+
+func getWorkspaceVolumeSource(taskRun *v1alpha1.TaskRun, wb v1alpha1.WorkspaceBinding) corev1.VolumeSource {
+	switch {
+	case wb.PersistentVolumeClaim != nil:
+		return corev1.VolumeSource{PersistentVolumeClaim: wb.PersistentVolumeClaim.DeepCopy()}
+	case wb.VolumeClaimTemplate != nil:
+		return corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: volumeclaim.GetPersistentVolumeClaimName(wb, taskRun.GetOwnerReference()[0]),
+			},
+		}
+	case wb.ConfigMap != nil:
+		return corev1.VolumeSource{ConfigMap: wb.ConfigMap.DeepCopy()}
+	case wb.Secret != nil:
+		return corev1.VolumeSource{Secret: wb.Secret.DeepCopy()}
+	case wb.EmptyDir != nil:
+		return corev1.VolumeSource{EmptyDir: wb.EmptyDir.DeepCopy()}
+	}
+	return emptyVolumeSource
+}
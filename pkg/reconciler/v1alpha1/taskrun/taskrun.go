@@ -18,8 +18,11 @@ package taskrun
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"time"
 
 	"github.com/knative/pkg/apis"
@@ -32,6 +35,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/entrypoint"
 	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/taskrun/resources"
+	"github.com/tektoncd/pipeline/pkg/reconciler/v1alpha1/volumeclaim"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -62,6 +66,10 @@ const (
 	// reasonTimedOut indicates that the TaskRun has taken longer than its configured timeout
 	reasonTimedOut = "TaskRunTimeout"
 
+	// reasonRetrying indicates that the TaskRun's pod failed and that a new pod will be
+	// created to retry it
+	reasonRetrying = "Retrying"
+
 	// taskRunAgentName defines logging agent name for TaskRun Controller
 	taskRunAgentName = "taskrun-controller"
 	// taskRunControllerName defines name for TaskRun Controller
@@ -244,10 +252,15 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error
 	}
 
 	// Check if the TaskRun has timed out; if it is, this will set its status
-	// accordingly.
+	// accordingly. An attempt which timed out is retried like one which failed,
+	// with the same timeout.
 	if timedOut, err := c.checkTimeout(tr, taskSpec, c.KubeClientSet.CoreV1().Pods(tr.Namespace).Delete); err != nil {
 		return err
 	} else if timedOut {
+		if isRetryable(tr) {
+			c.Logger.Infof("TaskRun %q timed out, retrying (%d/%d)", tr.Name, len(tr.Status.RetriesStatus)+1, tr.Spec.Retries)
+			retryTaskRun(tr)
+		}
 		return nil
 	}
 
@@ -274,6 +287,17 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error
 		return nil
 	}
 
+	if err := ValidateWorkspaces(taskSpec.Workspaces, tr.Spec.Workspaces); err != nil {
+		c.Logger.Errorf("Failed to validate workspaces of taskrun %q: %v", tr.Name, err)
+		tr.Status.SetCondition(&apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  reasonFailedValidation,
+			Message: err.Error(),
+		})
+		return nil
+	}
+
 	// Get the TaskRun's Pod if it should have one. Otherwise, create the Pod.
 	var pod *corev1.Pod
 	if tr.Status.PodName != "" {
@@ -283,7 +307,11 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error
 			return err
 		}
 	} else {
-		// Pod is not present, create pod.
+		// Pod is not present, create the claims of its workspaces and the pod.
+		if err := volumeclaim.CreatePersistentVolumeClaimsForWorkspaces(c.KubeClientSet, tr.Spec.Workspaces, tr.GetOwnerReference()[0], tr.Namespace); err != nil {
+			c.Logger.Errorf("Failed to create PVCs for workspaces of taskrun %q: %v", tr.Name, err)
+			return err
+		}
 		go c.timeoutHandler.WaitTaskRun(tr)
 		pod, err = c.createPod(tr, rtr.TaskSpec, rtr.TaskName)
 		if err != nil {
@@ -315,8 +343,28 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1alpha1.TaskRun) error
 
 	c.timeoutHandler.StatusLock(tr)
 	updateStatusFromPod(tr, pod)
+	// The steps of the pod are done, including when the TaskRun is about to be retried.
+	stepsDone := tr.IsDone()
+	retrying := isRetryable(tr)
+	if retrying {
+		c.Logger.Infof("TaskRun %q failed, retrying (%d/%d)", tr.Name, len(tr.Status.RetriesStatus)+1, tr.Spec.Retries)
+		retryTaskRun(tr)
+	}
 	c.timeoutHandler.StatusUnlock(tr)
 
+	if retrying {
+		// The pod of the failed attempt, and its sidecars, are replaced by the pod of the retry
+		if err := c.KubeClientSet.CoreV1().Pods(tr.Namespace).Delete(pod.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			c.Logger.Errorf("Failed to delete pod %q of the failed attempt of taskrun %q: %v", pod.Name, tr.Name, err)
+			return err
+		}
+	} else if stepsDone {
+		if err := resources.StopSidecars(pod, c.KubeClientSet.CoreV1().Pods(tr.Namespace).Update); err != nil {
+			c.Logger.Errorf("Failed to stop sidecars of pod %q for taskrun %q: %v", pod.Name, tr.Name, err)
+			return err
+		}
+	}
+
 	after := tr.Status.GetCondition(apis.ConditionSucceeded)
 
 	reconciler.EmitEvent(c.Recorder, before, after, tr)
@@ -342,12 +390,33 @@ func updateStatusFromPod(taskRun *v1alpha1.TaskRun, pod *corev1.Pod) {
 
 	taskRun.Status.Steps = []v1alpha1.StepState{}
 	for _, s := range pod.Status.ContainerStatuses {
+		if resources.IsContainerSidecar(s.Name) {
+			continue
+		}
+		state := s.State.DeepCopy()
+		if reason := getStepReason(state.Terminated); reason != "" {
+			state.Terminated.Reason = reason
+		}
+		if code, ok := getStepExitCode(state.Terminated); ok {
+			state.Terminated.ExitCode = code
+		}
 		taskRun.Status.Steps = append(taskRun.Status.Steps, v1alpha1.StepState{
-			ContainerState: *s.State.DeepCopy(),
+			ContainerState: *state,
 		})
 	}
+	taskRun.Status.TaskResults = getTaskResults(pod)
+
+	phase := pod.Status.Phase
+	if phase == corev1.PodRunning && areStepsComplete(pod) {
+		// The pod is kept running by its sidecars, which are stopped once the
+		// steps are done, so the TaskRun completes with its steps.
+		phase = corev1.PodSucceeded
+		if didStepFail(pod) {
+			phase = corev1.PodFailed
+		}
+	}
 
-	switch pod.Status.Phase {
+	switch phase {
 	case corev1.PodRunning:
 		taskRun.Status.SetCondition(&apis.Condition{
 			Type:   apis.ConditionSucceeded,
@@ -381,6 +450,145 @@ func updateStatusFromPod(taskRun *v1alpha1.TaskRun, pod *corev1.Pod) {
 	}
 }
 
+// areStepsComplete returns true if all of the steps of pod have terminated, i.e. if
+// the last step has written its post file.
+func areStepsComplete(pod *corev1.Pod) bool {
+	complete := false
+	for _, s := range pod.Status.ContainerStatuses {
+		if resources.IsContainerSidecar(s.Name) {
+			continue
+		}
+		if s.State.Terminated == nil {
+			return false
+		}
+		complete = true
+	}
+	return complete
+}
+
+// didStepFail returns true if one of the steps of pod exited with a non-zero code.
+func didStepFail(pod *corev1.Pod) bool {
+	for _, s := range pod.Status.ContainerStatuses {
+		if resources.IsContainerSidecar(s.Name) {
+			continue
+		}
+		if s.State.Terminated != nil && s.State.Terminated.ExitCode != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// getTaskResults returns the results reported by the entrypoint of the steps of pod in the
+// termination messages of their containers. If several steps reported a value for the same
+// result, the value reported by the step which finished last is used.
+func getTaskResults(pod *corev1.Pod) []v1alpha1.TaskRunResult {
+	values := map[string]string{}
+	finishedAt := map[string]metav1.Time{}
+	for _, s := range pod.Status.ContainerStatuses {
+		terminated := s.State.Terminated
+		if terminated == nil || resources.IsContainerSidecar(s.Name) {
+			continue
+		}
+		for _, r := range getTerminationMessage(terminated) {
+			if r.Name == v1alpha1.StepReasonResultName || r.Name == v1alpha1.StepExitCodeResultName {
+				continue
+			}
+			if t, ok := finishedAt[r.Name]; ok && terminated.FinishedAt.Before(&t) {
+				continue
+			}
+			values[r.Name] = r.Value
+			finishedAt[r.Name] = terminated.FinishedAt
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	results := make([]v1alpha1.TaskRunResult, 0, len(names))
+	for _, name := range names {
+		results = append(results, v1alpha1.TaskRunResult{Name: name, Value: values[name]})
+	}
+	return results
+}
+
+// getStepReason returns the reason reported by the entrypoint for the termination of the
+// step whose container is in the terminated state, if any.
+func getStepReason(terminated *corev1.ContainerStateTerminated) string {
+	reason, _ := getReportedValue(terminated, v1alpha1.StepReasonResultName)
+	return reason
+}
+
+// getStepExitCode returns the exit code reported by the entrypoint for the command of the
+// step whose container is in the terminated state, if it failed but the step continued.
+func getStepExitCode(terminated *corev1.ContainerStateTerminated) (int32, bool) {
+	value, ok := getReportedValue(terminated, v1alpha1.StepExitCodeResultName)
+	if !ok {
+		return 0, false
+	}
+	code, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(code), true
+}
+
+// getReportedValue returns the value of the entry named name reported by the entrypoint in
+// the termination message of a step whose container is in the terminated state, if any.
+func getReportedValue(terminated *corev1.ContainerStateTerminated, name string) (string, bool) {
+	if terminated == nil {
+		return "", false
+	}
+	for _, r := range getTerminationMessage(terminated) {
+		if r.Name == name {
+			return r.Value, true
+		}
+	}
+	return "", false
+}
+
+// getTerminationMessage returns the entries reported by the entrypoint in the termination
+// message of a step whose container is in the terminated state.
+func getTerminationMessage(terminated *corev1.ContainerStateTerminated) []v1alpha1.TaskRunResult {
+	if terminated.Message == "" {
+		return nil
+	}
+	reported := []v1alpha1.TaskRunResult{}
+	if err := json.Unmarshal([]byte(terminated.Message), &reported); err != nil {
+		// The message wasn't written by the entrypoint
+		return nil
+	}
+	return reported
+}
+
+// isRetryable returns true if the TaskRun has failed but still has retries left.
+func isRetryable(tr *v1alpha1.TaskRun) bool {
+	return tr.Status.GetCondition(apis.ConditionSucceeded).IsFalse() && len(tr.Status.RetriesStatus) < tr.Spec.Retries
+}
+
+// retryTaskRun records the status of the failed attempt in the RetriesStatus of the TaskRun
+// and resets its status so that a new pod is created on the next reconcile.
+func retryTaskRun(tr *v1alpha1.TaskRun) {
+	attempt := tr.Status.DeepCopy()
+	attempt.RetriesStatus = nil
+	tr.Status.RetriesStatus = append(tr.Status.RetriesStatus, *attempt)
+	tr.Status.PodName = ""
+	tr.Status.StartTime = nil
+	tr.Status.CompletionTime = nil
+	tr.Status.Steps = nil
+	tr.Status.TaskResults = nil
+	tr.Status.SetCondition(&apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionUnknown,
+		Reason:  reasonRetrying,
+		Message: fmt.Sprintf("Attempt %d of TaskRun %q failed: %s", len(tr.Status.RetriesStatus), tr.Name, attempt.GetCondition(apis.ConditionSucceeded).Message),
+	})
+}
+
 func getWaitingMessage(pod *corev1.Pod) string {
 	// First, try to surface reason for pending/unknown about the actual build step.
 	for _, status := range pod.Status.ContainerStatuses {
@@ -411,7 +619,14 @@ func getWaitingMessage(pod *corev1.Pod) string {
 func getFailureMessage(pod *corev1.Pod) string {
 	// First, try to surface an error about the actual build step that failed.
 	for _, status := range pod.Status.ContainerStatuses {
+		if resources.IsContainerSidecar(status.Name) {
+			continue
+		}
 		term := status.State.Terminated
+		if getStepReason(term) == v1alpha1.StepReasonTimedOut {
+			return fmt.Sprintf("build step %q timed out; for logs run: kubectl -n %s logs %s -c %s",
+				status.Name, pod.Namespace, pod.Name, status.Name)
+		}
 		if term != nil && term.ExitCode != 0 {
 			return fmt.Sprintf("build step %q exited with code %d (image: %q); for logs run: kubectl -n %s logs %s -c %s",
 				status.Name, term.ExitCode, status.ImageID,
@@ -478,6 +693,9 @@ func (c *Reconciler) createPod(tr *v1alpha1.TaskRun, ts *v1alpha1.TaskSpec, task
 	// Apply parameter templating from the taskrun.
 	ts = resources.ApplyParameters(ts, tr, defaults...)
 
+	// Apply context templating from the taskrun, after the parameters which can reference it.
+	ts = resources.ApplyContexts(ts, tr)
+
 	// Apply bound resource templating from the taskrun.
 	ts, err = resources.ApplyResources(ts, tr.Spec.Inputs.Resources, c.resourceLister.PipelineResources(tr.Namespace).Get, "inputs")
 	if err != nil {
@@ -488,6 +706,9 @@ func (c *Reconciler) createPod(tr *v1alpha1.TaskRun, ts *v1alpha1.TaskSpec, task
 		return nil, fmt.Errorf("couldnt apply output resource templating: %s", err)
 	}
 
+	// Apply workspace path templating from the task.
+	ts = resources.ApplyWorkspaces(ts)
+
 	pod, err := resources.MakePod(tr, *ts, c.KubeClientSet, c.cache, c.Logger)
 	if err != nil {
 		return nil, fmt.Errorf("translating Build to Pod: %v", err)
@@ -507,6 +728,12 @@ func createRedirectedTaskSpec(kubeclient kubernetes.Interface, ts *v1alpha1.Task
 	if err != nil {
 		return nil, fmt.Errorf("failed to add entrypoint to steps of TaskRun %s: %v", tr.Name, err)
 	}
+	// Add the step which will write the scripts of the steps to the files
+	// they now run. This must happen before any other step is added.
+	entrypoint.AddScripts(ts)
+	// Have the entrypoint report the results of the Task, which are written
+	// to a volume shared by all of the steps.
+	entrypoint.AddResultsDir(ts)
 	// Add the step which will copy the entrypoint into the volume
 	// we are going to be using, so that all of the steps will have
 	// access to it.
@@ -38,6 +38,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -80,6 +81,11 @@ var (
 		tb.TaskOutputs(tb.OutputsResource(gitResource.Name, v1alpha1.PipelineResourceTypeGit)),
 	))
 
+	workspaceTask = tb.Task("test-task-with-workspace", "foo", tb.TaskSpec(
+		tb.Step("ls-step", "foo", tb.Command("ls"), tb.Args("${workspaces.source.path}")),
+		tb.TaskWorkspace("source", "", "", false),
+	))
+
 	saTask = tb.Task("test-with-sa", "foo", tb.TaskSpec(tb.Step("sa-step", "foo", tb.Command("/mycmd"))))
 
 	templatedTask = tb.Task("test-task-with-templating", "foo", tb.TaskSpec(
@@ -249,7 +255,7 @@ func TestReconcile(t *testing.T) {
 		tb.TaskRunInputs(
 			tb.TaskRunInputsResource("workspace", tb.TaskResourceBindingResourceSpec(&v1alpha1.PipelineResourceSpec{
 				Type: v1alpha1.PipelineResourceTypeGit,
-				Params: []v1alpha1.Param{{
+				Params: []v1alpha1.ResourceParam{{
 					Name:  "URL",
 					Value: "github.com/foo/bar.git",
 				}, {
@@ -311,11 +317,19 @@ func TestReconcile(t *testing.T) {
 		),
 	)
 
+	taskRunWithScript := tb.TaskRun("test-taskrun-with-script", "foo",
+		tb.TaskRunSpec(
+			tb.TaskRunTaskSpec(
+				tb.ScriptStep("myscript", "python", "#!/usr/bin/env python\nprint('hello')"),
+			),
+		),
+	)
+
 	taskruns := []*v1alpha1.TaskRun{
 		taskRunSuccess, taskRunWithSaSuccess,
 		taskRunTemplating, taskRunInputOutput,
 		taskRunWithTaskSpec, taskRunWithClusterTask, taskRunWithResourceSpecAndTaskSpec,
-		taskRunWithLabels, taskRunWithResourceRequests,
+		taskRunWithLabels, taskRunWithResourceRequests, taskRunWithScript,
 	}
 
 	d := test.Data{
@@ -851,6 +865,55 @@ func TestReconcile(t *testing.T) {
 				),
 			),
 		),
+	}, {
+		name:    "taskrun-with-script",
+		taskRun: taskRunWithScript,
+		wantPod: tb.Pod("test-taskrun-with-script-pod-123456", "foo",
+			tb.PodAnnotation("sidecar.istio.io/inject", "false"),
+			tb.PodLabel(taskRunNameLabelKey, "test-taskrun-with-script"),
+			tb.PodOwnerReference("TaskRun", "test-taskrun-with-script",
+				tb.OwnerReferenceAPIVersion(currentApiVersion)),
+			tb.PodSpec(
+				tb.PodVolumes(toolsVolume, workspaceVolume, homeVolume),
+				tb.PodRestartPolicy(corev1.RestartPolicyNever),
+				getCredentialsInitContainer("mz4c7"),
+				placeToolsInitContainer,
+				tb.PodInitContainer("build-step-place-scripts", "override-with-entrypoint:latest",
+					tb.Command("/bin/sh"),
+					tb.Args("-c", `mkdir -p /builder/tools/scripts
+cat > /builder/tools/scripts/script-0 << 'script-heredoc-9l9zj'
+#!/usr/bin/env python
+print('hello')
+script-heredoc-9l9zj
+chmod +x /builder/tools/scripts/script-0
+`),
+					tb.WorkingDir(workspaceDir),
+					tb.EnvVar("HOME", "/builder/home"),
+					tb.VolumeMount("tools", "/builder/tools"),
+					tb.VolumeMount("workspace", workspaceDir),
+					tb.VolumeMount("home", "/builder/home"),
+				),
+				tb.PodContainer("build-step-myscript", "python",
+					tb.Command(entrypointLocation),
+					tb.Args("-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "/builder/tools/scripts/script-0", "--"),
+					tb.WorkingDir(workspaceDir),
+					tb.EnvVar("HOME", "/builder/home"),
+					tb.VolumeMount("tools", "/builder/tools"),
+					tb.VolumeMount("workspace", workspaceDir),
+					tb.VolumeMount("home", "/builder/home"),
+					tb.Resources(tb.Requests(
+						tb.CPU("0"),
+						tb.Memory("0"),
+						tb.EphemeralStorage("0"),
+					)),
+				),
+				tb.PodContainer("nop", "override-with-nop:latest",
+					tb.Command("/builder/tools/entrypoint"),
+					tb.Args("-wait_file", "/builder/tools/0", "-post_file", "/builder/tools/1", "-entrypoint", "/ko-app/nop", "--"),
+					tb.VolumeMount(entrypoint.MountName, entrypoint.MountPoint),
+				),
+			),
+		),
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			names.TestingSeed()
@@ -923,8 +986,11 @@ func TestReconcileInvalidTaskRuns(t *testing.T) {
 	withWrongRef := tb.TaskRun("taskrun-with-wrong-ref", "foo", tb.TaskRunSpec(
 		tb.TaskRunTaskRef("taskrun-with-wrong-ref", tb.TaskRefKind(v1alpha1.ClusterTaskKind)),
 	))
-	taskRuns := []*v1alpha1.TaskRun{noTaskRun, withWrongRef}
-	tasks := []*v1alpha1.Task{simpleTask}
+	withoutWorkspace := tb.TaskRun("taskrun-without-workspace", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskRef(workspaceTask.Name),
+	))
+	taskRuns := []*v1alpha1.TaskRun{noTaskRun, withWrongRef, withoutWorkspace}
+	tasks := []*v1alpha1.Task{simpleTask, workspaceTask}
 
 	d := test.Data{
 		TaskRuns: taskRuns,
@@ -946,6 +1012,11 @@ func TestReconcileInvalidTaskRuns(t *testing.T) {
 			taskRun: withWrongRef,
 			reason:  reasonFailedResolution,
 		},
+		{
+			name:    "task run without workspace binding",
+			taskRun: withoutWorkspace,
+			reason:  reasonFailedValidation,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -976,6 +1047,75 @@ func TestReconcileInvalidTaskRuns(t *testing.T) {
 
 }
 
+func TestReconcileWithWorkspaceVolumeClaimTemplate(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun-workspace", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskRef(workspaceTask.Name),
+		tb.TaskRunWorkspace("source", tb.WorkspaceBindingVolumeClaimTemplate(&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "mypvc"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			},
+		})),
+	))
+	d := test.Data{
+		TaskRuns: []*v1alpha1.TaskRun{taskRun},
+		Tasks:    []*v1alpha1.Task{workspaceTask},
+	}
+
+	testAssets := getTaskRunController(d)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+	if _, err := clients.Kube.CoreV1().ServiceAccounts(taskRun.Namespace).Create(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: taskRun.Namespace,
+		},
+	}); err != nil {
+		t.Fatalf("Unexpected error creating service account: %v", err)
+	}
+
+	if err := c.Reconciler.Reconcile(context.Background(), getRunName(taskRun)); err != nil {
+		t.Fatalf("Unexpected error when Reconcile(): %v", err)
+	}
+	newTr, err := clients.Pipeline.TektonV1alpha1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error fetching taskrun: %v", err)
+	}
+
+	pvcs, err := clients.Kube.CoreV1().PersistentVolumeClaims(taskRun.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error listing claims: %v", err)
+	}
+	if len(pvcs.Items) != 1 {
+		t.Fatalf("Expected a claim to be created for the workspace but got %d claims", len(pvcs.Items))
+	}
+	pvc := pvcs.Items[0]
+	if !strings.HasPrefix(pvc.Name, "mypvc-") {
+		t.Errorf("Expected the claim to be named after its template but was %q", pvc.Name)
+	}
+	if len(pvc.OwnerReferences) != 1 || pvc.OwnerReferences[0].Kind != "TaskRun" || pvc.OwnerReferences[0].Name != taskRun.Name {
+		t.Errorf("Expected the claim to be owned by the TaskRun but owner references were %v", pvc.OwnerReferences)
+	}
+
+	pod, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(newTr.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected pod %s to exist but got error: %v", newTr.Status.PodName, err)
+	}
+	found := false
+	for _, v := range pod.Spec.Volumes {
+		if v.Name == "ws-source" && v.PersistentVolumeClaim != nil && v.PersistentVolumeClaim.ClaimName == pvc.Name {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected the pod to use the claim %q for the workspace but volumes were %v", pvc.Name, pod.Spec.Volumes)
+	}
+	step := pod.Spec.Containers[0]
+	if d := cmp.Diff([]string{"-wait_file", "", "-post_file", "/builder/tools/0", "-entrypoint", "ls", "--", "/workspace/source"}, step.Args); d != "" {
+		t.Errorf("Expected the workspace path to be replaced in the step args -want, +got: %v", d)
+	}
+}
+
 func TestReconcilePodFetchError(t *testing.T) {
 	taskRun := tb.TaskRun("test-taskrun-run-success", "foo",
 		tb.TaskRunSpec(tb.TaskRunTaskRef("test-task")),
@@ -1076,6 +1216,234 @@ func TestReconcilePodUpdateStatus(t *testing.T) {
 	}
 }
 
+func TestReconcileStopsSidecars(t *testing.T) {
+	sidecarTask := tb.Task("test-sidecar-task", "foo", tb.TaskSpec(
+		simpleStep,
+		tb.Sidecar("database", "postgres"),
+	))
+	taskRun := tb.TaskRun("test-taskrun-sidecar", "foo", tb.TaskRunSpec(tb.TaskRunTaskRef(sidecarTask.Name)))
+
+	logger, _ := logging.NewLogger("", "")
+	cache, _ := entrypoint.NewCache()
+	pod, err := resources.MakePod(taskRun, sidecarTask.Spec, fakekubeclientset.NewSimpleClientset(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: taskRun.Namespace,
+		},
+	}), cache, logger)
+	if err != nil {
+		t.Fatalf("MakePod: %v", err)
+	}
+	// The steps are done, but the sidecar keeps the pod running.
+	var nopImage string
+	pod.Status.Phase = corev1.PodRunning
+	for _, c := range pod.Spec.Containers {
+		state := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+		if resources.IsContainerSidecar(c.Name) {
+			state = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+		}
+		if c.Name == "nop" {
+			nopImage = c.Image
+		}
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{Name: c.Name, State: state})
+	}
+	taskRun.Status = v1alpha1.TaskRunStatus{
+		PodName: pod.Name,
+	}
+	d := test.Data{
+		TaskRuns: []*v1alpha1.TaskRun{taskRun},
+		Tasks:    []*v1alpha1.Task{sidecarTask},
+		Pods:     []*corev1.Pod{pod},
+	}
+
+	testAssets := getTaskRunController(d)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), fmt.Sprintf("%s/%s", taskRun.Namespace, taskRun.Name)); err != nil {
+		t.Fatalf("Unexpected error when Reconcile() : %v", err)
+	}
+	newTr, err := clients.Pipeline.TektonV1alpha1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	if !newTr.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+		t.Errorf("Expected TaskRun to succeed once its steps are done but its condition was %v", newTr.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	if len(newTr.Status.Steps) != len(pod.Spec.Containers)-1 {
+		t.Errorf("Expected the sidecar not to be reported as a step but got %d steps", len(newTr.Status.Steps))
+	}
+
+	newPod, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(pod.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error fetching pod: %v", err)
+	}
+	for _, c := range newPod.Spec.Containers {
+		if resources.IsContainerSidecar(c.Name) && c.Image != nopImage {
+			t.Errorf("Expected sidecar %q to be stopped with image %q but its image was %q", c.Name, nopImage, c.Image)
+		}
+	}
+}
+
+func TestReconcileRetriesFailedPod(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun-retry", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskRef("test-task"),
+		tb.TaskRunRetries(1),
+	))
+
+	logger, _ := logging.NewLogger("", "")
+	cache, _ := entrypoint.NewCache()
+	pod, err := resources.MakePod(taskRun, simpleTask.Spec, fakekubeclientset.NewSimpleClientset(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: taskRun.Namespace,
+		},
+	}), cache, logger)
+	if err != nil {
+		t.Fatalf("MakePod: %v", err)
+	}
+	pod.Status = corev1.PodStatus{
+		Phase:   corev1.PodFailed,
+		Message: "boom",
+	}
+	taskRun.Status = v1alpha1.TaskRunStatus{
+		PodName: pod.Name,
+	}
+	d := test.Data{
+		TaskRuns: []*v1alpha1.TaskRun{taskRun},
+		Tasks:    []*v1alpha1.Task{simpleTask},
+		Pods:     []*corev1.Pod{pod},
+	}
+
+	testAssets := getTaskRunController(d)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+	if _, err := clients.Kube.CoreV1().ServiceAccounts(taskRun.Namespace).Create(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: taskRun.Namespace,
+		},
+	}); err != nil {
+		t.Fatalf("Unexpected error creating service account: %v", err)
+	}
+
+	if err := c.Reconciler.Reconcile(context.Background(), fmt.Sprintf("%s/%s", taskRun.Namespace, taskRun.Name)); err != nil {
+		t.Fatalf("Unexpected error when Reconcile(): %v", err)
+	}
+	newTr, err := clients.Pipeline.TektonV1alpha1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error fetching taskrun: %v", err)
+	}
+	if len(newTr.Status.RetriesStatus) != 1 {
+		t.Fatalf("Expected the failed attempt to be recorded in RetriesStatus but got %d entries", len(newTr.Status.RetriesStatus))
+	}
+	if !newTr.Status.RetriesStatus[0].GetCondition(apis.ConditionSucceeded).IsFalse() {
+		t.Errorf("Expected the recorded attempt to have failed but condition was %v", newTr.Status.RetriesStatus[0].GetCondition(apis.ConditionSucceeded))
+	}
+	if newTr.Status.RetriesStatus[0].PodName != pod.Name {
+		t.Errorf("Expected the recorded attempt to reference pod %q but was %q", pod.Name, newTr.Status.RetriesStatus[0].PodName)
+	}
+	if newTr.Status.PodName != "" {
+		t.Errorf("Expected the pod name to be reset so a new pod is created, but was %q", newTr.Status.PodName)
+	}
+	condition := newTr.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionUnknown || condition.Reason != reasonRetrying {
+		t.Errorf("Expected TaskRun to be retrying but condition was %v", condition)
+	}
+	if _, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(pod.Name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the pod of the failed attempt to be deleted but got %v", err)
+	}
+
+	// The next reconcile creates a new pod for the retry
+	if err := c.Reconciler.Reconcile(context.Background(), fmt.Sprintf("%s/%s", taskRun.Namespace, taskRun.Name)); err != nil {
+		t.Fatalf("Unexpected error when Reconcile(): %v", err)
+	}
+	newTr, err = clients.Pipeline.TektonV1alpha1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error fetching taskrun: %v", err)
+	}
+	if newTr.Status.PodName == "" || newTr.Status.PodName == pod.Name {
+		t.Errorf("Expected a new pod to be created for the retry but pod name was %q", newTr.Status.PodName)
+	}
+
+	// Once the retries are exhausted, a failure is final
+	newPod, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(newTr.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected pod %s to exist but got error: %v", newTr.Status.PodName, err)
+	}
+	newPod.Status = corev1.PodStatus{Phase: corev1.PodFailed}
+	if _, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).UpdateStatus(newPod); err != nil {
+		t.Errorf("Unexpected error while updating pod: %v", err)
+	}
+	if err := c.Reconciler.Reconcile(context.Background(), fmt.Sprintf("%s/%s", taskRun.Namespace, taskRun.Name)); err != nil {
+		t.Fatalf("Unexpected error when Reconcile(): %v", err)
+	}
+	newTr, err = clients.Pipeline.TektonV1alpha1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error fetching taskrun: %v", err)
+	}
+	if !newTr.Status.GetCondition(apis.ConditionSucceeded).IsFalse() {
+		t.Errorf("Expected TaskRun to have failed once its retries were exhausted but condition was %v", newTr.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	if len(newTr.Status.RetriesStatus) != 1 {
+		t.Errorf("Expected 1 entry in RetriesStatus but got %d", len(newTr.Status.RetriesStatus))
+	}
+}
+
+func TestReconcileRetriesTimedOutPod(t *testing.T) {
+	taskRun := tb.TaskRun("test-taskrun-retry-timeout", "foo", tb.TaskRunSpec(
+		tb.TaskRunTaskRef("test-task"),
+		tb.TaskRunTimeout(10*time.Second),
+		tb.TaskRunRetries(1),
+	), tb.TaskRunStatus(
+		tb.PodName("test-taskrun-retry-timeout-pod"),
+		tb.TaskRunStartTime(time.Now().Add(-15*time.Second)),
+		tb.Condition(apis.Condition{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionUnknown,
+		}),
+	))
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      "test-taskrun-retry-timeout-pod",
+		Namespace: taskRun.Namespace,
+	}}
+	d := test.Data{
+		TaskRuns: []*v1alpha1.TaskRun{taskRun},
+		Tasks:    []*v1alpha1.Task{simpleTask},
+		Pods:     []*corev1.Pod{pod},
+	}
+
+	testAssets := getTaskRunController(d)
+	c := testAssets.Controller
+	clients := testAssets.Clients
+
+	if err := c.Reconciler.Reconcile(context.Background(), fmt.Sprintf("%s/%s", taskRun.Namespace, taskRun.Name)); err != nil {
+		t.Fatalf("Unexpected error when Reconcile(): %v", err)
+	}
+	newTr, err := clients.Pipeline.TektonV1alpha1().TaskRuns(taskRun.Namespace).Get(taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error fetching taskrun: %v", err)
+	}
+
+	// The attempt which timed out is recorded, and its pod deleted, before it is retried
+	if len(newTr.Status.RetriesStatus) != 1 {
+		t.Fatalf("Expected the attempt which timed out to be recorded in RetriesStatus but got %d entries", len(newTr.Status.RetriesStatus))
+	}
+	if reason := newTr.Status.RetriesStatus[0].GetCondition(apis.ConditionSucceeded).Reason; reason != reasonTimedOut {
+		t.Errorf("Expected the recorded attempt to have timed out but reason was %q", reason)
+	}
+	if newTr.Status.PodName != "" || newTr.Status.StartTime != nil {
+		t.Errorf("Expected the pod and start time to be reset so a new attempt is started, but got %q and %v", newTr.Status.PodName, newTr.Status.StartTime)
+	}
+	condition := newTr.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Status != corev1.ConditionUnknown || condition.Reason != reasonRetrying {
+		t.Errorf("Expected TaskRun to be retrying but condition was %v", condition)
+	}
+	if _, err := clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(pod.Name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected the pod of the attempt which timed out to be deleted but got %v", err)
+	}
+}
+
 func TestCreateRedirectedTaskSpec(t *testing.T) {
 	tr := tb.TaskRun("tr", "tr", tb.TaskRunSpec(
 		tb.TaskRunServiceAccount("sa"),
@@ -1357,6 +1725,69 @@ func TestUpdateStatusFromPod(t *testing.T) {
 			// We don't actually care about the time, just that it's not nil
 			CompletionTime: &metav1.Time{Time: time.Now()},
 		},
+	}, {
+		desc: "failure-step-timeout",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-build",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Reason:   "Error",
+						Message:  `[{"name":"$reason","value":"StepTimedOut"}]`,
+					},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionFalse,
+					Message: `build step "step-build" timed out; for logs run: kubectl -n foo logs pod -c step-build`,
+				}},
+			},
+			Steps: []v1alpha1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Reason:   v1alpha1.StepReasonTimedOut,
+						Message:  `[{"name":"$reason","value":"StepTimedOut"}]`,
+					}},
+			}},
+			// We don't actually care about the time, just that it's not nil
+			CompletionTime: &metav1.Time{Time: time.Now()},
+		},
+	}, {
+		desc: "success-step-continued-after-error",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-lint",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Reason:  "Completed",
+						Message: `[{"name":"$exitCode","value":"2"}]`,
+					},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{conditionTrue},
+			},
+			Steps: []v1alpha1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 2,
+						Reason:   "Completed",
+						Message:  `[{"name":"$exitCode","value":"2"}]`,
+					}},
+			}},
+			// We don't actually care about the time, just that it's not nil
+			CompletionTime: &metav1.Time{Time: time.Now()},
+		},
 	}, {
 		desc: "failure-message",
 		podStatus: corev1.PodStatus{
@@ -1475,6 +1906,177 @@ func TestUpdateStatusFromPod(t *testing.T) {
 			},
 			Steps: []v1alpha1.StepState{},
 		},
+	}, {
+		desc: "task-results",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-build",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message:    `[{"name":"sha","value":"abc123"},{"name":"version","value":"v0.1"}]`,
+						FinishedAt: metav1.Unix(1, 0),
+					},
+				},
+			}, {
+				Name: "step-tag",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message:    `[{"name":"version","value":"v0.2"}]`,
+						FinishedAt: metav1.Unix(2, 0),
+					},
+				},
+			}, {
+				Name: "step-other",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message:    "not written by the entrypoint",
+						FinishedAt: metav1.Unix(3, 0),
+					},
+				},
+			}, {
+				Name: "sidecar-database",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message:    `[{"name":"sha","value":"written by the sidecar"}]`,
+						FinishedAt: metav1.Unix(4, 0),
+					},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{conditionTrue},
+			},
+			Steps: []v1alpha1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message:    `[{"name":"sha","value":"abc123"},{"name":"version","value":"v0.1"}]`,
+						FinishedAt: metav1.Unix(1, 0),
+					}},
+			}, {
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message:    `[{"name":"version","value":"v0.2"}]`,
+						FinishedAt: metav1.Unix(2, 0),
+					}},
+			}, {
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message:    "not written by the entrypoint",
+						FinishedAt: metav1.Unix(3, 0),
+					}},
+			}},
+			TaskResults: []v1alpha1.TaskRunResult{{
+				Name:  "sha",
+				Value: "abc123",
+			}, {
+				Name:  "version",
+				Value: "v0.2",
+			}},
+			// We don't actually care about the time, just that it's not nil
+			CompletionTime: &metav1.Time{Time: time.Now()},
+		},
+	}, {
+		desc: "running-sidecar",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "build-step-build",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{},
+				},
+			}, {
+				Name: "build-step-test",
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{},
+				},
+			}, {
+				Name: "sidecar-database",
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{conditionBuilding},
+			},
+			Steps: []v1alpha1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{},
+				},
+			}, {
+				ContainerState: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{},
+				},
+			}},
+		},
+	}, {
+		desc: "success-steps-complete-sidecar-running",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "build-step-build",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{},
+				},
+			}, {
+				Name: "sidecar-database",
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{conditionTrue},
+			},
+			Steps: []v1alpha1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{},
+				},
+			}},
+			// We don't actually care about the time, just that it's not nil
+			CompletionTime: &metav1.Time{Time: time.Now()},
+		},
+	}, {
+		desc: "failure-steps-complete-sidecar-running",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:    "build-step-build",
+				ImageID: "image-id",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+					},
+				},
+			}, {
+				Name: "sidecar-database",
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{},
+				},
+			}},
+		},
+		want: v1alpha1.TaskRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: []apis.Condition{{
+					Type:    apis.ConditionSucceeded,
+					Status:  corev1.ConditionFalse,
+					Message: `build step "build-step-build" exited with code 1 (image: "image-id"); for logs run: kubectl -n foo logs pod -c build-step-build`,
+				}},
+			},
+			Steps: []v1alpha1.StepState{{
+				ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+					},
+				},
+			}},
+			// We don't actually care about the time, just that it's not nil
+			CompletionTime: &metav1.Time{Time: time.Now()},
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			now := metav1.Now()
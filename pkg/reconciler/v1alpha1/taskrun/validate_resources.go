@@ -80,7 +80,7 @@ func validateParams(inputs *v1alpha1.Inputs, params []v1alpha1.Param) error {
 	missingParamsNoDefaults := []string{}
 	for _, param := range missingParams {
 		for _, inputResourceParam := range inputs.Params {
-			if inputResourceParam.Name == param && inputResourceParam.Default == "" {
+			if inputResourceParam.Name == param && inputResourceParam.Default == nil {
 				missingParamsNoDefaults = append(missingParamsNoDefaults, param)
 			}
 		}
@@ -92,6 +92,18 @@ func validateParams(inputs *v1alpha1.Inputs, params []v1alpha1.Param) error {
 	if len(extraParams) != 0 {
 		return fmt.Errorf("didn't need these params but they were provided anyway: %s", extraParams)
 	}
+	wrongTypeParams := []string{}
+	for _, param := range params {
+		for _, inputResourceParam := range inputs.Params {
+			isArray := inputResourceParam.GetType() == v1alpha1.ParamTypeArray
+			if inputResourceParam.Name == param.Name && isArray != (param.Value.Type == v1alpha1.ParamTypeArray) {
+				wrongTypeParams = append(wrongTypeParams, param.Name)
+			}
+		}
+	}
+	if len(wrongTypeParams) > 0 {
+		return fmt.Errorf("param types don't match the user-specified type: %s", wrongTypeParams)
+	}
 	return nil
 }
 
@@ -109,3 +121,20 @@ func ValidateResolvedTaskResources(params []v1alpha1.Param, rtr *resources.Resol
 
 	return nil
 }
+
+// ValidateWorkspaces validates that each of the workspaces declared by the task is bound by
+// the taskrun, and that the taskrun doesn't bind any other workspace
+func ValidateWorkspaces(declarations []v1alpha1.WorkspaceDeclaration, bindings []v1alpha1.WorkspaceBinding) error {
+	declared := make([]string, 0, len(declarations))
+	for _, w := range declarations {
+		declared = append(declared, w.Name)
+	}
+	bound := make([]string, 0, len(bindings))
+	for _, wb := range bindings {
+		bound = append(bound, wb.Name)
+	}
+	if err := list.IsSame(declared, bound); err != nil {
+		return fmt.Errorf("TaskRun's workspace bindings didn't match the workspaces declared by the Task: %s", err)
+	}
+	return nil
+}
@@ -31,14 +31,17 @@ func TestValidateResolvedTaskResources_ValidResources(t *testing.T) {
 func TestValidateResolvedTaskResources_ValidParams(t *testing.T) {
 	rtr := tb.ResolvedTaskResources(tb.ResolvedTaskResourcesTaskSpec(
 		tb.Step("mystep", "myimage", tb.Command("mycmd")),
-		tb.TaskInputs(tb.InputsParam("foo"), tb.InputsParam("bar")),
+		tb.TaskInputs(tb.InputsParam("foo"), tb.InputsParam("bar"), tb.InputsParam("zoo", tb.ParamType(v1alpha1.ParamTypeArray))),
 	))
 	p := []v1alpha1.Param{{
 		Name:  "foo",
-		Value: "somethinggood",
+		Value: *v1alpha1.NewArrayOrString("somethinggood"),
 	}, {
 		Name:  "bar",
-		Value: "somethinggood",
+		Value: *v1alpha1.NewArrayOrString("somethinggood"),
+	}, {
+		Name:  "zoo",
+		Value: *v1alpha1.NewArrayOrString("a", "b"),
 	}}
 	if err := taskrun.ValidateResolvedTaskResources(p, rtr); err != nil {
 		t.Fatalf("Did not expect to see error when validating TaskRun with correct params but saw %v", err)
@@ -58,7 +61,7 @@ func TestValidateResolvedTaskResources_InvalidParams(t *testing.T) {
 		)),
 		params: []v1alpha1.Param{{
 			Name:  "foobar",
-			Value: "somethingfun",
+			Value: *v1alpha1.NewArrayOrString("somethingfun"),
 		}},
 	}, {
 		name: "missing-params",
@@ -68,10 +71,20 @@ func TestValidateResolvedTaskResources_InvalidParams(t *testing.T) {
 		)),
 		params: []v1alpha1.Param{{
 			Name:  "foo",
-			Value: "i am a real param",
+			Value: *v1alpha1.NewArrayOrString("i am a real param"),
 		}, {
 			Name:  "extra",
-			Value: "i am an extra param",
+			Value: *v1alpha1.NewArrayOrString("i am an extra param"),
+		}},
+	}, {
+		name: "mismatching-param-types",
+		rtr: tb.ResolvedTaskResources(tb.ResolvedTaskResourcesTaskSpec(
+			tb.Step("mystep", "myimage", tb.Command("mycmd")),
+			tb.TaskInputs(tb.InputsParam("foo", tb.ParamType(v1alpha1.ParamTypeArray))),
+		)),
+		params: []v1alpha1.Param{{
+			Name:  "foo",
+			Value: *v1alpha1.NewArrayOrString("i am a string"),
 		}},
 	}}
 	for _, tc := range tcs {
@@ -159,3 +172,34 @@ func TestValidateResolvedTaskResources_InvalidResources(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateWorkspaces(t *testing.T) {
+	declarations := []v1alpha1.WorkspaceDeclaration{{Name: "source"}, {Name: "cache"}}
+	tcs := []struct {
+		name     string
+		bindings []v1alpha1.WorkspaceBinding
+		wantErr  bool
+	}{{
+		name:     "all-bound",
+		bindings: []v1alpha1.WorkspaceBinding{{Name: "cache"}, {Name: "source"}},
+	}, {
+		name:     "missing-binding",
+		bindings: []v1alpha1.WorkspaceBinding{{Name: "source"}},
+		wantErr:  true,
+	}, {
+		name:     "extra-binding",
+		bindings: []v1alpha1.WorkspaceBinding{{Name: "source"}, {Name: "cache"}, {Name: "other"}},
+		wantErr:  true,
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := taskrun.ValidateWorkspaces(declarations, tc.bindings)
+			if tc.wantErr && err == nil {
+				t.Errorf("Expected to see error when validating invalid workspace bindings but saw none")
+			} else if !tc.wantErr && err != nil {
+				t.Errorf("Did not expect to see error when validating valid workspace bindings but saw %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package volumeclaim creates the PersistentVolumeClaims of the workspaces
+// bound to a volumeClaimTemplate.
+package volumeclaim
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultClaimName = "pvc"
+	hashLength       = 10
+	maxBaseLength    = 63 - hashLength - 1
+)
+
+// GetPersistentVolumeClaimName returns the name of the PersistentVolumeClaim created from the
+// volumeClaimTemplate of wb for the run owner. The name is stable so that the claim is only
+// created once per run.
+func GetPersistentVolumeClaimName(wb v1alpha1.WorkspaceBinding, owner metav1.OwnerReference) string {
+	base := defaultClaimName
+	if wb.VolumeClaimTemplate != nil && wb.VolumeClaimTemplate.Name != "" {
+		base = wb.VolumeClaimTemplate.Name
+	}
+	if len(base) > maxBaseLength {
+		base = base[:maxBaseLength]
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(wb.Name+owner.Name)))
+	return fmt.Sprintf("%s-%s", base, hash[:hashLength])
+}
+
+// CreatePersistentVolumeClaimsForWorkspaces creates the PersistentVolumeClaims of the bindings
+// which use a volumeClaimTemplate, unless they already exist. The claims are owned by owner, so
+// they are deleted along with the run.
+func CreatePersistentVolumeClaimsForWorkspaces(c kubernetes.Interface, bindings []v1alpha1.WorkspaceBinding, owner metav1.OwnerReference, namespace string) error {
+	for _, wb := range bindings {
+		if wb.VolumeClaimTemplate == nil {
+			continue
+		}
+		name := GetPersistentVolumeClaimName(wb, owner)
+		if _, err := c.CoreV1().PersistentVolumeClaims(namespace).Get(name, metav1.GetOptions{}); err == nil {
+			continue
+		} else if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get claim Persistent Volume %q for workspace %q due to error: %s", name, wb.Name, err)
+		}
+		if _, err := c.CoreV1().PersistentVolumeClaims(namespace).Create(getPersistentVolumeClaim(wb, name, owner, namespace)); err != nil {
+			return fmt.Errorf("failed to claim Persistent Volume %q for workspace %q due to error: %s", name, wb.Name, err)
+		}
+	}
+	return nil
+}
+
+func getPersistentVolumeClaim(wb v1alpha1.WorkspaceBinding, name string, owner metav1.OwnerReference, namespace string) *corev1.PersistentVolumeClaim {
+	claim := wb.VolumeClaimTemplate.DeepCopy()
+	claim.Name = name
+	claim.Namespace = namespace
+	claim.OwnerReferences = append(claim.OwnerReferences, owner)
+	return claim
+}
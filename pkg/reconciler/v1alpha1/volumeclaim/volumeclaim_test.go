@@ -0,0 +1,107 @@
+/*
+Copyright 2019 The Knative Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumeclaim
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+var owner = metav1.OwnerReference{
+	APIVersion: "tekton.dev/v1alpha1",
+	Kind:       "PipelineRun",
+	Name:       "test-pipelinerun",
+}
+
+func TestGetPersistentVolumeClaimName(t *testing.T) {
+	wb := v1alpha1.WorkspaceBinding{
+		Name: "source",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "mypvc"},
+		},
+	}
+	name := GetPersistentVolumeClaimName(wb, owner)
+	if !strings.HasPrefix(name, "mypvc-") || len(name) != len("mypvc-")+hashLength {
+		t.Errorf("Expected the claim name to be the template name followed by a hash but was %q", name)
+	}
+	if name != GetPersistentVolumeClaimName(wb, owner) {
+		t.Errorf("Expected the claim name to be stable")
+	}
+
+	otherOwner := owner
+	otherOwner.Name = "other-pipelinerun"
+	if name == GetPersistentVolumeClaimName(wb, otherOwner) {
+		t.Errorf("Expected the claim names of different runs to be different")
+	}
+
+	wb.VolumeClaimTemplate.Name = ""
+	if n := GetPersistentVolumeClaimName(wb, owner); !strings.HasPrefix(n, "pvc-") {
+		t.Errorf("Expected the claim name to default to the pvc prefix but was %q", n)
+	}
+}
+
+func TestCreatePersistentVolumeClaimsForWorkspaces(t *testing.T) {
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "mypvc"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		},
+	}
+	bindings := []v1alpha1.WorkspaceBinding{{
+		Name:                "source",
+		VolumeClaimTemplate: claim,
+	}, {
+		Name:     "cache",
+		EmptyDir: &corev1.EmptyDirVolumeSource{},
+	}}
+	c := fakek8s.NewSimpleClientset()
+
+	// Creating the claims twice must not fail, since reconciling the run is retried.
+	for i := 0; i < 2; i++ {
+		if err := CreatePersistentVolumeClaimsForWorkspaces(c, bindings, owner, "foo"); err != nil {
+			t.Fatalf("Didn't expect error creating the claims but got %v", err)
+		}
+	}
+
+	pvcs, err := c.CoreV1().PersistentVolumeClaims("foo").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error listing claims: %v", err)
+	}
+	if len(pvcs.Items) != 1 {
+		t.Fatalf("Expected a single claim to be created but got %d", len(pvcs.Items))
+	}
+	expected := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            GetPersistentVolumeClaimName(bindings[0], owner),
+			Namespace:       "foo",
+			OwnerReferences: []metav1.OwnerReference{owner},
+		},
+		Spec: claim.Spec,
+	}
+	if d := cmp.Diff(expected, pvcs.Items[0]); d != "" {
+		t.Errorf("Unexpected claim -want, +got: %v", d)
+	}
+	if claim.Name != "mypvc" {
+		t.Errorf("Expected the template not to be modified but its name was %q", claim.Name)
+	}
+}
@@ -23,7 +23,23 @@ import (
 
 const parameterSubstitution = "[_a-zA-Z][_a-zA-Z0-9.-]*"
 
+// contextVariables are the variables describing the context a Task is run in, which
+// are referenced as ${context.<variable>}.
+var contextVariables = map[string]struct{}{
+	"taskRun.name":      {},
+	"taskRun.namespace": {},
+	"taskRun.uid":       {},
+	"pipelineRun.name":  {},
+	"pipelineTask.name": {},
+	"task.retry-count":  {},
+}
+
+// ValidateVariable returns an error if value references a variable starting with prefix which
+// isn't one of vars, or a context variable which doesn't exist.
 func ValidateVariable(name, value, prefix, contextPrefix, locationName, path string, vars map[string]struct{}) *apis.FieldError {
+	if err := validateContextVariables(name, value, locationName, path); err != nil {
+		return err
+	}
 	if vs, present := extractVariablesFromString(value, contextPrefix+prefix); present {
 		for _, v := range vs {
 			if _, ok := vars[v]; !ok {
@@ -37,6 +53,68 @@ func ValidateVariable(name, value, prefix, contextPrefix, locationName, path str
 	return nil
 }
 
+// validateContextVariables returns an error if value references a context variable which
+// doesn't exist.
+func validateContextVariables(name, value, locationName, path string) *apis.FieldError {
+	re := regexp.MustCompile(fmt.Sprintf("\\$\\{context\\.(%s)\\}", parameterSubstitution))
+	for _, match := range re.FindAllStringSubmatch(value, -1) {
+		if _, ok := contextVariables[match[1]]; !ok {
+			return &apis.FieldError{
+				Message: fmt.Sprintf("non-existent context variable in %q for %s %s", value, locationName, name),
+				Paths:   []string{path + "." + name},
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateVariableProhibited returns an error if value references one of vars,
+// e.g. an array parameter in a field which can only hold a string.
+func ValidateVariableProhibited(name, value, prefix, contextPrefix, locationName, path string, vars map[string]struct{}) *apis.FieldError {
+	if vs, present := extractVariablesFromString(value, contextPrefix+prefix); present {
+		for _, v := range vs {
+			if _, ok := vars[v]; ok {
+				return &apis.FieldError{
+					Message: fmt.Sprintf("variable type invalid in %q for %s %s", value, locationName, name),
+					Paths:   []string{path + "." + name},
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateVariableIsolated returns an error if value references one of vars
+// without being exactly that reference, e.g. an array parameter which isn't a
+// whole element of an array.
+func ValidateVariableIsolated(name, value, prefix, contextPrefix, locationName, path string, vars map[string]struct{}) *apis.FieldError {
+	if vs, present := extractVariablesFromString(value, contextPrefix+prefix); present {
+		firstMatch, _ := extractExpressionFromString(value, contextPrefix+prefix)
+		for _, v := range vs {
+			if _, ok := vars[v]; ok {
+				if len(value) != len(firstMatch) {
+					return &apis.FieldError{
+						Message: fmt.Sprintf("variable is not properly isolated in %q for %s %s", value, locationName, name),
+						Paths:   []string{path + "." + name},
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// extractExpressionFromString returns the first variable of value starting with prefix.
+func extractExpressionFromString(s, prefix string) (string, bool) {
+	pattern := fmt.Sprintf("\\$({%s.(?P<var>%s)})", prefix, parameterSubstitution)
+	re := regexp.MustCompile(pattern)
+	match := re.FindStringSubmatch(s)
+	if match == nil {
+		return "", false
+	}
+	return match[0], true
+}
+
 func extractVariablesFromString(s, prefix string) ([]string, bool) {
 	pattern := fmt.Sprintf("\\$({%s.(?P<var>%s)})", prefix, parameterSubstitution)
 	re := regexp.MustCompile(pattern)
@@ -69,3 +147,15 @@ func ApplyReplacements(in string, replacements map[string]string) string {
 	}
 	return in
 }
+
+// ApplyArrayReplacements replaces the variables of in with stringReplacements,
+// unless in is exactly a variable of arrayReplacements, in which case it is
+// replaced with all of the elements of that array.
+func ApplyArrayReplacements(in string, stringReplacements map[string]string, arrayReplacements map[string][]string) []string {
+	for k, v := range arrayReplacements {
+		if in == fmt.Sprintf("${%s}", k) {
+			return v
+		}
+	}
+	return []string{ApplyReplacements(in, stringReplacements)}
+}
@@ -112,6 +112,33 @@ func TestValidateVariables(t *testing.T) {
 				Paths:   []string{"taskspec.steps.somefield"},
 			},
 		},
+		{
+			name: "context variables",
+			args: args{
+				input:         "--tag=${context.pipelineRun.name}-${context.taskRun.uid}-${context.task.retry-count}",
+				prefix:        "params",
+				contextPrefix: "inputs.",
+				locationName:  "step",
+				path:          "taskspec.steps",
+				vars:          map[string]struct{}{},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "undefined context variable",
+			args: args{
+				input:         "--tag=${context.taskRun.labels}",
+				prefix:        "params",
+				contextPrefix: "inputs.",
+				locationName:  "step",
+				path:          "taskspec.steps",
+				vars:          map[string]struct{}{},
+			},
+			expectedError: &apis.FieldError{
+				Message: `non-existent context variable in "--tag=${context.taskRun.labels}" for step somefield`,
+				Paths:   []string{"taskspec.steps.somefield"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -123,3 +150,79 @@ func TestValidateVariables(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateVariableIsolated(t *testing.T) {
+	vars := map[string]struct{}{"baz": {}}
+	tests := []struct {
+		name          string
+		input         string
+		expectedError *apis.FieldError
+	}{{
+		name:  "isolated variable",
+		input: "${inputs.params.baz}",
+	}, {
+		name:  "other variable",
+		input: "--flag=${inputs.params.foo}",
+	}, {
+		name:  "variable not isolated",
+		input: "--flag=${inputs.params.baz}",
+		expectedError: &apis.FieldError{
+			Message: `variable is not properly isolated in "--flag=${inputs.params.baz}" for step somefield`,
+			Paths:   []string{"taskspec.steps.somefield"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := templating.ValidateVariableIsolated("somefield", tt.input, "params", "inputs.", "step", "taskspec.steps", vars)
+			if d := cmp.Diff(got, tt.expectedError, cmp.AllowUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("ValidateVariableIsolated() error did not match expected error %s", d)
+			}
+		})
+	}
+}
+
+func TestValidateVariableProhibited(t *testing.T) {
+	vars := map[string]struct{}{"baz": {}}
+	tests := []struct {
+		name          string
+		input         string
+		expectedError *apis.FieldError
+	}{{
+		name:  "other variable",
+		input: "--flag=${inputs.params.foo}",
+	}, {
+		name:  "prohibited variable",
+		input: "${inputs.params.baz}",
+		expectedError: &apis.FieldError{
+			Message: `variable type invalid in "${inputs.params.baz}" for step somefield`,
+			Paths:   []string{"taskspec.steps.somefield"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := templating.ValidateVariableProhibited("somefield", tt.input, "params", "inputs.", "step", "taskspec.steps", vars)
+			if d := cmp.Diff(got, tt.expectedError, cmp.AllowUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("ValidateVariableProhibited() error did not match expected error %s", d)
+			}
+		})
+	}
+}
+
+func TestApplyArrayReplacements(t *testing.T) {
+	stringReplacements := map[string]string{"params.foo": "bar"}
+	arrayReplacements := map[string][]string{"params.list": {"a", "b"}}
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"${params.list}", []string{"a", "b"}},
+		{"--${params.foo}", []string{"--bar"}},
+		{"--${params.list}", []string{"--${params.list}"}},
+	}
+	for _, tt := range tests {
+		got := templating.ApplyArrayReplacements(tt.input, stringReplacements, arrayReplacements)
+		if d := cmp.Diff(tt.expected, got); d != "" {
+			t.Errorf("ApplyArrayReplacements(%q) diff -want, +got: %v", tt.input, d)
+		}
+	}
+}
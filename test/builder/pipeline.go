@@ -31,6 +31,9 @@ type PipelineSpecOp func(*v1alpha1.PipelineSpec)
 // PipelineParamOp is an operation which modify a PipelineParam struct.
 type PipelineParamOp func(*v1alpha1.PipelineParam)
 
+// PipelineResultOp is an operation which modify a PipelineResult struct.
+type PipelineResultOp func(*v1alpha1.PipelineResult)
+
 // PipelineTaskOp is an operation which modify a PipelineTask struct.
 type PipelineTaskOp func(*v1alpha1.PipelineTask)
 
@@ -49,6 +52,9 @@ type PipelineResourceBindingOp func(*v1alpha1.PipelineResourceBinding)
 // PipelineResourceSpecOp is an operation which modify a PipelineResourceSpec struct.
 type PipelineResourceSpecOp func(*v1alpha1.PipelineResourceSpec)
 
+// PipelineTaskConditionOp is an operation which modifies a PipelineTaskCondition.
+type PipelineTaskConditionOp func(*v1alpha1.PipelineTaskCondition)
+
 // PipelineTaskInputResourceOp is an operation which modifies a PipelineTaskInputResource.
 type PipelineTaskInputResourceOp func(*v1alpha1.PipelineTaskInputResource)
 
@@ -91,6 +97,11 @@ func PipelineRunCancelled(spec *v1alpha1.PipelineRunSpec) {
 	spec.Status = v1alpha1.PipelineRunSpecStatusCancelled
 }
 
+// PipelineRunPaused sets the status to pause to the PipelineRunSpec.
+func PipelineRunPaused(spec *v1alpha1.PipelineRunSpec) {
+	spec.Status = v1alpha1.PipelineRunSpecStatusPaused
+}
+
 // PipelineDeclaredResource adds a resource declaration to the Pipeline Spec,
 // with the specified name and type.
 func PipelineDeclaredResource(name string, t v1alpha1.PipelineResourceType) PipelineSpecOp {
@@ -122,10 +133,54 @@ func PipelineParamDescription(desc string) PipelineParamOp {
 	}
 }
 
-// PipelineParamDefault sets the default value to the PipelineParam.
-func PipelineParamDefault(value string) PipelineParamOp {
+// PipelineParamType sets the type to the PipelineParam.
+func PipelineParamType(paramType v1alpha1.ParamType) PipelineParamOp {
+	return func(pp *v1alpha1.PipelineParam) {
+		pp.Type = paramType
+	}
+}
+
+// PipelineParamDefault sets the default value to the PipelineParam. The default value is
+// an array if additionalValues are given, and a string otherwise.
+func PipelineParamDefault(value string, additionalValues ...string) PipelineParamOp {
 	return func(pp *v1alpha1.PipelineParam) {
-		pp.Default = value
+		pp.Default = v1alpha1.NewArrayOrString(value, additionalValues...)
+	}
+}
+
+// PipelineResult adds a PipelineResult, with specified name and value, to the PipelineSpec.
+// Any number of PipelineResult modifier can be passed to transform it.
+func PipelineResult(name, value string, ops ...PipelineResultOp) PipelineSpecOp {
+	return func(ps *v1alpha1.PipelineSpec) {
+		r := &v1alpha1.PipelineResult{Name: name, Value: value}
+		for _, op := range ops {
+			op(r)
+		}
+		ps.Results = append(ps.Results, *r)
+	}
+}
+
+// PipelineResultDescription sets the description to the PipelineResult.
+func PipelineResultDescription(desc string) PipelineResultOp {
+	return func(r *v1alpha1.PipelineResult) {
+		r.Description = desc
+	}
+}
+
+// PipelineFailurePolicy sets what happens once one of the tasks of the PipelineSpec has failed.
+func PipelineFailurePolicy(policy v1alpha1.FailurePolicy) PipelineSpecOp {
+	return func(ps *v1alpha1.PipelineSpec) {
+		ps.FailurePolicy = policy
+	}
+}
+
+// PipelineWorkspace adds a workspace, with specified name and description, to the PipelineSpec.
+func PipelineWorkspace(name, description string) PipelineSpecOp {
+	return func(ps *v1alpha1.PipelineSpec) {
+		ps.Workspaces = append(ps.Workspaces, v1alpha1.PipelineWorkspaceDeclaration{
+			Name:        name,
+			Description: description,
+		})
 	}
 }
 
@@ -146,6 +201,23 @@ func PipelineTask(name, taskName string, ops ...PipelineTaskOp) PipelineSpecOp {
 	}
 }
 
+// PipelineFinallyTask adds a finally PipelineTask, with specified name and task name, to the
+// PipelineSpec. Any number of PipelineTask modifier can be passed to transform it.
+func PipelineFinallyTask(name, taskName string, ops ...PipelineTaskOp) PipelineSpecOp {
+	return func(ps *v1alpha1.PipelineSpec) {
+		pTask := &v1alpha1.PipelineTask{
+			Name: name,
+			TaskRef: v1alpha1.TaskRef{
+				Name: taskName,
+			},
+		}
+		for _, op := range ops {
+			op(pTask)
+		}
+		ps.Finally = append(ps.Finally, *pTask)
+	}
+}
+
 // RunAfter will update the provided Pipeline Task to indicate that it
 // should be run after the provided list of Pipeline Task names.
 func RunAfter(tasks ...string) PipelineTaskOp {
@@ -154,6 +226,32 @@ func RunAfter(tasks ...string) PipelineTaskOp {
 	}
 }
 
+// Retries sets the number of times the TaskRun created for the PipelineTask is retried.
+func Retries(retries int) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.Retries = retries
+	}
+}
+
+// PipelineTaskTimeout sets the timeout of the TaskRun created for the PipelineTask.
+func PipelineTaskTimeout(duration time.Duration) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.Timeout = &metav1.Duration{Duration: duration}
+	}
+}
+
+// PipelineTaskSpec sets the embedded TaskSpec, built from the specified TaskSpec modifiers,
+// to the PipelineTask.
+func PipelineTaskSpec(ops ...TaskSpecOp) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		taskSpec := &v1alpha1.TaskSpec{}
+		for _, op := range ops {
+			op(taskSpec)
+		}
+		pt.TaskSpec = taskSpec
+	}
+}
+
 // PipelineTaskRefKind sets the TaskKind to the PipelineTaskRef.
 func PipelineTaskRefKind(kind v1alpha1.TaskKind) PipelineTaskOp {
 	return func(pt *v1alpha1.PipelineTask) {
@@ -161,16 +259,95 @@ func PipelineTaskRefKind(kind v1alpha1.TaskKind) PipelineTaskOp {
 	}
 }
 
+// PipelineTaskRefCustomTask sets the apiVersion and the kind of the custom task
+// referenced by the PipelineTaskRef.
+func PipelineTaskRefCustomTask(apiVersion, kind string) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.TaskRef.APIVersion = apiVersion
+		pt.TaskRef.Kind = v1alpha1.TaskKind(kind)
+	}
+}
+
 // PipelineTaskParam adds a Param, with specified name and value, to the PipelineTask.
-func PipelineTaskParam(name, value string) PipelineTaskOp {
+// The value is an array if additionalValues are given, and a string otherwise.
+func PipelineTaskParam(name, value string, additionalValues ...string) PipelineTaskOp {
 	return func(pt *v1alpha1.PipelineTask) {
 		pt.Params = append(pt.Params, v1alpha1.Param{
 			Name:  name,
-			Value: value,
+			Value: *v1alpha1.NewArrayOrString(value, additionalValues...),
+		})
+	}
+}
+
+// PipelineTaskMatrix adds a parameter, with specified name and values, to the matrix
+// of the PipelineTask: a TaskRun is created for each combination of these values.
+func PipelineTaskMatrix(name string, values ...string) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.Matrix = append(pt.Matrix, v1alpha1.Param{
+			Name: name,
+			Value: v1alpha1.ArrayOrString{
+				Type:     v1alpha1.ParamTypeArray,
+				ArrayVal: values,
+			},
 		})
 	}
 }
 
+// PipelineTaskApproval gates the PipelineTask behind an approval, which can be given
+// by any of the specified approvers.
+func PipelineTaskApproval(approvers ...string) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.Approval = &v1alpha1.PipelineTaskApproval{Approvers: approvers}
+	}
+}
+
+// PipelineTaskWorkspace maps the workspace, with specified name, of the Task of the
+// PipelineTask to the workspace of the Pipeline with the specified name.
+func PipelineTaskWorkspace(name, workspace string) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		pt.Workspaces = append(pt.Workspaces, v1alpha1.WorkspacePipelineTaskBinding{
+			Name:      name,
+			Workspace: workspace,
+		})
+	}
+}
+
+// PipelineTaskCondition adds a condition, with specified name, to the PipelineTask.
+// Any number of PipelineTaskCondition modifiers can be passed to transform it.
+func PipelineTaskCondition(name string, ops ...PipelineTaskConditionOp) PipelineTaskOp {
+	return func(pt *v1alpha1.PipelineTask) {
+		c := &v1alpha1.PipelineTaskCondition{Name: name}
+		for _, op := range ops {
+			op(c)
+		}
+		pt.Conditions = append(pt.Conditions, *c)
+	}
+}
+
+// ConditionCheck sets the check container, with the specified image, of the PipelineTaskCondition.
+// Any number of Container modifiers can be passed to transform it.
+func ConditionCheck(image string, ops ...ContainerOp) PipelineTaskConditionOp {
+	return func(c *v1alpha1.PipelineTaskCondition) {
+		check := &corev1.Container{Name: c.Name, Image: image}
+		for _, op := range ops {
+			op(check)
+		}
+		c.Check = check
+	}
+}
+
+// ConditionExpression sets the expression, comparing input to values with operator,
+// of the PipelineTaskCondition.
+func ConditionExpression(input string, operator v1alpha1.ConditionOperator, values ...string) PipelineTaskConditionOp {
+	return func(c *v1alpha1.PipelineTaskCondition) {
+		c.Expression = &v1alpha1.ConditionExpression{
+			Input:    input,
+			Operator: operator,
+			Values:   values,
+		}
+	}
+}
+
 // From will update the provided PipelineTaskInputResource to indicate that it
 // should come from tasks.
 func From(tasks ...string) PipelineTaskInputResourceOp {
@@ -251,6 +428,18 @@ func PipelineRunSpec(name string, ops ...PipelineRunSpecOp) PipelineRunOp {
 	}
 }
 
+// PipelineRunPipelineSpec sets the embedded PipelineSpec, built from the specified
+// PipelineSpec modifiers, to the PipelineRunSpec.
+func PipelineRunPipelineSpec(ops ...PipelineSpecOp) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		ps := &v1alpha1.PipelineSpec{}
+		for _, op := range ops {
+			op(ps)
+		}
+		prs.PipelineSpec = ps
+	}
+}
+
 // PipelineRunLabels adds a label to the PipelineRun.
 func PipelineRunLabel(key, value string) PipelineRunOp {
 	return func(pr *v1alpha1.PipelineRun) {
@@ -284,6 +473,19 @@ func PipelineResourceBindingRef(name string) PipelineResourceBindingOp {
 	}
 }
 
+// PipelineResourceBindingResourceSpec binds the resource to the ResourceSpec, with specified type,
+// instead of a reference. Any number of PipelineResourceSpec modifier can be passed to transform it.
+func PipelineResourceBindingResourceSpec(resourceType v1alpha1.PipelineResourceType, ops ...PipelineResourceSpecOp) PipelineResourceBindingOp {
+	return func(b *v1alpha1.PipelineResourceBinding) {
+		spec := &v1alpha1.PipelineResourceSpec{Type: resourceType}
+		for _, op := range ops {
+			op(spec)
+		}
+		b.ResourceRef = v1alpha1.PipelineResourceRef{}
+		b.ResourceSpec = spec
+	}
+}
+
 // PipelineRunServiceAccount sets the service account to the PipelineRunSpec.
 func PipelineRunServiceAccount(sa string) PipelineRunSpecOp {
 	return func(prs *v1alpha1.PipelineRunSpec) {
@@ -291,16 +493,47 @@ func PipelineRunServiceAccount(sa string) PipelineRunSpecOp {
 	}
 }
 
+// PipelineRunServiceAccountTask maps the service account to the PipelineTask named
+// taskName in the PipelineRunSpec.
+func PipelineRunServiceAccountTask(taskName, sa string) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.ServiceAccounts = append(prs.ServiceAccounts, v1alpha1.PipelineRunSpecServiceAccount{
+			TaskName:       taskName,
+			ServiceAccount: sa,
+		})
+	}
+}
+
 // PipelineRunParam add a param, with specified name and value, to the PipelineRunSpec.
-func PipelineRunParam(name, value string) PipelineRunSpecOp {
+// The value is an array if additionalValues are given, and a string otherwise.
+func PipelineRunParam(name, value string, additionalValues ...string) PipelineRunSpecOp {
 	return func(prs *v1alpha1.PipelineRunSpec) {
 		prs.Params = append(prs.Params, v1alpha1.Param{
 			Name:  name,
-			Value: value,
+			Value: *v1alpha1.NewArrayOrString(value, additionalValues...),
+		})
+	}
+}
+
+// PipelineRunApproval adds the decision of the approver about the PipelineTask, with
+// specified name, to the PipelineRunSpec.
+func PipelineRunApproval(pipelineTask string, decision v1alpha1.ApprovalDecision, approver string) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.Approvals = append(prs.Approvals, v1alpha1.PipelineRunApproval{
+			PipelineTask: pipelineTask,
+			Decision:     decision,
+			Approver:     approver,
 		})
 	}
 }
 
+// PipelineRunRerunOf sets the name of the previous PipelineRun rerun by the PipelineRunSpec.
+func PipelineRunRerunOf(name string) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.RerunOf = &v1alpha1.PipelineRunRef{Name: name}
+	}
+}
+
 // PipelineRunTimeout sets the timeout to the PipelineSpec.
 func PipelineRunTimeout(duration *metav1.Duration) PipelineRunSpecOp {
 	return func(prs *v1alpha1.PipelineRunSpec) {
@@ -329,6 +562,21 @@ func PipelineRunAffinity(affinity *corev1.Affinity) PipelineRunSpecOp {
 	}
 }
 
+// PipelineRunPodTemplate sets the PodTemplate to the PipelineRunSpec.
+func PipelineRunPodTemplate(template *v1alpha1.PodTemplate) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.PodTemplate = template
+	}
+}
+
+// PipelineRunWorkspace adds a binding of the workspace with the specified name to the
+// PipelineRunSpec. Any number of WorkspaceBinding modifier can be passed to transform it.
+func PipelineRunWorkspace(name string, ops ...WorkspaceBindingOp) PipelineRunSpecOp {
+	return func(prs *v1alpha1.PipelineRunSpec) {
+		prs.Workspaces = append(prs.Workspaces, workspaceBinding(name, ops...))
+	}
+}
+
 // PipelineRunStatus sets the PipelineRunStatus to the PipelineRun.
 // Any number of PipelineRunStatus modifier can be passed to transform it.
 func PipelineRunStatus(ops ...PipelineRunStatusOp) PipelineRunOp {
@@ -355,6 +603,30 @@ func PipelineRunStartTime(startTime time.Time) PipelineRunStatusOp {
 	}
 }
 
+// PipelineRunPausedTime sets the time at which the PipelineRun was paused to the PipelineRunStatus.
+func PipelineRunPausedTime(pausedTime time.Time) PipelineRunStatusOp {
+	return func(s *v1alpha1.PipelineRunStatus) {
+		s.PausedTime = &metav1.Time{Time: pausedTime}
+	}
+}
+
+// PipelineRunPausedDuration sets the time the PipelineRun spent paused to the PipelineRunStatus.
+func PipelineRunPausedDuration(duration time.Duration) PipelineRunStatusOp {
+	return func(s *v1alpha1.PipelineRunStatus) {
+		s.PausedDuration = &metav1.Duration{Duration: duration}
+	}
+}
+
+// PipelineRunSkippedTask adds a SkippedTask, with the specified name and reason, to the PipelineRunStatus.
+func PipelineRunSkippedTask(name, reason string) PipelineRunStatusOp {
+	return func(s *v1alpha1.PipelineRunStatus) {
+		s.SkippedTasks = append(s.SkippedTasks, v1alpha1.SkippedTask{
+			Name:   name,
+			Reason: reason,
+		})
+	}
+}
+
 // PipelineRunTaskRunsStatus sets the TaskRuns of the PipelineRunStatus.
 func PipelineRunTaskRunsStatus(taskRuns map[string]*v1alpha1.PipelineRunTaskRunStatus) PipelineRunStatusOp {
 	return func(s *v1alpha1.PipelineRunStatus) {
@@ -362,6 +634,27 @@ func PipelineRunTaskRunsStatus(taskRuns map[string]*v1alpha1.PipelineRunTaskRunS
 	}
 }
 
+// PipelineRunPendingApproval adds the PipelineTask, with specified name, to the
+// PipelineTasks waiting for the approval of one of the approvers.
+func PipelineRunPendingApproval(pipelineTask string, approvers ...string) PipelineRunStatusOp {
+	return func(s *v1alpha1.PipelineRunStatus) {
+		s.PendingApprovals = append(s.PendingApprovals, v1alpha1.PipelineRunPendingApproval{
+			PipelineTask: pipelineTask,
+			Approvers:    approvers,
+		})
+	}
+}
+
+// PipelineRunResult adds the value of the result with the specified name to the PipelineRunStatus.
+func PipelineRunResult(name, value string) PipelineRunStatusOp {
+	return func(s *v1alpha1.PipelineRunStatus) {
+		s.PipelineResults = append(s.PipelineResults, v1alpha1.PipelineRunResult{
+			Name:  name,
+			Value: value,
+		})
+	}
+}
+
 // PipelineResource creates a PipelineResource with default values.
 // Any number of PipelineResource modifier can be passed to transform it.
 func PipelineResource(name, namespace string, ops ...PipelineResourceOp) *v1alpha1.PipelineResource {
@@ -393,7 +686,7 @@ func PipelineResourceSpec(resourceType v1alpha1.PipelineResourceType, ops ...Pip
 // PipelineResourceSpecParam adds a Param, with specified name and value, to the PipelineResourceSpec.
 func PipelineResourceSpecParam(name, value string) PipelineResourceSpecOp {
 	return func(spec *v1alpha1.PipelineResourceSpec) {
-		spec.Params = append(spec.Params, v1alpha1.Param{
+		spec.Params = append(spec.Params, v1alpha1.ResourceParam{
 			Name:  name,
 			Value: value,
 		})
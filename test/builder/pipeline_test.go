@@ -22,6 +22,7 @@ import (
 	duckv1beta1 "github.com/knative/pkg/apis/duck/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	tb "github.com/tektoncd/pipeline/test/builder"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -30,8 +31,10 @@ func TestPipeline(t *testing.T) {
 		tb.PipelineDeclaredResource("my-only-git-resource", "git"),
 		tb.PipelineDeclaredResource("my-only-image-resource", "image"),
 		tb.PipelineParam("first-param", tb.PipelineParamDefault("default-value"), tb.PipelineParamDescription("default description")),
+		tb.PipelineWorkspace("shared", "the shared source"),
 		tb.PipelineTask("foo", "banana",
 			tb.PipelineTaskParam("name", "value"),
+			tb.PipelineTaskWorkspace("source", "shared"),
 		),
 		tb.PipelineTask("bar", "chocolate",
 			tb.PipelineTaskRefKind(v1alpha1.ClusterTaskKind),
@@ -41,6 +44,7 @@ func TestPipeline(t *testing.T) {
 		tb.PipelineTask("never-gonna", "give-you-up",
 			tb.RunAfter("foo"),
 		),
+		tb.PipelineResult("digest", "${tasks.foo.results.digest}", tb.PipelineResultDescription("the digest")),
 	))
 	expectedPipeline := &v1alpha1.Pipeline{
 		ObjectMeta: metav1.ObjectMeta{Name: "tomatoes", Namespace: "foo"},
@@ -54,13 +58,17 @@ func TestPipeline(t *testing.T) {
 			}},
 			Params: []v1alpha1.PipelineParam{{
 				Name:        "first-param",
-				Default:     "default-value",
+				Default:     v1alpha1.NewArrayOrString("default-value"),
 				Description: "default description",
 			}},
 			Tasks: []v1alpha1.PipelineTask{{
 				Name:    "foo",
 				TaskRef: v1alpha1.TaskRef{Name: "banana"},
-				Params:  []v1alpha1.Param{{Name: "name", Value: "value"}},
+				Params:  []v1alpha1.Param{{Name: "name", Value: *v1alpha1.NewArrayOrString("value")}},
+				Workspaces: []v1alpha1.WorkspacePipelineTaskBinding{{
+					Name:      "source",
+					Workspace: "shared",
+				}},
 			}, {
 				Name:    "bar",
 				TaskRef: v1alpha1.TaskRef{Name: "chocolate", Kind: v1alpha1.ClusterTaskKind},
@@ -80,6 +88,15 @@ func TestPipeline(t *testing.T) {
 				TaskRef:  v1alpha1.TaskRef{Name: "give-you-up"},
 				RunAfter: []string{"foo"},
 			}},
+			Results: []v1alpha1.PipelineResult{{
+				Name:        "digest",
+				Description: "the digest",
+				Value:       "${tasks.foo.results.digest}",
+			}},
+			Workspaces: []v1alpha1.PipelineWorkspaceDeclaration{{
+				Name:        "shared",
+				Description: "the shared source",
+			}},
 		},
 	}
 	if d := cmp.Diff(expectedPipeline, pipeline); d != "" {
@@ -94,6 +111,7 @@ func TestPipelineRun(t *testing.T) {
 		tb.PipelineRunParam("first-param", "first-value"),
 		tb.PipelineRunTimeout(&metav1.Duration{Duration: 1 * time.Hour}),
 		tb.PipelineRunResourceBinding("some-resource", tb.PipelineResourceBindingRef("my-special-resource")),
+		tb.PipelineRunWorkspace("shared", tb.WorkspaceBindingSecret("my-secret")),
 	), tb.PipelineRunStatus(tb.PipelineRunStatusCondition(apis.Condition{
 		Type: apis.ConditionSucceeded,
 	}), tb.PipelineRunStartTime(startTime),
@@ -112,7 +130,7 @@ func TestPipelineRun(t *testing.T) {
 			ServiceAccount: "sa",
 			Params: []v1alpha1.Param{{
 				Name:  "first-param",
-				Value: "first-value",
+				Value: *v1alpha1.NewArrayOrString("first-value"),
 			}},
 			Timeout: &metav1.Duration{Duration: 1 * time.Hour},
 			Resources: []v1alpha1.PipelineResourceBinding{{
@@ -121,6 +139,10 @@ func TestPipelineRun(t *testing.T) {
 					Name: "my-special-resource",
 				},
 			}},
+			Workspaces: []v1alpha1.WorkspaceBinding{{
+				Name:   "shared",
+				Secret: &corev1.SecretVolumeSource{SecretName: "my-secret"},
+			}},
 		},
 		Status: v1alpha1.PipelineRunStatus{
 			Status: duckv1beta1.Status{
@@ -142,7 +164,7 @@ func TestPipelineResource(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{Name: "git-resource", Namespace: "foo"},
 		Spec: v1alpha1.PipelineResourceSpec{
 			Type: v1alpha1.PipelineResourceTypeGit,
-			Params: []v1alpha1.Param{{
+			Params: []v1alpha1.ResourceParam{{
 				Name: "URL", Value: "https://foo.git",
 			}},
 		},
@@ -74,6 +74,9 @@ type StepStateOp func(*v1alpha1.StepState)
 // VolumeOp is an operation which modify a Volume struct.
 type VolumeOp func(*corev1.Volume)
 
+// WorkspaceBindingOp is an operation which modify a WorkspaceBinding struct.
+type WorkspaceBindingOp func(*v1alpha1.WorkspaceBinding)
+
 var (
 	trueB = true
 )
@@ -140,7 +143,7 @@ func TaskSpec(ops ...TaskSpecOp) TaskOp {
 func Step(name, image string, ops ...ContainerOp) TaskSpecOp {
 	return func(spec *v1alpha1.TaskSpec) {
 		if spec.Steps == nil {
-			spec.Steps = []corev1.Container{}
+			spec.Steps = []v1alpha1.Step{}
 		}
 		step := &corev1.Container{
 			Name:  name,
@@ -149,7 +152,37 @@ func Step(name, image string, ops ...ContainerOp) TaskSpecOp {
 		for _, op := range ops {
 			op(step)
 		}
-		spec.Steps = append(spec.Steps, *step)
+		spec.Steps = append(spec.Steps, v1alpha1.Step{Container: *step})
+	}
+}
+
+// ScriptStep adds a step with the specified name and image, which runs script, to the
+// TaskSpec. Any number of Container modifier can be passed to transform it.
+func ScriptStep(name, image, script string, ops ...ContainerOp) TaskSpecOp {
+	return func(spec *v1alpha1.TaskSpec) {
+		step := &corev1.Container{
+			Name:  name,
+			Image: image,
+		}
+		for _, op := range ops {
+			op(step)
+		}
+		spec.Steps = append(spec.Steps, v1alpha1.Step{Container: *step, Script: script})
+	}
+}
+
+// Sidecar adds a sidecar container with the specified name and image to the TaskSpec.
+// Any number of Container modifier can be passed to transform it.
+func Sidecar(name, image string, ops ...ContainerOp) TaskSpecOp {
+	return func(spec *v1alpha1.TaskSpec) {
+		sidecar := &corev1.Container{
+			Name:  name,
+			Image: image,
+		}
+		for _, op := range ops {
+			op(sidecar)
+		}
+		spec.Sidecars = append(spec.Sidecars, *sidecar)
 	}
 }
 
@@ -165,6 +198,29 @@ func TaskVolume(name string, ops ...VolumeOp) TaskSpecOp {
 	}
 }
 
+// TaskResult adds a result with the specified name and description to the TaskSpec.
+func TaskResult(name, description string) TaskSpecOp {
+	return func(spec *v1alpha1.TaskSpec) {
+		spec.Results = append(spec.Results, v1alpha1.TaskResult{
+			Name:        name,
+			Description: description,
+		})
+	}
+}
+
+// TaskWorkspace adds a workspace with the specified name, description, mount path and
+// read-only flag to the TaskSpec.
+func TaskWorkspace(name, description, mountPath string, readOnly bool) TaskSpecOp {
+	return func(spec *v1alpha1.TaskSpec) {
+		spec.Workspaces = append(spec.Workspaces, v1alpha1.WorkspaceDeclaration{
+			Name:        name,
+			Description: description,
+			MountPath:   mountPath,
+			ReadOnly:    readOnly,
+		})
+	}
+}
+
 // VolumeSource sets the VolumeSource to the Volume.
 func VolumeSource(s corev1.VolumeSource) VolumeOp {
 	return func(v *corev1.Volume) {
@@ -242,10 +298,18 @@ func ParamDescription(desc string) TaskParamOp {
 	}
 }
 
-// ParamDefault sets the default value to the TaskParam.
-func ParamDefault(value string) TaskParamOp {
+// ParamType sets the type to the TaskParam.
+func ParamType(paramType v1alpha1.ParamType) TaskParamOp {
 	return func(tp *v1alpha1.TaskParam) {
-		tp.Default = value
+		tp.Type = paramType
+	}
+}
+
+// ParamDefault sets the default value to the TaskParam. The default value is an array
+// if additionalValues are given, and a string otherwise.
+func ParamDefault(value string, additionalValues ...string) TaskParamOp {
+	return func(tp *v1alpha1.TaskParam) {
+		tp.Default = v1alpha1.NewArrayOrString(value, additionalValues...)
 	}
 }
 
@@ -316,6 +380,13 @@ func TaskRunTimeout(d time.Duration) TaskRunSpecOp {
 	}
 }
 
+// TaskRunRetries sets the number of times the TaskRun is retried after it fails.
+func TaskRunRetries(retries int) TaskRunSpecOp {
+	return func(spec *v1alpha1.TaskRunSpec) {
+		spec.Retries = retries
+	}
+}
+
 // TaskRunNodeSelector sets the NodeSelector to the PipelineSpec.
 func TaskRunNodeSelector(values map[string]string) TaskRunSpecOp {
 	return func(spec *v1alpha1.TaskRunSpec) {
@@ -337,6 +408,96 @@ func TaskRunAffinity(affinity *corev1.Affinity) TaskRunSpecOp {
 	}
 }
 
+// TaskRunPodTemplate sets the PodTemplate to the TaskRunSpec.
+func TaskRunPodTemplate(template *v1alpha1.PodTemplate) TaskRunSpecOp {
+	return func(spec *v1alpha1.TaskRunSpec) {
+		spec.PodTemplate = template
+	}
+}
+
+// TaskRunWorkspace adds a binding of the workspace with the specified name to the TaskRunSpec.
+// Any number of WorkspaceBinding modifier can be passed to transform it.
+func TaskRunWorkspace(name string, ops ...WorkspaceBindingOp) TaskRunSpecOp {
+	return func(spec *v1alpha1.TaskRunSpec) {
+		spec.Workspaces = append(spec.Workspaces, workspaceBinding(name, ops...))
+	}
+}
+
+func workspaceBinding(name string, ops ...WorkspaceBindingOp) v1alpha1.WorkspaceBinding {
+	b := &v1alpha1.WorkspaceBinding{Name: name}
+	for _, op := range ops {
+		op(b)
+	}
+	return *b
+}
+
+// WorkspaceBindingSubPath sets the sub path of the volume to use for the WorkspaceBinding.
+func WorkspaceBindingSubPath(subPath string) WorkspaceBindingOp {
+	return func(b *v1alpha1.WorkspaceBinding) {
+		b.SubPath = subPath
+	}
+}
+
+// WorkspaceBindingPersistentVolumeClaim binds the WorkspaceBinding to the claim with the
+// specified name.
+func WorkspaceBindingPersistentVolumeClaim(claimName string) WorkspaceBindingOp {
+	return func(b *v1alpha1.WorkspaceBinding) {
+		b.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: claimName}
+	}
+}
+
+// WorkspaceBindingVolumeClaimTemplate binds the WorkspaceBinding to a claim created from
+// the specified template.
+func WorkspaceBindingVolumeClaimTemplate(claim *corev1.PersistentVolumeClaim) WorkspaceBindingOp {
+	return func(b *v1alpha1.WorkspaceBinding) {
+		b.VolumeClaimTemplate = claim
+	}
+}
+
+// WorkspaceBindingEmptyDir binds the WorkspaceBinding to an empty directory.
+func WorkspaceBindingEmptyDir(b *v1alpha1.WorkspaceBinding) {
+	b.EmptyDir = &corev1.EmptyDirVolumeSource{}
+}
+
+// WorkspaceBindingConfigMap binds the WorkspaceBinding to the ConfigMap with the specified name.
+func WorkspaceBindingConfigMap(name string) WorkspaceBindingOp {
+	return func(b *v1alpha1.WorkspaceBinding) {
+		b.ConfigMap = &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+		}
+	}
+}
+
+// WorkspaceBindingSecret binds the WorkspaceBinding to the Secret with the specified name.
+func WorkspaceBindingSecret(name string) WorkspaceBindingOp {
+	return func(b *v1alpha1.WorkspaceBinding) {
+		b.Secret = &corev1.SecretVolumeSource{SecretName: name}
+	}
+}
+
+// TaskRunRetriesStatus adds a TaskRunStatus, built from the specified TaskRunStatus modifiers,
+// to the RetriesStatus of the TaskRunStatus.
+func TaskRunRetriesStatus(ops ...TaskRunStatusOp) TaskRunStatusOp {
+	return func(s *v1alpha1.TaskRunStatus) {
+		retry := &v1alpha1.TaskRunStatus{}
+		for _, op := range ops {
+			op(retry)
+		}
+		s.RetriesStatus = append(s.RetriesStatus, *retry)
+	}
+}
+
+// TaskRunTaskResult adds the value reported for the result with the specified name
+// to the TaskRunStatus.
+func TaskRunTaskResult(name, value string) TaskRunStatusOp {
+	return func(s *v1alpha1.TaskRunStatus) {
+		s.TaskResults = append(s.TaskResults, v1alpha1.TaskRunResult{
+			Name:  name,
+			Value: value,
+		})
+	}
+}
+
 // StateTerminated set Terminated to the StepState.
 func StateTerminated(exitcode int) StepStateOp {
 	return func(s *v1alpha1.StepState) {
@@ -462,11 +623,12 @@ func TaskRunInputs(ops ...TaskRunInputsOp) TaskRunSpecOp {
 }
 
 // TaskRunInputsParam add a param, with specified name and value, to the TaskRunInputs.
-func TaskRunInputsParam(name, value string) TaskRunInputsOp {
+// The value is an array if additionalValues are given, and a string otherwise.
+func TaskRunInputsParam(name, value string, additionalValues ...string) TaskRunInputsOp {
 	return func(i *v1alpha1.TaskRunInputs) {
 		i.Params = append(i.Params, v1alpha1.Param{
 			Name:  name,
-			Value: value,
+			Value: *v1alpha1.NewArrayOrString(value, additionalValues...),
 		})
 	}
 }
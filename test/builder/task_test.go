@@ -41,23 +41,36 @@ func TestTask(t *testing.T) {
 		tb.TaskInputs(
 			tb.InputsResource("workspace", v1alpha1.PipelineResourceTypeGit, tb.ResourceTargetPath("/foo/bar")),
 			tb.InputsParam("param", tb.ParamDescription("mydesc"), tb.ParamDefault("default")),
+			tb.InputsParam("array-param", tb.ParamDescription("mydesc"), tb.ParamType(v1alpha1.ParamTypeArray), tb.ParamDefault("array", "values")),
 		),
 		tb.TaskOutputs(tb.OutputsResource("myotherimage", v1alpha1.PipelineResourceTypeImage)),
 		tb.Step("mycontainer", "myimage", tb.Command("/mycmd"), tb.Args(
 			"--my-other-arg=${inputs.resources.workspace.url}",
 		)),
+		tb.ScriptStep("myscript", "python", "#!/usr/bin/env python\nprint('hello')"),
+		tb.Sidecar("database", "postgres", tb.EnvVar("POSTGRES_PASSWORD", "secret")),
 		tb.TaskVolume("foo", tb.VolumeSource(corev1.VolumeSource{
 			HostPath: &corev1.HostPathVolumeSource{Path: "/foo/bar"},
 		})),
+		tb.TaskResult("digest", "the digest of the image"),
+		tb.TaskWorkspace("source", "the source", "/src", true),
 	))
 	expectedTask := &v1alpha1.Task{
 		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "foo"},
 		Spec: v1alpha1.TaskSpec{
-			Steps: []corev1.Container{{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
 				Name:    "mycontainer",
 				Image:   "myimage",
 				Command: []string{"/mycmd"},
 				Args:    []string{"--my-other-arg=${inputs.resources.workspace.url}"},
+			}}, {
+				Container: corev1.Container{Name: "myscript", Image: "python"},
+				Script:    "#!/usr/bin/env python\nprint('hello')",
+			}},
+			Sidecars: []corev1.Container{{
+				Name:  "database",
+				Image: "postgres",
+				Env:   []corev1.EnvVar{{Name: "POSTGRES_PASSWORD", Value: "secret"}},
 			}},
 			Inputs: &v1alpha1.Inputs{
 				Resources: []v1alpha1.TaskResource{{
@@ -65,7 +78,16 @@ func TestTask(t *testing.T) {
 					Type:       v1alpha1.PipelineResourceTypeGit,
 					TargetPath: "/foo/bar",
 				}},
-				Params: []v1alpha1.TaskParam{{Name: "param", Description: "mydesc", Default: "default"}},
+				Params: []v1alpha1.TaskParam{{
+					Name:        "param",
+					Description: "mydesc",
+					Default:     v1alpha1.NewArrayOrString("default"),
+				}, {
+					Name:        "array-param",
+					Type:        v1alpha1.ParamTypeArray,
+					Description: "mydesc",
+					Default:     &v1alpha1.ArrayOrString{Type: v1alpha1.ParamTypeArray, ArrayVal: []string{"array", "values"}},
+				}},
 			},
 			Outputs: &v1alpha1.Outputs{
 				Resources: []v1alpha1.TaskResource{{
@@ -79,6 +101,16 @@ func TestTask(t *testing.T) {
 					HostPath: &corev1.HostPathVolumeSource{Path: "/foo/bar"},
 				},
 			}},
+			Results: []v1alpha1.TaskResult{{
+				Name:        "digest",
+				Description: "the digest of the image",
+			}},
+			Workspaces: []v1alpha1.WorkspaceDeclaration{{
+				Name:        "source",
+				Description: "the source",
+				MountPath:   "/src",
+				ReadOnly:    true,
+			}},
 		},
 	}
 	if d := cmp.Diff(expectedTask, task); d != "" {
@@ -95,12 +127,12 @@ func TestClusterTask(t *testing.T) {
 	expectedTask := &v1alpha1.ClusterTask{
 		ObjectMeta: metav1.ObjectMeta{Name: "test-clustertask"},
 		Spec: v1alpha1.TaskSpec{
-			Steps: []corev1.Container{{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
 				Name:    "mycontainer",
 				Image:   "myimage",
 				Command: []string{"/mycmd"},
 				Args:    []string{"--my-other-arg=${inputs.resources.workspace.url}"},
-			}},
+			}}},
 		},
 	}
 	if d := cmp.Diff(expectedTask, task); d != "" {
@@ -138,11 +170,14 @@ func TestTaskRunWitTaskRef(t *testing.T) {
 					tb.TaskResourceBindingPaths("output-folder"),
 				),
 			),
+			tb.TaskRunWorkspace("source", tb.WorkspaceBindingPersistentVolumeClaim("my-pvc"), tb.WorkspaceBindingSubPath("src")),
+			tb.TaskRunWorkspace("cache", tb.WorkspaceBindingEmptyDir),
 		),
 		tb.TaskRunStatus(
 			tb.PodName("my-pod-name"),
 			tb.Condition(apis.Condition{Type: apis.ConditionSucceeded}),
 			tb.StepState(tb.StateTerminated(127)),
+			tb.TaskRunTaskResult("digest", "sha256:abcd"),
 		),
 	)
 	expectedTaskRun := &v1alpha1.TaskRun{
@@ -171,7 +206,7 @@ func TestTaskRunWitTaskRef(t *testing.T) {
 					ResourceSpec: &v1alpha1.PipelineResourceSpec{Type: v1alpha1.PipelineResourceType("cluster")},
 					Paths:        []string{"source-folder"},
 				}},
-				Params: []v1alpha1.Param{{Name: "iparam", Value: "ivalue"}},
+				Params: []v1alpha1.Param{{Name: "iparam", Value: *v1alpha1.NewArrayOrString("ivalue")}},
 			},
 			Outputs: v1alpha1.TaskRunOutputs{
 				Resources: []v1alpha1.TaskResourceBinding{{
@@ -187,6 +222,14 @@ func TestTaskRunWitTaskRef(t *testing.T) {
 				Kind:       v1alpha1.ClusterTaskKind,
 				APIVersion: "a1",
 			},
+			Workspaces: []v1alpha1.WorkspaceBinding{{
+				Name:                  "source",
+				SubPath:               "src",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "my-pvc"},
+			}, {
+				Name:     "cache",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
 		},
 		Status: v1alpha1.TaskRunStatus{
 			PodName: "my-pod-name",
@@ -196,6 +239,7 @@ func TestTaskRunWitTaskRef(t *testing.T) {
 			Steps: []v1alpha1.StepState{{ContainerState: corev1.ContainerState{
 				Terminated: &corev1.ContainerStateTerminated{ExitCode: 127},
 			}}},
+			TaskResults: []v1alpha1.TaskRunResult{{Name: "digest", Value: "sha256:abcd"}},
 		},
 	}
 	if d := cmp.Diff(expectedTaskRun, taskRun); d != "" {
@@ -218,11 +262,11 @@ func TestTaskRunWithTaskSpec(t *testing.T) {
 		},
 		Spec: v1alpha1.TaskRunSpec{
 			TaskSpec: &v1alpha1.TaskSpec{
-				Steps: []corev1.Container{{
+				Steps: []v1alpha1.Step{{Container: corev1.Container{
 					Name:    "step",
 					Image:   "image",
 					Command: []string{"/mycmd"},
-				}},
+				}}},
 			},
 			Trigger: v1alpha1.TaskTrigger{
 				Name: "mytrigger",
@@ -247,11 +291,11 @@ func TestResolvedTaskResources(t *testing.T) {
 	)
 	expectedResolvedTaskResources := &resources.ResolvedTaskResources{
 		TaskSpec: &v1alpha1.TaskSpec{
-			Steps: []corev1.Container{{
+			Steps: []v1alpha1.Step{{Container: corev1.Container{
 				Name:    "step",
 				Image:   "image",
 				Command: []string{"/mycmd"},
-			}},
+			}}},
 		},
 		Inputs: map[string]*v1alpha1.PipelineResource{
 			"foo": {
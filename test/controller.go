@@ -45,6 +45,7 @@ type Data struct {
 	Tasks             []*v1alpha1.Task
 	ClusterTasks      []*v1alpha1.ClusterTask
 	PipelineResources []*v1alpha1.PipelineResource
+	Runs              []*v1alpha1.Run
 	Pods              []*corev1.Pod
 	Namespaces        []*corev1.Namespace
 }
@@ -63,6 +64,7 @@ type Informers struct {
 	Task             informersv1alpha1.TaskInformer
 	ClusterTask      informersv1alpha1.ClusterTaskInformer
 	PipelineResource informersv1alpha1.PipelineResourceInformer
+	Run              informersv1alpha1.RunInformer
 	Pod              coreinformers.PodInformer
 }
 
@@ -96,6 +98,9 @@ func SeedTestData(d Data) (Clients, Informers) {
 	for _, tr := range d.TaskRuns {
 		objs = append(objs, tr)
 	}
+	for _, r := range d.Runs {
+		objs = append(objs, r)
+	}
 
 	kubeObjs := []runtime.Object{}
 	for _, p := range d.Pods {
@@ -118,6 +123,7 @@ func SeedTestData(d Data) (Clients, Informers) {
 		Task:             sharedInformer.Tekton().V1alpha1().Tasks(),
 		ClusterTask:      sharedInformer.Tekton().V1alpha1().ClusterTasks(),
 		PipelineResource: sharedInformer.Tekton().V1alpha1().PipelineResources(),
+		Run:              sharedInformer.Tekton().V1alpha1().Runs(),
 		Pod:              kubeInformer.Core().V1().Pods(),
 	}
 
@@ -139,6 +145,9 @@ func SeedTestData(d Data) (Clients, Informers) {
 	for _, r := range d.PipelineResources {
 		i.PipelineResource.Informer().GetIndexer().Add(r)
 	}
+	for _, r := range d.Runs {
+		i.Run.Informer().GetIndexer().Add(r)
+	}
 	for _, p := range d.Pods {
 		i.Pod.Informer().GetIndexer().Add(p)
 	}
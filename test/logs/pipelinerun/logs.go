@@ -44,18 +44,22 @@ func TailLogs(ctx context.Context, cfg *rest.Config, out io.Writer, name, namesp
 		return err
 	}
 
-	pipelineName := pipelineRun.Spec.PipelineRef.Name
-	if pipelineName == "" {
-		return fmt.Errorf("Expected pipeline ref to be set")
-	}
+	pipelineSpec := pipelineRun.Spec.PipelineSpec
+	if pipelineSpec == nil {
+		pipelineName := pipelineRun.Spec.PipelineRef.Name
+		if pipelineName == "" {
+			return fmt.Errorf("Expected pipeline ref or embedded pipeline spec to be set")
+		}
 
-	pp, err := pclient.Pipelines(namespace).Get(pipelineName, metav1.GetOptions{IncludeUninitialized: true})
-	if err != nil {
-		return err
+		pp, err := pclient.Pipelines(namespace).Get(pipelineName, metav1.GetOptions{IncludeUninitialized: true})
+		if err != nil {
+			return err
+		}
+		pipelineSpec = &pp.Spec
 	}
 
 	var expectedTaskRuns []string
-	for _, pt := range pp.Spec.Tasks {
+	for _, pt := range pipelineSpec.Tasks {
 		expectedTaskRuns = append(expectedTaskRuns, fmt.Sprintf("%s-%s", pipelineRun.Name, pt.Name))
 	}
 